@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"news-app/config"
+	"news-app/internal/adapter/cloudflare"
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/service"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+type gcMediaObject struct {
+	Key          string `json:"key"`
+	Size         int64  `json:"size"`
+	LastModified string `json:"last_modified"`
+	Deleted      bool   `json:"deleted"`
+	Error        string `json:"error,omitempty"`
+}
+
+type gcMediaReport struct {
+	Bucket         string          `json:"bucket"`
+	Prefix         string          `json:"prefix"`
+	MinAge         string          `json:"min_age"`
+	Applied        bool            `json:"applied"`
+	ScannedObjects int             `json:"scanned_objects"`
+	TooRecent      int             `json:"too_recent"`
+	OrphanCount    int             `json:"orphan_count"`
+	OrphanBytes    int64           `json:"orphan_bytes"`
+	Deleted        int             `json:"deleted"`
+	Orphans        []gcMediaObject `json:"orphans"`
+	StartedAt      string          `json:"started_at"`
+	FinishedAt     string          `json:"finished_at"`
+}
+
+var gcMediaCmd = &cobra.Command{
+	Use:   "gc-media",
+	Short: "Find and delete orphaned media files",
+	Long: "Walk the media bucket and report every object that no media, pending upload or content refers to.\n" +
+		"Nothing is deleted unless --apply is given, and objects newer than --min-age are always kept.",
+	Run: func(cmd *cobra.Command, args []string) {
+		apply, _ := cmd.Flags().GetBool("apply")
+		minAge, _ := cmd.Flags().GetDuration("min-age")
+		prefix, _ := cmd.Flags().GetString("prefix")
+
+		if minAge < time.Hour {
+			log.Fatal().Msgf("--min-age must be at least 1h, got %s", minAge)
+		}
+
+		cfg := config.NewConfig()
+		if cfg.R2.PublicUrl == "" {
+			// Keys are matched to references through their public URL.
+			log.Fatal().Msg("CLOUDFLARE_R2_PUBLIC_URL must be set")
+		}
+
+		db, err := cfg.ConnPostgres()
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Failed to connect to database: %v", err)
+		}
+
+		r2Adapter := cloudflare.NewCloudflareR2Adapter(cfg.LoadR2Client(), cfg)
+		mediaRepo := repository.NewMediaRepository(db.DB)
+		imageService := service.NewImageService(r2Adapter, cfg)
+		gcService := service.NewMediaGCService(mediaRepo, r2Adapter, imageService)
+
+		result, err := gcService.CollectOrphans(context.Background(), prefix, minAge, apply)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Failed to collect orphaned media: %v", err)
+		}
+
+		report := gcMediaReport{
+			Bucket:         cfg.R2.Name,
+			Prefix:         result.Prefix,
+			MinAge:         result.MinAge.String(),
+			Applied:        result.Applied,
+			ScannedObjects: result.ScannedObjects,
+			TooRecent:      result.TooRecent,
+			OrphanCount:    len(result.Orphans),
+			OrphanBytes:    result.OrphanBytes,
+			Deleted:        result.Deleted,
+			Orphans:        []gcMediaObject{},
+			StartedAt:      result.StartedAt.Format(time.RFC3339),
+			FinishedAt:     result.FinishedAt.Format(time.RFC3339),
+		}
+		for _, orphan := range result.Orphans {
+			report.Orphans = append(report.Orphans, gcMediaObject{
+				Key:          orphan.Key,
+				Size:         orphan.Size,
+				LastModified: orphan.LastModified.Format(time.RFC3339),
+				Deleted:      orphan.Deleted,
+				Error:        orphan.Error,
+			})
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatal().Err(err).Msg("Failed to write report")
+		}
+
+		if apply && report.Deleted < report.OrphanCount {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	gcMediaCmd.Flags().Bool("apply", false, "delete the orphans instead of only reporting them")
+	gcMediaCmd.Flags().Duration("min-age", 7*24*time.Hour, "only consider objects older than this (at least 1h)")
+	gcMediaCmd.Flags().String("prefix", "", "only scan keys starting with this prefix")
+	rootCmd.AddCommand(gcMediaCmd)
+}
@@ -2,10 +2,12 @@ package config
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/rs/zerolog/log"
 )
 
@@ -24,3 +26,10 @@ func (cfg *Config) LoadAwsConfig() aws.Config {
 	log.Info().Msgf("aws config loaded successfully")
 	return conf
 }
+
+// LoadR2Client returns an S3 client pointed at the account's R2 endpoint.
+func (cfg *Config) LoadR2Client() *s3.Client {
+	return s3.NewFromConfig(cfg.LoadAwsConfig(), func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(fmt.Sprintf("https://%s.r2.cloudflarestorage.com", cfg.R2.AccountID))
+	})
+}
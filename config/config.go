@@ -6,8 +6,16 @@ type App struct {
 	AppPort string `json:"app_port"`
 	AppEnv  string `json:"app_env"`
 
+	SiteName  string `json:"site_name"`
+	PublicUrl string `json:"public_url"`
+
 	JwtSecretKey string `json:"jwt_secret_key"`
 	JwtIssuer    string `json:"jwt_issuer"`
+
+	// ProxyHeader carries the client IP, and is only believed on requests
+	// from one of the TrustedProxies (IPs or CIDR ranges).
+	ProxyHeader    string   `json:"proxy_header"`
+	TrustedProxies []string `json:"trusted_proxies"`
 }
 
 type PsqlDB struct {
@@ -29,10 +37,33 @@ type CloudflareR2 struct {
 	PublicUrl string `json:"public_url"`
 }
 
+type Image struct {
+	Variants  string `json:"variants"`
+	MaxPixels int    `json:"max_pixels"`
+}
+
+type Comment struct {
+	Moderation string `json:"moderation"`
+	MaxLinks   int    `json:"max_links"`
+	Blocklist  string `json:"blocklist"`
+	Words      string `json:"words"`
+}
+
+type Mail struct {
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
+}
+
 type Config struct {
-	App  App
-	Psql PsqlDB
-	R2   CloudflareR2
+	App     App
+	Psql    PsqlDB
+	R2      CloudflareR2
+	Image   Image
+	Comment Comment
+	Mail    Mail
 }
 
 func NewConfig() *Config {
@@ -41,8 +72,14 @@ func NewConfig() *Config {
 			AppPort: viper.GetString("APP_PORT"),
 			AppEnv:  viper.GetString("APP_PORT"),
 
+			SiteName:  viper.GetString("SITE_NAME"),
+			PublicUrl: viper.GetString("PUBLIC_URL"),
+
 			JwtSecretKey: viper.GetString("JWT_SECRET_KEY"),
 			JwtIssuer:    viper.GetString("JWT_ISSUER"),
+
+			ProxyHeader:    viper.GetString("APP_PROXY_HEADER"),
+			TrustedProxies: viper.GetStringSlice("APP_TRUSTED_PROXIES"),
 		},
 		Psql: PsqlDB{
 			Host:      viper.GetString("DATABASE_HOST"),
@@ -61,5 +98,22 @@ func NewConfig() *Config {
 			AccountID: viper.GetString("CLOUDFLARE_R2_ACCOUNT_ID"),
 			PublicUrl: viper.GetString("CLOUDFLARE_R2_PUBLIC_URL"),
 		},
+		Image: Image{
+			Variants:  viper.GetString("IMAGE_VARIANTS"),
+			MaxPixels: viper.GetInt("IMAGE_MAX_PIXELS"),
+		},
+		Comment: Comment{
+			Moderation: viper.GetString("COMMENT_MODERATION"),
+			MaxLinks:   viper.GetInt("COMMENT_MAX_LINKS"),
+			Blocklist:  viper.GetString("COMMENT_BLOCKLIST"),
+			Words:      viper.GetString("COMMENT_BLOCKED_WORDS"),
+		},
+		Mail: Mail{
+			Host:     viper.GetString("SMTP_HOST"),
+			Port:     viper.GetString("SMTP_PORT"),
+			Username: viper.GetString("SMTP_USERNAME"),
+			Password: viper.GetString("SMTP_PASSWORD"),
+			From:     viper.GetString("MAIL_FROM"),
+		},
 	}
 }
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+)
+
+func (cfg *Config) SiteURL() string {
+	return strings.TrimRight(cfg.App.PublicUrl, "/")
+}
+
+func (cfg *Config) ContentURL(slug string) string {
+	return cfg.SiteURL() + "/contents/" + url.PathEscape(slug)
+}
+
+func (cfg *Config) CategoryURL(slug string) string {
+	return cfg.SiteURL() + "/categories/" + url.PathEscape(slug)
+}
+
+func (cfg *Config) TagURL(tag string) string {
+	return cfg.SiteURL() + "/tags/" + url.PathEscape(tag)
+}
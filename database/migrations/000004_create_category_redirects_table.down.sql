@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "category_redirects";
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS "category_redirects" (
+    id SERIAL PRIMARY KEY,
+    old_slug VARCHAR(200) UNIQUE NOT NULL,
+    category_id INT REFERENCES categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_category_redirects_category_id ON category_redirects(category_id);
\ No newline at end of file
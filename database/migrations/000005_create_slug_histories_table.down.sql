@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "category_redirects" (
+    id SERIAL PRIMARY KEY,
+    old_slug VARCHAR(200) UNIQUE NOT NULL,
+    category_id INT REFERENCES categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_category_redirects_category_id ON category_redirects(category_id);
+
+INSERT INTO category_redirects (old_slug, category_id, created_at)
+SELECT slug, entity_id, created_at FROM slug_histories WHERE entity_type = 'categories';
+
+DROP TABLE IF EXISTS "slug_histories";
\ No newline at end of file
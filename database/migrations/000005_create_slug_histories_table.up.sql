@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "slug_histories" (
+    id SERIAL PRIMARY KEY,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id INT NOT NULL,
+    slug VARCHAR(200) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (entity_type, slug)
+);
+
+CREATE INDEX idx_slug_histories_entity ON slug_histories(entity_type, entity_id);
+
+INSERT INTO slug_histories (entity_type, entity_id, slug, created_at)
+SELECT 'categories', category_id, old_slug, created_at FROM category_redirects WHERE category_id IS NOT NULL;
+
+DROP TABLE IF EXISTS "category_redirects";
\ No newline at end of file
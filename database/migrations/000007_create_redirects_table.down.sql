@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "redirects";
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "redirects" (
+    id SERIAL PRIMARY KEY,
+    source_path VARCHAR(255) UNIQUE NOT NULL,
+    target_path VARCHAR(255) NOT NULL,
+    status_code INT NOT NULL DEFAULT 301,
+    hit_count BIGINT NOT NULL DEFAULT 0,
+    created_by_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_redirects_target_path ON redirects(target_path);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_contents_published_at;
+
+ALTER TABLE "contents" DROP COLUMN IF EXISTS published_at;
\ No newline at end of file
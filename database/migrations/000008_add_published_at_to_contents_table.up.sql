@@ -0,0 +1,5 @@
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS published_at TIMESTAMP NULL;
+
+UPDATE contents SET published_at = created_at WHERE status = 'PUBLISH' AND published_at IS NULL;
+
+CREATE INDEX idx_contents_published_at ON contents(published_at) WHERE status = 'PUBLISH';
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS image TEXT NULL;
+
+UPDATE contents SET image = media.url FROM media WHERE media.id = contents.media_id;
+
+DROP INDEX IF EXISTS idx_contents_media_id;
+
+ALTER TABLE "contents" DROP COLUMN IF EXISTS media_id;
+
+DROP TABLE IF EXISTS "media";
\ No newline at end of file
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS "media" (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    mime_type VARCHAR(100) NOT NULL DEFAULT '',
+    size BIGINT NOT NULL DEFAULT 0,
+    width INT NOT NULL DEFAULT 0,
+    height INT NOT NULL DEFAULT 0,
+    alt_text VARCHAR(255) NOT NULL DEFAULT '',
+    caption TEXT NOT NULL DEFAULT '',
+    credit VARCHAR(255) NOT NULL DEFAULT '',
+    copyright VARCHAR(255) NOT NULL DEFAULT '',
+    license VARCHAR(100) NOT NULL DEFAULT '',
+    tags TEXT NOT NULL DEFAULT '',
+    uploaded_by_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_media_url ON media(url);
+CREATE INDEX idx_media_created_at ON media(created_at);
+
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS media_id INT NULL REFERENCES media(id) ON DELETE RESTRICT;
+
+INSERT INTO media (url, file_name, uploaded_by_id, created_at)
+SELECT DISTINCT ON (image) image, regexp_replace(image, '^.*/', ''), created_by_id, created_at
+FROM contents
+WHERE image IS NOT NULL AND image <> ''
+ORDER BY image, created_at
+ON CONFLICT (url) DO NOTHING;
+
+UPDATE contents SET media_id = media.id FROM media WHERE media.url = contents.image;
+
+CREATE INDEX idx_contents_media_id ON contents(media_id);
+
+ALTER TABLE "contents" DROP COLUMN IF EXISTS image;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "media_uploads";
\ No newline at end of file
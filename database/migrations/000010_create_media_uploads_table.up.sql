@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "media_uploads" (
+    id SERIAL PRIMARY KEY,
+    object_key TEXT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    uploaded_by_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_media_uploads_object_key ON media_uploads(object_key);
+CREATE INDEX idx_media_uploads_expires_at ON media_uploads(expires_at);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "contents" DROP COLUMN IF EXISTS description_html;
+ALTER TABLE "contents" DROP COLUMN IF EXISTS description_format;
\ No newline at end of file
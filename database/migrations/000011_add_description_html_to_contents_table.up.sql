@@ -0,0 +1,2 @@
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS description_format VARCHAR(10) NOT NULL DEFAULT 'html';
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS description_html TEXT NOT NULL DEFAULT '';
\ No newline at end of file
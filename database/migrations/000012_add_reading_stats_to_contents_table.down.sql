@@ -0,0 +1,2 @@
+ALTER TABLE "contents" DROP COLUMN IF EXISTS reading_time;
+ALTER TABLE "contents" DROP COLUMN IF EXISTS word_count;
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS word_count INT NOT NULL DEFAULT 0;
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS reading_time INT NOT NULL DEFAULT 0;
+
+-- Approximate counts for existing rows; every save recomputes them exactly.
+UPDATE contents SET word_count = COALESCE(array_length(regexp_split_to_array(
+    btrim(regexp_replace(regexp_replace(description, '<[^>]*>', ' ', 'g'), '\s+', ' ', 'g')), ' '), 1), 0)
+WHERE btrim(regexp_replace(description, '<[^>]*>', '', 'g')) <> '';
+
+UPDATE contents SET reading_time = CEIL(word_count / 200.0) WHERE word_count > 0;
\ No newline at end of file
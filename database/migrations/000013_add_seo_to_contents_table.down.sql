@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_contents_og_media_id;
+
+ALTER TABLE "contents" DROP COLUMN IF EXISTS og_media_id;
+ALTER TABLE "contents" DROP COLUMN IF EXISTS noindex;
+ALTER TABLE "contents" DROP COLUMN IF EXISTS canonical_url;
+ALTER TABLE "contents" DROP COLUMN IF EXISTS meta_description;
+ALTER TABLE "contents" DROP COLUMN IF EXISTS meta_title;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS meta_title VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS meta_description VARCHAR(300) NOT NULL DEFAULT '';
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS canonical_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS noindex BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS og_media_id INT NULL REFERENCES media(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_contents_og_media_id ON contents(og_media_id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_contents_search_vector;
+ALTER TABLE "contents" DROP COLUMN IF EXISTS search_vector;
\ No newline at end of file
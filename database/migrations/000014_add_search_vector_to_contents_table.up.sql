@@ -0,0 +1,8 @@
+-- Titles weigh more than excerpts when comparing articles. The simple
+-- configuration is used because stories are not all in one language.
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(exerpt, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_contents_search_vector ON contents USING GIN (search_vector);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "content_views_daily";
+DROP TABLE IF EXISTS "content_views_hourly";
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "content_views_hourly" (
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    hour TIMESTAMP NOT NULL,
+    views BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (content_id, hour)
+);
+
+CREATE INDEX idx_content_views_hourly_hour ON content_views_hourly(hour);
+
+CREATE TABLE IF NOT EXISTS "content_views_daily" (
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (content_id, day)
+);
+
+CREATE INDEX idx_content_views_daily_day ON content_views_daily(day);
\ No newline at end of file
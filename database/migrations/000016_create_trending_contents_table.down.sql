@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "trending_contents";
\ No newline at end of file
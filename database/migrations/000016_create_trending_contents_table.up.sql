@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS "trending_contents" (
+    content_id INT PRIMARY KEY REFERENCES contents(id) ON DELETE CASCADE,
+    category_id INT NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    views BIGINT NOT NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_trending_contents_score ON trending_contents(score DESC);
+CREATE INDEX idx_trending_contents_category_id_score ON trending_contents(category_id, score DESC);
\ No newline at end of file
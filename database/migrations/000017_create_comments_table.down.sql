@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "comment_bans";
+DROP TABLE IF EXISTS "comments";
+ALTER TABLE "contents" DROP COLUMN IF EXISTS comments_enabled;
\ No newline at end of file
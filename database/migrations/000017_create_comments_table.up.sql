@@ -0,0 +1,34 @@
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS comments_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE TABLE IF NOT EXISTS "comments" (
+    id SERIAL PRIMARY KEY,
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    parent_id INT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    author_name VARCHAR(100) NOT NULL,
+    author_email VARCHAR(200) NOT NULL,
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    moderated_by_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    moderated_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_comments_content_id_status ON comments(content_id, status, created_at) WHERE parent_id IS NULL;
+CREATE INDEX idx_comments_parent_id ON comments(parent_id);
+CREATE INDEX idx_comments_unmoderated ON comments(created_at) WHERE moderated_at IS NULL;
+CREATE INDEX idx_comments_author_email ON comments(lower(author_email));
+
+CREATE TABLE IF NOT EXISTS "comment_bans" (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(200) NOT NULL DEFAULT '',
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    created_by_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_comment_bans_email ON comment_bans(lower(email)) WHERE email <> '';
+CREATE INDEX idx_comment_bans_ip ON comment_bans(ip) WHERE ip <> '';
\ No newline at end of file
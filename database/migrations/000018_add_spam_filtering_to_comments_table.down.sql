@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "comment_spam_tokens";
+DROP INDEX IF EXISTS idx_comments_trained_as;
+DROP INDEX IF EXISTS idx_comments_body_hash;
+ALTER TABLE "comments" DROP COLUMN IF EXISTS trained_as;
+ALTER TABLE "comments" DROP COLUMN IF EXISTS spam_checks;
+ALTER TABLE "comments" DROP COLUMN IF EXISTS spam_score;
+ALTER TABLE "comments" DROP COLUMN IF EXISTS body_hash;
\ No newline at end of file
@@ -0,0 +1,13 @@
+ALTER TABLE "comments" ADD COLUMN IF NOT EXISTS body_hash VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE "comments" ADD COLUMN IF NOT EXISTS spam_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE "comments" ADD COLUMN IF NOT EXISTS spam_checks TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE "comments" ADD COLUMN IF NOT EXISTS trained_as VARCHAR(10) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_comments_body_hash ON comments(body_hash, created_at);
+CREATE INDEX idx_comments_trained_as ON comments(trained_as) WHERE trained_as <> '';
+
+CREATE TABLE IF NOT EXISTS "comment_spam_tokens" (
+    token VARCHAR(64) PRIMARY KEY,
+    spam_count INT NOT NULL DEFAULT 0,
+    ham_count INT NOT NULL DEFAULT 0
+);
\ No newline at end of file
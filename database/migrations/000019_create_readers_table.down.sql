@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "readers";
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "readers" (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(200) NOT NULL,
+    password VARCHAR(100) NOT NULL,
+    email_verified_at TIMESTAMP NULL,
+    verification_token VARCHAR(64) NULL,
+    verification_sent_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX idx_readers_email ON readers(lower(email));
+CREATE UNIQUE INDEX idx_readers_verification_token ON readers(verification_token) WHERE verification_token IS NOT NULL;
\ No newline at end of file
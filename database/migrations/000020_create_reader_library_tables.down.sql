@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "reader_history";
+DROP TABLE IF EXISTS "reader_bookmarks";
+ALTER TABLE "readers" DROP COLUMN IF EXISTS history_enabled;
\ No newline at end of file
@@ -0,0 +1,22 @@
+ALTER TABLE "readers" ADD COLUMN IF NOT EXISTS history_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS "reader_bookmarks" (
+    reader_id INT NOT NULL REFERENCES readers(id) ON DELETE CASCADE,
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (reader_id, content_id)
+);
+
+CREATE INDEX idx_reader_bookmarks_reader_id_created_at ON reader_bookmarks(reader_id, created_at DESC);
+CREATE INDEX idx_reader_bookmarks_content_id ON reader_bookmarks(content_id);
+
+CREATE TABLE IF NOT EXISTS "reader_history" (
+    reader_id INT NOT NULL REFERENCES readers(id) ON DELETE CASCADE,
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL DEFAULT 0 CHECK (position BETWEEN 0 AND 100),
+    read_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (reader_id, content_id)
+);
+
+CREATE INDEX idx_reader_history_reader_id_read_at ON reader_history(reader_id, read_at DESC);
+CREATE INDEX idx_reader_history_content_id ON reader_history(content_id);
\ No newline at end of file
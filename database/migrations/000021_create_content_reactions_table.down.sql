@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "content_reaction_counts";
+DROP TABLE IF EXISTS "content_reactions";
\ No newline at end of file
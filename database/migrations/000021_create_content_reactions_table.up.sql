@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS "content_reactions" (
+    id SERIAL PRIMARY KEY,
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    reader_id INT NULL REFERENCES readers(id) ON DELETE CASCADE,
+    fingerprint VARCHAR(64) NULL,
+    reaction VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL,
+    CHECK ((reader_id IS NULL) <> (fingerprint IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_content_reactions_reader ON content_reactions(content_id, reader_id) WHERE reader_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_content_reactions_fingerprint ON content_reactions(content_id, fingerprint) WHERE fingerprint IS NOT NULL;
+CREATE INDEX idx_content_reactions_reader_id ON content_reactions(reader_id) WHERE reader_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS "content_reaction_counts" (
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    reaction VARCHAR(20) NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (content_id, reaction)
+);
\ No newline at end of file
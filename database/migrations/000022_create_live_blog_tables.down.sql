@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "live_blog_events";
+DROP TABLE IF EXISTS "live_blog_entries";
+ALTER TABLE "contents" DROP COLUMN IF EXISTS content_type;
\ No newline at end of file
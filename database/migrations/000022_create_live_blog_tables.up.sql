@@ -0,0 +1,26 @@
+ALTER TABLE "contents" ADD COLUMN IF NOT EXISTS content_type VARCHAR(20) NOT NULL DEFAULT 'ARTICLE';
+
+CREATE TABLE IF NOT EXISTS "live_blog_entries" (
+    id SERIAL PRIMARY KEY,
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    body_format VARCHAR(20) NOT NULL DEFAULT 'html',
+    body_html TEXT NOT NULL DEFAULT '',
+    pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_live_blog_entries_content_id_created_at ON live_blog_entries(content_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS "live_blog_events" (
+    id BIGSERIAL PRIMARY KEY,
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    entry_id INT NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_live_blog_events_content_id_id ON live_blog_events(content_id, id);
\ No newline at end of file
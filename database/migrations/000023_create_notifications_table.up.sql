@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS "notifications" (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    actor_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    content_id INT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    comment_id INT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    read_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_notifications_user_id ON notifications(user_id, id DESC);
+CREATE INDEX idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS "notification_subscriptions" (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, type)
+);
\ No newline at end of file
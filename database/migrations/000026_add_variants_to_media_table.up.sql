@@ -0,0 +1 @@
+ALTER TABLE "media" ADD COLUMN IF NOT EXISTS variants TEXT NOT NULL DEFAULT '';
\ No newline at end of file
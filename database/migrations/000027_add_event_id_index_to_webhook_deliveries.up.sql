@@ -0,0 +1 @@
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id_event_id ON webhook_deliveries(webhook_id, event_id);
\ No newline at end of file
@@ -0,0 +1,198 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"news-app/config"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+var ErrObjectNotFound = errors.New("object not found")
+
+type PresignedUpload struct {
+	URL     string
+	Method  string
+	Headers map[string]string
+}
+
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+type CloudflareR2Adapter interface {
+	PutObject(ctx context.Context, key string, body []byte, contentType string) (string, error)
+	PresignPutObject(ctx context.Context, key, contentType string, size int64, expires time.Duration) (*PresignedUpload, error)
+	HeadObject(ctx context.Context, key string) (int64, error)
+	GetObjectRange(ctx context.Context, key string, length int64) ([]byte, error)
+	DeleteObject(ctx context.Context, key string) error
+	ListObjects(ctx context.Context, prefix string, fn func(ObjectInfo) error) error
+	PublicURL(key string) string
+}
+
+type cloudflareR2Adapter struct {
+	client    *s3.Client
+	bucket    string
+	publicUrl string
+}
+
+// PutObject implements CloudflareR2Adapter. It returns the public URL of the
+// stored object.
+func (c *cloudflareR2Adapter) PutObject(ctx context.Context, key string, body []byte, contentType string) (string, error) {
+	_, err := c.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:       aws.String(c.bucket),
+		Key:          aws.String(key),
+		Body:         bytes.NewReader(body),
+		ContentType:  aws.String(contentType),
+		CacheControl: aws.String("public, max-age=31536000, immutable"),
+	})
+	if err != nil {
+		code := "[CLOUDFLARE] PutObject - 1"
+		log.Errorw(code, err)
+		return "", err
+	}
+
+	return c.PublicURL(key), nil
+}
+
+// PresignPutObject implements CloudflareR2Adapter. The content type and
+// length are part of the signature, so the bucket rejects any upload that
+// does not send exactly the returned headers.
+func (c *cloudflareR2Adapter) PresignPutObject(ctx context.Context, key, contentType string, size int64, expires time.Duration) (*PresignedUpload, error) {
+	presigned, err := s3.NewPresignClient(c.client).PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(c.bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(size),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		code := "[CLOUDFLARE] PresignPutObject - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := &PresignedUpload{URL: presigned.URL, Method: presigned.Method, Headers: map[string]string{}}
+	for name, values := range presigned.SignedHeader {
+		if strings.EqualFold(name, "Host") || len(values) == 0 {
+			continue
+		}
+		res.Headers[http.CanonicalHeaderKey(name)] = values[0]
+	}
+
+	return res, nil
+}
+
+// HeadObject implements CloudflareR2Adapter. It returns the size of the
+// object, or ErrObjectNotFound.
+func (c *cloudflareR2Adapter) HeadObject(ctx context.Context, key string) (int64, error) {
+	out, err := c.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return 0, ErrObjectNotFound
+		}
+
+		code := "[CLOUDFLARE] HeadObject - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return aws.ToInt64(out.ContentLength), nil
+}
+
+// GetObjectRange implements CloudflareR2Adapter. It reads at most length
+// bytes from the start of the object.
+func (c *cloudflareR2Adapter) GetObjectRange(ctx context.Context, key string, length int64) ([]byte, error) {
+	out, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=0-%d", length-1)),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrObjectNotFound
+		}
+
+		code := "[CLOUDFLARE] GetObjectRange - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(io.LimitReader(out.Body, length))
+}
+
+// DeleteObject implements CloudflareR2Adapter.
+func (c *cloudflareR2Adapter) DeleteObject(ctx context.Context, key string) error {
+	_, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		code := "[CLOUDFLARE] DeleteObject - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// ListObjects implements CloudflareR2Adapter. It calls fn for every object
+// under prefix, one page at a time, and stops at the first error.
+func (c *cloudflareR2Adapter) ListObjects(ctx context.Context, prefix string, fn func(ObjectInfo) error) error {
+	paginator := s3.NewListObjectsV2Paginator(c.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.bucket),
+		Prefix: aws.String(prefix),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			code := "[CLOUDFLARE] ListObjects - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		for _, object := range page.Contents {
+			err = fn(ObjectInfo{
+				Key:          aws.ToString(object.Key),
+				Size:         aws.ToInt64(object.Size),
+				LastModified: aws.ToTime(object.LastModified),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// PublicURL implements CloudflareR2Adapter.
+func (c *cloudflareR2Adapter) PublicURL(key string) string {
+	return c.publicUrl + "/" + strings.TrimLeft(key, "/")
+}
+
+func NewCloudflareR2Adapter(client *s3.Client, cfg *config.Config) CloudflareR2Adapter {
+	return &cloudflareR2Adapter{
+		client:    client,
+		bucket:    cfg.R2.Name,
+		publicUrl: strings.TrimRight(cfg.R2.PublicUrl, "/"),
+	}
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+const analyticsDateLayout = "2006-01-02"
+
+type AnalyticsHandler interface {
+	GetReport(c *fiber.Ctx) error
+}
+
+type analyticsHandler struct {
+	analyticsService service.AnalyticsService
+}
+
+// GetReport implements AnalyticsHandler. The report is returned as JSON, or
+// as a CSV download with format=csv.
+func (ah *analyticsHandler) GetReport(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetReport - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	query := entity.AnalyticsQueryEntity{
+		CategoryID: int64(c.QueryInt("category_id", 0)),
+		ContentID:  int64(c.QueryInt("content_id", 0)),
+		Limit:      c.QueryInt("limit", 0),
+	}
+	for param, dst := range map[string]*time.Time{"from": &query.From, "to": &query.To} {
+		if c.Query(param) == "" {
+			continue
+		}
+
+		*dst, err = time.Parse(analyticsDateLayout, c.Query(param))
+		if err != nil {
+			code = "[HANDLER] GetReport - 2"
+			log.Errorw(code, err)
+			errResponse.Meta.Status = false
+			errResponse.Meta.Message = fmt.Sprintf("%s must be a date formatted as YYYY-MM-DD", param)
+
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+	}
+
+	result, err := ah.analyticsService.GetReport(c.Context(), c.Params("report"), query)
+	if err != nil {
+		code = "[HANDLER] GetReport - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrUnknownReport) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+		if errors.Is(err, service.ErrInvalidAnalyticsRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	if c.Query("format") == "csv" {
+		body, err := analyticsReportToCSV(result)
+		if err != nil {
+			code = "[HANDLER] GetReport - 4"
+			log.Errorw(code, err)
+			errResponse.Meta.Status = false
+			errResponse.Meta.Message = err.Error()
+
+			return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+		}
+
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.csv"`, result.Name))
+		return c.Send(body)
+	}
+
+	res := response.AnalyticsReportResponse{
+		Report:  result.Name,
+		From:    result.From.Format(analyticsDateLayout),
+		To:      result.To.Format(analyticsDateLayout),
+		Columns: result.Columns,
+		Rows:    []map[string]interface{}{},
+	}
+	for _, row := range result.Rows {
+		item := map[string]interface{}{}
+		for i, column := range result.Columns {
+			item[column] = analyticsValue(column, row[i])
+		}
+		res.Rows = append(res.Rows, item)
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Message = "Analytics report fetched successfully"
+	defaultResponse.Data = res
+
+	return c.JSON(defaultResponse)
+}
+
+func analyticsReportToCSV(result *entity.AnalyticsReportEntity) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	err := w.Write(result.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range result.Rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			switch v := analyticsValue(result.Columns[i], value).(type) {
+			case nil:
+				record[i] = ""
+			case float64:
+				record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+			default:
+				record[i] = csvSafe(fmt.Sprint(v))
+			}
+		}
+
+		err = w.Write(record)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// csvSafe keeps spreadsheets from reading a text cell, such as a title or
+// a path, as a formula.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// analyticsValue formats the dates of a report: day columns as plain dates
+// and every other time as RFC 3339.
+func analyticsValue(column string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		if column == "day" {
+			return v.Format(analyticsDateLayout)
+		}
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.Format(time.RFC3339)
+	default:
+		return value
+	}
+}
+
+func NewAnalyticsHandler(analyticsService service.AnalyticsService) AnalyticsHandler {
+	return &analyticsHandler{analyticsService: analyticsService}
+}
@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"errors"
+	"math"
+
 	"news-app/internal/adapter/handler/request"
 	"news-app/internal/adapter/handler/response"
 	"news-app/internal/core/domain/entity"
@@ -10,6 +13,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
 )
 
 var defaultResponse response.DefaultSuccessResponse
@@ -17,9 +21,11 @@ var defaultResponse response.DefaultSuccessResponse
 type CategoryHandler interface {
 	GetCategories(c *fiber.Ctx) error
 	GetCategoryByID(c *fiber.Ctx) error
+	GetCategoryBySlug(c *fiber.Ctx) error
 	CreateCategory(c *fiber.Ctx) error
 	UpdateCategory(c *fiber.Ctx) error
 	DeleteCategory(c *fiber.Ctx) error
+	MergeCategories(c *fiber.Ctx) error
 }
 
 type categoryHandler struct {
@@ -225,6 +231,37 @@ func (ch *categoryHandler) GetCategoryByID(c *fiber.Ctx) error {
 	return c.JSON(defaultResponse)
 }
 
+// GetCategoryBySlug implements CategoryHandler.
+func (ch *categoryHandler) GetCategoryBySlug(c *fiber.Ctx) error {
+	result, err := ch.categoryService.GetCategoryBySlug(c.Context(), c.Params("slug"))
+	if err != nil {
+		code = "[HANDLER] GetCategoryBySlug - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	categoryRes := response.SuccessCategoryResponse{
+		ID:            result.ID,
+		Title:         result.Title,
+		Slug:          result.Slug,
+		CreatedByName: result.UserEntity.Name,
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Message = "Category fetched successfully"
+	defaultResponse.Data = categoryRes
+
+	return c.JSON(defaultResponse)
+}
+
 // UpdateCategory implements CategoryHandler.
 func (ch *categoryHandler) UpdateCategory(c *fiber.Ctx) error {
 	var req request.CategoryRequest
@@ -295,6 +332,95 @@ func (ch *categoryHandler) UpdateCategory(c *fiber.Ctx) error {
 	return c.JSON(defaultResponse)
 }
 
+// MergeCategories implements CategoryHandler.
+func (ch *categoryHandler) MergeCategories(c *fiber.Ctx) error {
+	var req request.CategoryMergeRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] MergeCategories - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	idParam := c.Params("categoryId")
+	id, err := conv.StringToInt64(idParam)
+	if err != nil {
+		code = "[HANDLER] MergeCategories - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] MergeCategories - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] MergeCategories - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	// Category ids are SMALLINT; a larger id would wrap around to another
+	// category.
+	for _, categoryID := range append([]int64{id}, req.SourceIDs...) {
+		if categoryID < 1 || categoryID > math.MaxInt16 {
+			code = "[HANDLER] MergeCategories - 5"
+			err = errors.New("invalid category id")
+			log.Errorw(code, err)
+			errResponse.Meta.Status = false
+			errResponse.Meta.Message = err.Error()
+
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+	}
+
+	reqEntity := entity.CategoryMergeEntity{
+		TargetID: int16(id),
+	}
+	for _, sourceID := range req.SourceIDs {
+		reqEntity.SourceIDs = append(reqEntity.SourceIDs, int16(sourceID))
+	}
+
+	err = ch.categoryService.MergeCategories(c.Context(), reqEntity)
+	if err != nil {
+		code = "[HANDLER] MergeCategories - 6"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		case errors.Is(err, service.ErrCategoryMergeIntoItself), errors.Is(err, service.ErrCategoryMergeNoSource):
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Data = nil
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Categories merged successfully"
+	return c.JSON(defaultResponse)
+}
+
 func NewCategoryHandler(categoryService service.CategoryService) CategoryHandler {
 	return &categoryHandler{categoryService: categoryService}
 }
@@ -0,0 +1,431 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"news-app/internal/adapter/handler/request"
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/service"
+	"news-app/lib/conv"
+	validatorLib "news-app/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type CommentHandler interface {
+	GetContentComments(c *fiber.Ctx) error
+	CreateComment(c *fiber.Ctx) error
+
+	GetComments(c *fiber.Ctx) error
+	ApproveComment(c *fiber.Ctx) error
+	RejectComment(c *fiber.Ctx) error
+	MarkCommentSpam(c *fiber.Ctx) error
+	BanCommentAuthor(c *fiber.Ctx) error
+	GetCommentBans(c *fiber.Ctx) error
+	DeleteCommentBan(c *fiber.Ctx) error
+}
+
+type commentHandler struct {
+	commentService service.CommentService
+}
+
+// GetContentComments implements CommentHandler.
+func (ch *commentHandler) GetContentComments(c *fiber.Ctx) error {
+	query := parseQueryString(c)
+
+	results, totalData, err := ch.commentService.GetContentComments(c.Context(), c.Params("slug"), query)
+	if err != nil {
+		code = "[HANDLER] GetContentComments - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	commentResponses := []response.CommentResponse{}
+	for _, result := range results {
+		commentResponses = append(commentResponses, commentToResponse(result, false))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Comments fetched successfully"
+	defaultResponse.Data = commentResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// CreateComment implements CommentHandler.
+func (ch *commentHandler) CreateComment(c *fiber.Ctx) error {
+	var req request.CommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] CreateComment - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] CreateComment - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := ch.commentService.CreateComment(c.Context(), c.Params("slug"), entity.CommentEntity{
+		ParentID:    req.ParentID,
+		AuthorName:  req.AuthorName,
+		AuthorEmail: req.AuthorEmail,
+		Body:        req.Body,
+		IP:          c.IP(),
+		UserAgent:   c.Get(fiber.HeaderUserAgent),
+	})
+	if err != nil {
+		code = "[HANDLER] CreateComment - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		case errors.Is(err, service.ErrCommentsDisabled), errors.Is(err, service.ErrCommentAuthorBanned):
+			return c.Status(fiber.StatusForbidden).JSON(errResponse)
+		case errors.Is(err, service.ErrCommentParentNotFound):
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Comment submitted for moderation"
+	if result.Status == entity.CommentStatusApproved {
+		defaultResponse.Meta.Message = "Comment published successfully"
+	}
+	res := commentToResponse(*result, false)
+	// Spam is reported as pending, so spammers do not learn what gave
+	// them away.
+	res.Status = entity.CommentStatusPending
+	if result.Status == entity.CommentStatusApproved {
+		res.Status = result.Status
+	}
+	defaultResponse.Data = res
+
+	return c.Status(fiber.StatusCreated).JSON(defaultResponse)
+}
+
+// GetComments implements CommentHandler. Without a status it lists the
+// moderation queue.
+func (ch *commentHandler) GetComments(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetComments - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	query := parseQueryString(c)
+	results, totalData, err := ch.commentService.GetComments(c.Context(), query)
+	if err != nil {
+		code = "[HANDLER] GetComments - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	commentResponses := []response.CommentResponse{}
+	for _, result := range results {
+		commentResponses = append(commentResponses, commentToResponse(result, true))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Comments fetched successfully"
+	defaultResponse.Data = commentResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// ApproveComment implements CommentHandler.
+func (ch *commentHandler) ApproveComment(c *fiber.Ctx) error {
+	return ch.moderateComment(c, "ApproveComment", entity.CommentStatusApproved, "Comment approved successfully")
+}
+
+// RejectComment implements CommentHandler.
+func (ch *commentHandler) RejectComment(c *fiber.Ctx) error {
+	return ch.moderateComment(c, "RejectComment", entity.CommentStatusRejected, "Comment rejected successfully")
+}
+
+// MarkCommentSpam implements CommentHandler.
+func (ch *commentHandler) MarkCommentSpam(c *fiber.Ctx) error {
+	return ch.moderateComment(c, "MarkCommentSpam", entity.CommentStatusSpam, "Comment marked as spam successfully")
+}
+
+// BanCommentAuthor implements CommentHandler.
+func (ch *commentHandler) BanCommentAuthor(c *fiber.Ctx) error {
+	var req request.CommentBanRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] BanCommentAuthor - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("commentId"))
+	if err != nil {
+		code = "[HANDLER] BanCommentAuthor - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			code = "[HANDLER] BanCommentAuthor - 3"
+			log.Errorw(code, err)
+			errResponse.Meta.Status = false
+			errResponse.Meta.Message = err.Error()
+
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] BanCommentAuthor - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := ch.commentService.BanCommentAuthor(c.Context(), id, req.Reason, int64(userID))
+	if err != nil {
+		code = "[HANDLER] BanCommentAuthor - 5"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Comment author banned successfully"
+	defaultResponse.Data = commentBanToResponse(*result)
+
+	return c.Status(fiber.StatusCreated).JSON(defaultResponse)
+}
+
+// GetCommentBans implements CommentHandler.
+func (ch *commentHandler) GetCommentBans(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetCommentBans - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	query := parseQueryString(c)
+	results, totalData, err := ch.commentService.GetCommentBans(c.Context(), query)
+	if err != nil {
+		code = "[HANDLER] GetCommentBans - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	banResponses := []response.CommentBanResponse{}
+	for _, result := range results {
+		banResponses = append(banResponses, commentBanToResponse(result))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Comment bans fetched successfully"
+	defaultResponse.Data = banResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// DeleteCommentBan implements CommentHandler.
+func (ch *commentHandler) DeleteCommentBan(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] DeleteCommentBan - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("banId"))
+	if err != nil {
+		code = "[HANDLER] DeleteCommentBan - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = ch.commentService.DeleteCommentBan(c.Context(), id)
+	if err != nil {
+		code = "[HANDLER] DeleteCommentBan - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Data = nil
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Comment ban deleted successfully"
+	return c.JSON(defaultResponse)
+}
+
+func (ch *commentHandler) moderateComment(c *fiber.Ctx, name, status, message string) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] " + name + " - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("commentId"))
+	if err != nil {
+		code = "[HANDLER] " + name + " - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = ch.commentService.ModerateComment(c.Context(), id, status, int64(userID))
+	if err != nil {
+		code = "[HANDLER] " + name + " - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Data = nil
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = message
+	return c.JSON(defaultResponse)
+}
+
+// commentToResponse leaves out the author's contact details and the
+// moderation state unless withPrivate is set, for editors.
+func commentToResponse(result entity.CommentEntity, withPrivate bool) response.CommentResponse {
+	res := response.CommentResponse{
+		ID:         result.ID,
+		ParentID:   result.ParentID,
+		AuthorName: result.AuthorName,
+		Body:       result.Body,
+		CreatedAt:  result.CreatedAt.Format(time.RFC3339),
+	}
+	for _, reply := range result.Replies {
+		res.Replies = append(res.Replies, commentToResponse(reply, withPrivate))
+	}
+
+	if withPrivate {
+		res.ContentID = result.ContentID
+		res.ContentTitle = result.ContentTitle
+		res.ContentSlug = result.ContentSlug
+		res.AuthorEmail = result.AuthorEmail
+		res.IP = result.IP
+		res.UserAgent = result.UserAgent
+		res.Status = result.Status
+		res.SpamScore = result.SpamScore
+		res.TrainedAs = result.TrainedAs
+		for _, check := range result.SpamChecks {
+			res.SpamChecks = append(res.SpamChecks, response.SpamCheckResponse{
+				Filter: check.Filter,
+				Score:  check.Score,
+				Reason: check.Reason,
+			})
+		}
+		if result.ModeratedAt != nil {
+			res.ModeratedAt = result.ModeratedAt.Format(time.RFC3339)
+		}
+	}
+
+	return res
+}
+
+func commentBanToResponse(result entity.CommentBanEntity) response.CommentBanResponse {
+	return response.CommentBanResponse{
+		ID:        result.ID,
+		Email:     result.Email,
+		IP:        result.IP,
+		Reason:    result.Reason,
+		CreatedAt: result.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewCommentHandler(commentService service.CommentService) CommentHandler {
+	return &commentHandler{commentService: commentService}
+}
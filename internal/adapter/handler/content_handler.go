@@ -0,0 +1,451 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"news-app/internal/adapter/handler/request"
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/service"
+	"news-app/lib/conv"
+	validatorLib "news-app/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type ContentHandler interface {
+	GetContents(c *fiber.Ctx) error
+	GetContentByID(c *fiber.Ctx) error
+	CreateContent(c *fiber.Ctx) error
+	UpdateContent(c *fiber.Ctx) error
+	DeleteContent(c *fiber.Ctx) error
+
+	GetPublishedContents(c *fiber.Ctx) error
+	GetContentBySlug(c *fiber.Ctx) error
+	GetRelatedContents(c *fiber.Ctx) error
+}
+
+type contentHandler struct {
+	contentService service.ContentService
+}
+
+// GetContents implements ContentHandler.
+func (ch *contentHandler) GetContents(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetContents - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	query := parseQueryString(c)
+	results, totalData, err := ch.contentService.GetContents(c.Context(), query)
+	if err != nil {
+		code = "[HANDLER] GetContents - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	contentResponses := []response.ContentResponse{}
+	for _, result := range results {
+		contentResponses = append(contentResponses, contentToResponse(result, false))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Contents fetched successfully"
+	defaultResponse.Data = contentResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// GetContentByID implements ContentHandler.
+func (ch *contentHandler) GetContentByID(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetContentByID - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] GetContentByID - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := ch.contentService.GetContentByID(c.Context(), id)
+	if err != nil {
+		code = "[HANDLER] GetContentByID - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Message = "Content fetched successfully"
+	defaultResponse.Data = contentToResponse(*result, true)
+
+	return c.JSON(defaultResponse)
+}
+
+// CreateContent implements ContentHandler.
+func (ch *contentHandler) CreateContent(c *fiber.Ctx) error {
+	var req request.ContentRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] CreateContent - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] CreateContent - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] CreateContent - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	reqEntity := contentRequestToEntity(req)
+	reqEntity.CreatedByID = int64(userID)
+
+	result, err := ch.contentService.CreateContent(c.Context(), reqEntity)
+	if err != nil {
+		code = "[HANDLER] CreateContent - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrMediaNotFound) {
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Content created successfully"
+	defaultResponse.Data = contentToResponse(*result, true)
+	return c.Status(fiber.StatusCreated).JSON(defaultResponse)
+}
+
+// UpdateContent implements ContentHandler.
+func (ch *contentHandler) UpdateContent(c *fiber.Ctx) error {
+	var req request.ContentRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] UpdateContent - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] UpdateContent - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] UpdateContent - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] UpdateContent - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	reqEntity := contentRequestToEntity(req)
+	reqEntity.ID = id
+
+	result, err := ch.contentService.UpdateContent(c.Context(), reqEntity, int64(userID))
+	if err != nil {
+		code = "[HANDLER] UpdateContent - 5"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrMediaNotFound) {
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Content updated successfully"
+	defaultResponse.Data = contentToResponse(*result, true)
+	return c.JSON(defaultResponse)
+}
+
+// DeleteContent implements ContentHandler.
+func (ch *contentHandler) DeleteContent(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] DeleteContent - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] DeleteContent - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = ch.contentService.DeleteContent(c.Context(), id)
+	if err != nil {
+		code = "[HANDLER] DeleteContent - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Data = nil
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Content deleted successfully"
+	return c.JSON(defaultResponse)
+}
+
+// GetPublishedContents implements ContentHandler.
+func (ch *contentHandler) GetPublishedContents(c *fiber.Ctx) error {
+	query := parseQueryString(c)
+	query.Status = entity.ContentStatusPublish
+
+	results, totalData, err := ch.contentService.GetContents(c.Context(), query)
+	if err != nil {
+		code = "[HANDLER] GetPublishedContents - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	contentResponses := []response.ContentResponse{}
+	for _, result := range results {
+		contentResponses = append(contentResponses, contentToResponse(result, false))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Contents fetched successfully"
+	defaultResponse.Data = contentResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// GetContentBySlug implements ContentHandler.
+func (ch *contentHandler) GetContentBySlug(c *fiber.Ctx) error {
+	result, err := ch.contentService.GetContentBySlug(c.Context(), c.Params("slug"))
+	if err != nil {
+		code = "[HANDLER] GetContentBySlug - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Message = "Content fetched successfully"
+	res := contentToResponse(*result, true)
+	res.Reactions = reactionCountsToResponse(result.Reactions)
+	defaultResponse.Data = res
+
+	return c.JSON(defaultResponse)
+}
+
+// GetRelatedContents implements ContentHandler.
+func (ch *contentHandler) GetRelatedContents(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 5)
+	if limit <= 0 || limit > service.MaxRelatedContents {
+		limit = 5
+	}
+
+	results, err := ch.contentService.GetRelatedContents(c.Context(), c.Params("slug"), limit)
+	if err != nil {
+		code = "[HANDLER] GetRelatedContents - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	contentResponses := []response.ContentResponse{}
+	for _, result := range results {
+		contentResponses = append(contentResponses, contentToResponse(result, false))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Message = "Related contents fetched successfully"
+	defaultResponse.Data = contentResponses
+
+	return c.JSON(defaultResponse)
+}
+
+func contentRequestToEntity(req request.ContentRequest) entity.ContentEntity {
+	contentType := req.ContentType
+	if contentType == "" {
+		contentType = entity.ContentTypeArticle
+	}
+
+	return entity.ContentEntity{
+		Title:             req.Title,
+		Excerpt:           req.Excerpt,
+		Description:       req.Description,
+		DescriptionFormat: req.DescriptionFormat,
+		MediaID:           req.MediaID,
+		MetaTitle:         req.MetaTitle,
+		MetaDescription:   req.MetaDescription,
+		CanonicalURL:      req.CanonicalURL,
+		NoIndex:           req.NoIndex,
+		CommentsEnabled:   req.CommentsEnabled == nil || *req.CommentsEnabled,
+		ContentType:       contentType,
+		OgMediaID:         req.OgMediaID,
+		Tags:              req.Tags,
+		Status:            req.Status,
+		CategoryID:        req.CategoryID,
+	}
+}
+
+func contentToResponse(result entity.ContentEntity, withDescription bool) response.ContentResponse {
+	res := response.ContentResponse{
+		ID:              result.ID,
+		Title:           result.Title,
+		Slug:            result.Slug,
+		Excerpt:         result.Excerpt,
+		Image:           result.Image,
+		MediaID:         result.MediaID,
+		Tags:            result.Tags,
+		WordCount:       result.WordCount,
+		ReadingTime:     result.ReadingTime,
+		Status:          result.Status,
+		MetaTitle:       result.MetaTitle,
+		MetaDescription: result.MetaDescription,
+		CanonicalURL:    result.CanonicalURL,
+		NoIndex:         result.NoIndex,
+		CommentsEnabled: result.CommentsEnabled,
+		ContentType:     result.ContentType,
+		OgMediaID:       result.OgMediaID,
+		CategoryID:      result.CategoryID,
+		CategoryName:    result.Category.Title,
+		CategorySlug:    result.Category.Slug,
+		Author:          result.User.Name,
+		CreatedAt:       result.CreatedAt.Format(time.RFC3339),
+	}
+	if result.Media != nil {
+		media := mediaToResponse(*result.Media)
+		res.Media = &media
+	}
+	if len(result.ImageVariants) > 0 {
+		res.ImageVariants = imageVariantsToResponse(result.ImageVariants)
+		res.ImageSrcset = srcset(result.ImageVariants)
+	}
+	if result.PublishedAt != nil {
+		res.PublishedAt = result.PublishedAt.Format(time.RFC3339)
+	}
+	if res.Tags == nil {
+		res.Tags = []string{}
+	}
+	if withDescription {
+		res.Description = result.Description
+		res.DescriptionFormat = result.DescriptionFormat
+		res.DescriptionHTML = result.DescriptionHTML
+	}
+
+	return res
+}
+
+func NewContentHandler(contentService service.ContentService) ContentHandler {
+	return &contentHandler{contentService: contentService}
+}
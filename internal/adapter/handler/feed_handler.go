@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"news-app/internal/core/service"
+	"news-app/lib/feed"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type FeedHandler interface {
+	GetSiteFeed(c *fiber.Ctx) error
+	GetCategoryFeed(c *fiber.Ctx) error
+	GetTagFeed(c *fiber.Ctx) error
+}
+
+type feedHandler struct {
+	feedService service.FeedService
+}
+
+// GetSiteFeed implements FeedHandler.
+func (fh *feedHandler) GetSiteFeed(c *fiber.Ctx) error {
+	result, err := fh.feedService.GetSiteFeed(c.Context())
+	if err != nil {
+		code = "[HANDLER] GetSiteFeed - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	return writeFeed(c, result)
+}
+
+// GetCategoryFeed implements FeedHandler.
+func (fh *feedHandler) GetCategoryFeed(c *fiber.Ctx) error {
+	result, err := fh.feedService.GetCategoryFeed(c.Context(), c.Params("slug"))
+	if err != nil {
+		code = "[HANDLER] GetCategoryFeed - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	return writeFeed(c, result)
+}
+
+// GetTagFeed implements FeedHandler.
+func (fh *feedHandler) GetTagFeed(c *fiber.Ctx) error {
+	result, err := fh.feedService.GetTagFeed(c.Context(), c.Params("tag"))
+	if err != nil {
+		code = "[HANDLER] GetTagFeed - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	return writeFeed(c, result)
+}
+
+// writeFeed renders the feed in the format named by the :format param and
+// answers conditional requests. ETags are handled by the etag middleware on
+// the feed routes; If-Modified-Since is only honoured when no If-None-Match
+// was sent, as RFC 9110 requires.
+func writeFeed(c *fiber.Ctx, result *feed.Feed) error {
+	result.FeedLink = c.BaseURL() + c.OriginalURL()
+
+	if !result.Updated.IsZero() {
+		lastModified := result.Updated.UTC().Truncate(time.Second)
+		c.Set(fiber.HeaderLastModified, lastModified.Format(http.TimeFormat))
+
+		if c.Get(fiber.HeaderIfNoneMatch) == "" {
+			since, err := http.ParseTime(c.Get(fiber.HeaderIfModifiedSince))
+			if err == nil && !lastModified.After(since) {
+				return c.SendStatus(fiber.StatusNotModified)
+			}
+		}
+	}
+
+	var body []byte
+	var err error
+	switch c.Params("format") {
+	case "rss":
+		c.Set(fiber.HeaderContentType, "application/rss+xml; charset=utf-8")
+		body, err = result.RSS()
+	case "atom":
+		c.Set(fiber.HeaderContentType, "application/atom+xml; charset=utf-8")
+		body, err = result.Atom()
+	case "json":
+		c.Set(fiber.HeaderContentType, "application/feed+json; charset=utf-8")
+		body, err = result.JSON()
+	default:
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "unsupported feed format, use rss, atom or json"
+		return c.Status(fiber.StatusNotFound).JSON(errResponse)
+	}
+	if err != nil {
+		code = "[HANDLER] writeFeed - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.Send(body)
+}
+
+func NewFeedHandler(feedService service.FeedService) FeedHandler {
+	return &feedHandler{feedService: feedService}
+}
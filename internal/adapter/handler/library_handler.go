@@ -0,0 +1,351 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"news-app/internal/adapter/handler/request"
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/service"
+	"news-app/lib/conv"
+	validatorLib "news-app/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type LibraryHandler interface {
+	GetBookmarks(c *fiber.Ctx) error
+	AddBookmark(c *fiber.Ctx) error
+	DeleteBookmark(c *fiber.Ctx) error
+	GetReadingHistory(c *fiber.Ctx) error
+	SaveReadingHistory(c *fiber.Ctx) error
+	DeleteReadingHistory(c *fiber.Ctx) error
+	ClearReadingHistory(c *fiber.Ctx) error
+}
+
+type libraryHandler struct {
+	libraryService service.LibraryService
+}
+
+// GetBookmarks implements LibraryHandler.
+func (lh *libraryHandler) GetBookmarks(c *fiber.Ctx) error {
+	readerID := readerIDFromToken(c)
+	if readerID == 0 {
+		code = "[HANDLER] GetBookmarks - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	query := parseQueryString(c)
+	results, totalData, err := lh.libraryService.GetBookmarks(c.Context(), readerID, query)
+	if err != nil {
+		code = "[HANDLER] GetBookmarks - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	bookmarkResponses := []response.BookmarkResponse{}
+	for _, result := range results {
+		bookmarkResponses = append(bookmarkResponses, response.BookmarkResponse{
+			CreatedAt: result.CreatedAt.Format(time.RFC3339),
+			Content:   contentToResponse(result.Content, false),
+		})
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Bookmarks fetched successfully"
+	defaultResponse.Data = bookmarkResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// AddBookmark implements LibraryHandler.
+func (lh *libraryHandler) AddBookmark(c *fiber.Ctx) error {
+	readerID := readerIDFromToken(c)
+	if readerID == 0 {
+		code = "[HANDLER] AddBookmark - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	contentID, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] AddBookmark - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = lh.libraryService.AddBookmark(c.Context(), readerID, contentID)
+	if err != nil {
+		code = "[HANDLER] AddBookmark - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errResponse.Meta.Message = "content not found"
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Bookmark saved successfully"
+	defaultResponse.Data = nil
+
+	return c.JSON(defaultResponse)
+}
+
+// DeleteBookmark implements LibraryHandler.
+func (lh *libraryHandler) DeleteBookmark(c *fiber.Ctx) error {
+	readerID := readerIDFromToken(c)
+	if readerID == 0 {
+		code = "[HANDLER] DeleteBookmark - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	contentID, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] DeleteBookmark - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = lh.libraryService.DeleteBookmark(c.Context(), readerID, contentID)
+	if err != nil {
+		code = "[HANDLER] DeleteBookmark - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errResponse.Meta.Message = "bookmark not found"
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Bookmark removed successfully"
+	defaultResponse.Data = nil
+
+	return c.JSON(defaultResponse)
+}
+
+// GetReadingHistory implements LibraryHandler.
+func (lh *libraryHandler) GetReadingHistory(c *fiber.Ctx) error {
+	readerID := readerIDFromToken(c)
+	if readerID == 0 {
+		code = "[HANDLER] GetReadingHistory - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	query := parseQueryString(c)
+	results, totalData, err := lh.libraryService.GetReadingHistory(c.Context(), readerID, query)
+	if err != nil {
+		code = "[HANDLER] GetReadingHistory - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	historyResponses := []response.ReadingHistoryResponse{}
+	for _, result := range results {
+		historyResponses = append(historyResponses, response.ReadingHistoryResponse{
+			Position: result.Position,
+			ReadAt:   result.ReadAt.Format(time.RFC3339),
+			Content:  contentToResponse(result.Content, false),
+		})
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Reading history fetched successfully"
+	defaultResponse.Data = historyResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// SaveReadingHistory implements LibraryHandler.
+func (lh *libraryHandler) SaveReadingHistory(c *fiber.Ctx) error {
+	readerID := readerIDFromToken(c)
+	if readerID == 0 {
+		code = "[HANDLER] SaveReadingHistory - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	contentID, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] SaveReadingHistory - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	var req request.ReadingHistoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] SaveReadingHistory - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] SaveReadingHistory - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = lh.libraryService.SaveReadingHistory(c.Context(), entity.ReadingHistoryEntity{
+		ReaderID:  readerID,
+		ContentID: contentID,
+		Position:  req.Position,
+	})
+	if err != nil {
+		code = "[HANDLER] SaveReadingHistory - 5"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		switch {
+		case errors.Is(err, service.ErrReadingHistoryDisabled):
+			return c.Status(fiber.StatusForbidden).JSON(errResponse)
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			errResponse.Meta.Message = "content not found"
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Reading history saved successfully"
+	defaultResponse.Data = nil
+
+	return c.JSON(defaultResponse)
+}
+
+// DeleteReadingHistory implements LibraryHandler.
+func (lh *libraryHandler) DeleteReadingHistory(c *fiber.Ctx) error {
+	readerID := readerIDFromToken(c)
+	if readerID == 0 {
+		code = "[HANDLER] DeleteReadingHistory - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	contentID, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] DeleteReadingHistory - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = lh.libraryService.DeleteReadingHistory(c.Context(), readerID, contentID)
+	if err != nil {
+		code = "[HANDLER] DeleteReadingHistory - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errResponse.Meta.Message = "history entry not found"
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "History entry removed successfully"
+	defaultResponse.Data = nil
+
+	return c.JSON(defaultResponse)
+}
+
+// ClearReadingHistory implements LibraryHandler.
+func (lh *libraryHandler) ClearReadingHistory(c *fiber.Ctx) error {
+	readerID := readerIDFromToken(c)
+	if readerID == 0 {
+		code = "[HANDLER] ClearReadingHistory - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	deleted, err := lh.libraryService.ClearReadingHistory(c.Context(), readerID)
+	if err != nil {
+		code = "[HANDLER] ClearReadingHistory - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = fmt.Sprintf("Cleared %d history entries", deleted)
+	defaultResponse.Data = nil
+
+	return c.JSON(defaultResponse)
+}
+
+func NewLibraryHandler(libraryService service.LibraryService) LibraryHandler {
+	return &libraryHandler{libraryService: libraryService}
+}
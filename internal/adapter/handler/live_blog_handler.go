@@ -0,0 +1,397 @@
+package handler
+
+import (
+	"bufio"
+	"errors"
+	"time"
+
+	"news-app/internal/adapter/handler/request"
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/service"
+	"news-app/lib/conv"
+	"news-app/lib/richtext"
+	"news-app/lib/sse"
+	validatorLib "news-app/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+const (
+	// liveBlogHeartbeat keeps idle streams open through proxies and notices
+	// readers who left.
+	liveBlogHeartbeat = 15 * time.Second
+
+	// liveBlogRetry is how long, in milliseconds, a reader waits before
+	// reconnecting.
+	liveBlogRetry = 3000
+)
+
+type LiveBlogHandler interface {
+	GetLiveBlog(c *fiber.Ctx) error
+	StreamLiveBlog(c *fiber.Ctx) error
+
+	GetEntries(c *fiber.Ctx) error
+	CreateEntry(c *fiber.Ctx) error
+	UpdateEntry(c *fiber.Ctx) error
+	DeleteEntry(c *fiber.Ctx) error
+}
+
+type liveBlogHandler struct {
+	liveBlogService service.LiveBlogService
+}
+
+// GetLiveBlog implements LiveBlogHandler.
+func (lh *liveBlogHandler) GetLiveBlog(c *fiber.Ctx) error {
+	query := parseQueryString(c)
+
+	result, totalData, err := lh.liveBlogService.GetLiveBlog(c.Context(), c.Params("slug"), query)
+	if err != nil {
+		code = "[HANDLER] GetLiveBlog - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	entryResponses := []response.LiveBlogEntryResponse{}
+	for _, entry := range result.Entries {
+		entryResponses = append(entryResponses, liveBlogEntryToResponse(entry, false))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Live blog fetched successfully"
+	defaultResponse.Data = response.LiveBlogResponse{
+		LastEventID: result.LastEventID,
+		Entries:     entryResponses,
+	}
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// StreamLiveBlog implements LiveBlogHandler. Readers resume from the
+// Last-Event-ID header browsers send on reconnect, or from the
+// last_event_id query parameter returned with the entries.
+func (lh *liveBlogHandler) StreamLiveBlog(c *fiber.Ctx) error {
+	lastEventID := c.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	var afterID int64
+	if lastEventID != "" {
+		afterID, err = conv.StringToInt64(lastEventID)
+		if err != nil || afterID < 0 {
+			code = "[HANDLER] StreamLiveBlog - 1"
+			log.Errorw(code, err)
+			errResponse.Meta.Status = false
+			errResponse.Meta.Message = "Invalid last event id"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+	}
+
+	stream, err := lh.liveBlogService.OpenStream(c.Context(), c.Params("slug"), afterID)
+	if err != nil {
+		code = "[HANDLER] StreamLiveBlog - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The writer runs after the handler returns, so it must not touch c.
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer stream.Close()
+
+		if sse.WriteRetry(w, liveBlogRetry) != nil {
+			return
+		}
+		for _, event := range stream.Replay {
+			if sse.Write(w, event) != nil {
+				return
+			}
+		}
+
+		heartbeat := time.NewTicker(liveBlogHeartbeat)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case event, ok := <-stream.Events:
+				if !ok {
+					return
+				}
+				if event.ID <= stream.LastEventID {
+					continue
+				}
+				if sse.Write(w, event) != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if sse.WriteComment(w, "ping") != nil {
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+// GetEntries implements LiveBlogHandler.
+func (lh *liveBlogHandler) GetEntries(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetEntries - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	contentID, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] GetEntries - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	query := parseQueryString(c)
+	results, totalData, err := lh.liveBlogService.GetEntries(c.Context(), contentID, query)
+	if err != nil {
+		code = "[HANDLER] GetEntries - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	entryResponses := []response.LiveBlogEntryResponse{}
+	for _, result := range results {
+		entryResponses = append(entryResponses, liveBlogEntryToResponse(result, true))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Live blog entries fetched successfully"
+	defaultResponse.Data = entryResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// CreateEntry implements LiveBlogHandler.
+func (lh *liveBlogHandler) CreateEntry(c *fiber.Ctx) error {
+	return lh.saveEntry(c, "CreateEntry")
+}
+
+// UpdateEntry implements LiveBlogHandler.
+func (lh *liveBlogHandler) UpdateEntry(c *fiber.Ctx) error {
+	return lh.saveEntry(c, "UpdateEntry")
+}
+
+// DeleteEntry implements LiveBlogHandler.
+func (lh *liveBlogHandler) DeleteEntry(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] DeleteEntry - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	contentID, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] DeleteEntry - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	entryID, err := conv.StringToInt64(c.Params("entryId"))
+	if err != nil {
+		code = "[HANDLER] DeleteEntry - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = lh.liveBlogService.DeleteEntry(c.Context(), contentID, entryID)
+	if err != nil {
+		code = "[HANDLER] DeleteEntry - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Live blog entry deleted successfully"
+	defaultResponse.Data = nil
+
+	return c.JSON(defaultResponse)
+}
+
+// saveEntry creates an entry, or updates the one in the path.
+func (lh *liveBlogHandler) saveEntry(c *fiber.Ctx, name string) error {
+	var req request.LiveBlogEntryRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] " + name + " - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	contentID, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] " + name + " - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	var entryID int64
+	if c.Params("entryId") != "" {
+		entryID, err = conv.StringToInt64(c.Params("entryId"))
+		if err != nil {
+			code = "[HANDLER] " + name + " - 3"
+			log.Errorw(code, err)
+			errResponse.Meta.Status = false
+			errResponse.Meta.Message = err.Error()
+
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+	}
+
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] " + name + " - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] " + name + " - 5"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	reqEntity := entity.LiveBlogEntryEntity{
+		ID:          entryID,
+		ContentID:   contentID,
+		Title:       req.Title,
+		Body:        req.Body,
+		BodyFormat:  req.BodyFormat,
+		Pinned:      req.Pinned,
+		CreatedByID: int64(userID),
+	}
+
+	var result *entity.LiveBlogEntryEntity
+	if entryID == 0 {
+		result, err = lh.liveBlogService.CreateEntry(c.Context(), reqEntity)
+	} else {
+		result, err = lh.liveBlogService.UpdateEntry(c.Context(), reqEntity)
+	}
+	if err != nil {
+		code = "[HANDLER] " + name + " - 6"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		case errors.Is(err, service.ErrNotLiveBlog), errors.Is(err, richtext.ErrUnknownFormat):
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Data = liveBlogEntryToResponse(*result, true)
+	if entryID == 0 {
+		defaultResponse.Meta.Message = "Live blog entry created successfully"
+		return c.Status(fiber.StatusCreated).JSON(defaultResponse)
+	}
+
+	defaultResponse.Meta.Message = "Live blog entry updated successfully"
+	return c.JSON(defaultResponse)
+}
+
+// liveBlogEntryToResponse leaves the source of the body to editors.
+func liveBlogEntryToResponse(result entity.LiveBlogEntryEntity, admin bool) response.LiveBlogEntryResponse {
+	res := response.LiveBlogEntryResponse{
+		ID:        result.ID,
+		Title:     result.Title,
+		BodyHTML:  result.BodyHTML,
+		Pinned:    result.Pinned,
+		Author:    result.Author,
+		CreatedAt: result.CreatedAt.Format(time.RFC3339),
+	}
+	if result.UpdatedAt != nil {
+		res.UpdatedAt = result.UpdatedAt.Format(time.RFC3339)
+	}
+	if admin {
+		res.Body = result.Body
+		res.BodyFormat = result.BodyFormat
+	}
+
+	return res
+}
+
+func NewLiveBlogHandler(liveBlogService service.LiveBlogService) LiveBlogHandler {
+	return &liveBlogHandler{liveBlogService: liveBlogService}
+}
@@ -0,0 +1,571 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"news-app/internal/adapter/handler/request"
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/service"
+	"news-app/lib/conv"
+	"news-app/lib/imaging"
+	validatorLib "news-app/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+const maxImageSize = 10 * 1024 * 1024
+
+type MediaHandler interface {
+	GetMedia(c *fiber.Ctx) error
+	GetMediaByID(c *fiber.Ctx) error
+	UploadMedia(c *fiber.Ctx) error
+	UpdateMedia(c *fiber.Ctx) error
+	DeleteMedia(c *fiber.Ctx) error
+
+	CreateUpload(c *fiber.Ctx) error
+	CompleteUpload(c *fiber.Ctx) error
+}
+
+type mediaHandler struct {
+	mediaService service.MediaService
+}
+
+// GetMedia implements MediaHandler.
+func (mh *mediaHandler) GetMedia(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetMedia - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	query := parseQueryString(c)
+	results, totalData, err := mh.mediaService.GetMedia(c.Context(), query)
+	if err != nil {
+		code = "[HANDLER] GetMedia - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	mediaResponses := []response.MediaResponse{}
+	for _, result := range results {
+		mediaResponses = append(mediaResponses, mediaToResponse(result))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Media fetched successfully"
+	defaultResponse.Data = mediaResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// GetMediaByID implements MediaHandler.
+func (mh *mediaHandler) GetMediaByID(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetMediaByID - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("mediaId"))
+	if err != nil {
+		code = "[HANDLER] GetMediaByID - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := mh.mediaService.GetMediaByID(c.Context(), id)
+	if err != nil {
+		code = "[HANDLER] GetMediaByID - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Message = "Media fetched successfully"
+	defaultResponse.Data = mediaToResponse(*result)
+
+	return c.JSON(defaultResponse)
+}
+
+// UploadMedia implements MediaHandler. It takes a multipart form with the
+// image in the "file" field and the metadata of MediaRequest alongside it.
+func (mh *mediaHandler) UploadMedia(c *fiber.Ctx) error {
+	var req request.MediaRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] UploadMedia - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		code = "[HANDLER] UploadMedia - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Field file is required"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if fileHeader.Size > maxImageSize {
+		code = "[HANDLER] UploadMedia - 3"
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "File must be at most " + strconv.Itoa(maxImageSize/1024/1024) + "MB"
+
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(errResponse)
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] UploadMedia - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] UploadMedia - 5"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		code = "[HANDLER] UploadMedia - 6"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		code = "[HANDLER] UploadMedia - 7"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	reqEntity := mediaRequestToEntity(req)
+	reqEntity.FileName = fileHeader.Filename
+	reqEntity.UploadedByID = int64(userID)
+
+	result, err := mh.mediaService.UploadMedia(c.Context(), reqEntity, data)
+	if err != nil {
+		code = "[HANDLER] UploadMedia - 8"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		switch {
+		case errors.Is(err, imaging.ErrUnsupportedFormat):
+			return c.Status(fiber.StatusUnsupportedMediaType).JSON(errResponse)
+		case errors.Is(err, imaging.ErrTooManyPixels):
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Media uploaded successfully"
+	defaultResponse.Data = mediaToResponse(*result)
+
+	return c.Status(fiber.StatusCreated).JSON(defaultResponse)
+}
+
+// UpdateMedia implements MediaHandler.
+func (mh *mediaHandler) UpdateMedia(c *fiber.Ctx) error {
+	var req request.MediaRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] UpdateMedia - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("mediaId"))
+	if err != nil {
+		code = "[HANDLER] UpdateMedia - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] UpdateMedia - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] UpdateMedia - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	reqEntity := mediaRequestToEntity(req)
+	reqEntity.ID = id
+
+	result, err := mh.mediaService.UpdateMedia(c.Context(), reqEntity)
+	if err != nil {
+		code = "[HANDLER] UpdateMedia - 5"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Media updated successfully"
+	defaultResponse.Data = mediaToResponse(*result)
+
+	return c.JSON(defaultResponse)
+}
+
+// DeleteMedia implements MediaHandler.
+func (mh *mediaHandler) DeleteMedia(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] DeleteMedia - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("mediaId"))
+	if err != nil {
+		code = "[HANDLER] DeleteMedia - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = mh.mediaService.DeleteMedia(c.Context(), id)
+	if err != nil {
+		code = "[HANDLER] DeleteMedia - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+		if errors.Is(err, repository.ErrMediaInUse) {
+			return c.Status(fiber.StatusConflict).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Media deleted successfully"
+	defaultResponse.Data = nil
+
+	return c.JSON(defaultResponse)
+}
+
+// CreateUpload implements MediaHandler.
+func (mh *mediaHandler) CreateUpload(c *fiber.Ctx) error {
+	var req request.MediaUploadRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] CreateUpload - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] CreateUpload - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] CreateUpload - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := mh.mediaService.CreateUpload(c.Context(), entity.MediaUploadEntity{
+		FileName:     req.FileName,
+		ContentType:  req.ContentType,
+		Size:         req.Size,
+		UploadedByID: int64(userID),
+	})
+	if err != nil {
+		code = "[HANDLER] CreateUpload - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrUnsupportedUploadType) {
+			return c.Status(fiber.StatusUnsupportedMediaType).JSON(errResponse)
+		}
+		if errors.Is(err, service.ErrUploadTooLarge) {
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Upload created successfully"
+	defaultResponse.Data = response.MediaUploadResponse{
+		ID:        result.ID,
+		ObjectKey: result.ObjectKey,
+		UploadURL: result.UploadURL,
+		Method:    result.UploadMethod,
+		Headers:   result.UploadHeaders,
+		ExpiresAt: result.ExpiresAt.Format(time.RFC3339),
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(defaultResponse)
+}
+
+// CompleteUpload implements MediaHandler. The body carries the same
+// metadata as UpdateMedia.
+func (mh *mediaHandler) CompleteUpload(c *fiber.Ctx) error {
+	var req request.MediaRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] CompleteUpload - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("uploadId"))
+	if err != nil {
+		code = "[HANDLER] CompleteUpload - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] CompleteUpload - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] CompleteUpload - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	reqEntity := mediaRequestToEntity(req)
+	reqEntity.UploadedByID = int64(userID)
+
+	result, err := mh.mediaService.CompleteUpload(c.Context(), id, reqEntity)
+	if err != nil {
+		code = "[HANDLER] CompleteUpload - 5"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+		if errors.Is(err, service.ErrUploadNotReceived) {
+			return c.Status(fiber.StatusConflict).JSON(errResponse)
+		}
+		if errors.Is(err, service.ErrUploadMismatch) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(errResponse)
+		}
+		if errors.Is(err, service.ErrUploadNotOwned) {
+			return c.Status(fiber.StatusForbidden).JSON(errResponse)
+		}
+		if errors.Is(err, service.ErrUploadExpired) {
+			return c.Status(fiber.StatusGone).JSON(errResponse)
+		}
+		if errors.Is(err, imaging.ErrUnsupportedFormat) {
+			return c.Status(fiber.StatusUnsupportedMediaType).JSON(errResponse)
+		}
+		if errors.Is(err, imaging.ErrTooManyPixels) {
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Upload completed successfully"
+	defaultResponse.Data = mediaToResponse(*result)
+
+	return c.Status(fiber.StatusCreated).JSON(defaultResponse)
+}
+
+func mediaRequestToEntity(req request.MediaRequest) entity.MediaEntity {
+	// Multipart forms send tags as one comma separated value.
+	tags := []string{}
+	for _, tag := range req.Tags {
+		tags = append(tags, strings.Split(tag, ",")...)
+	}
+
+	return entity.MediaEntity{
+		AltText:   req.AltText,
+		Caption:   req.Caption,
+		Credit:    req.Credit,
+		Copyright: req.Copyright,
+		License:   req.License,
+		Tags:      tags,
+	}
+}
+
+func mediaToResponse(result entity.MediaEntity) response.MediaResponse {
+	res := response.MediaResponse{
+		ID:         result.ID,
+		URL:        result.URL,
+		FileName:   result.FileName,
+		MimeType:   result.MimeType,
+		Size:       result.Size,
+		Width:      result.Width,
+		Height:     result.Height,
+		AltText:    result.AltText,
+		Caption:    result.Caption,
+		Credit:     result.Credit,
+		Copyright:  result.Copyright,
+		License:    result.License,
+		Tags:       result.Tags,
+		UsageCount: result.UsageCount,
+		Variants:   imageVariantsToResponse(result.Variants),
+		Srcset:     srcset(result.Variants),
+		CreatedAt:  result.CreatedAt.Format(time.RFC3339),
+	}
+	if res.Tags == nil {
+		res.Tags = []string{}
+	}
+
+	return res
+}
+
+func imageVariantsToResponse(variants []entity.ImageVariantEntity) []response.ImageVariantResponse {
+	res := []response.ImageVariantResponse{}
+	for _, variant := range variants {
+		res = append(res, response.ImageVariantResponse{
+			Name:   variant.Name,
+			URL:    variant.URL,
+			Width:  variant.Width,
+			Height: variant.Height,
+		})
+	}
+
+	return res
+}
+
+// srcset formats variants as an HTML srcset attribute value.
+func srcset(variants []entity.ImageVariantEntity) string {
+	parts := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		parts = append(parts, variant.URL+" "+strconv.Itoa(variant.Width)+"w")
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func NewMediaHandler(mediaService service.MediaService) MediaHandler {
+	return &mediaHandler{mediaService: mediaService}
+}
@@ -0,0 +1,397 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"news-app/internal/adapter/handler/request"
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/service"
+	validatorLib "news-app/lib/validator"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+const (
+	// notificationPingInterval is how often the server pings an idle
+	// connection; a client that has not answered within
+	// notificationPongWait is gone.
+	notificationPingInterval = 30 * time.Second
+	notificationPongWait     = 70 * time.Second
+	notificationWriteWait    = 10 * time.Second
+
+	// notificationMaxMessage bounds what clients may send.
+	notificationMaxMessage = 4096
+)
+
+type NotificationHandler interface {
+	GetNotifications(c *fiber.Ctx) error
+	GetUnreadNotifications(c *fiber.Ctx) error
+	MarkNotificationsRead(c *fiber.Ctx) error
+	GetSubscriptions(c *fiber.Ctx) error
+	UpdateSubscriptions(c *fiber.Ctx) error
+	Socket(c *fiber.Ctx) error
+}
+
+type notificationHandler struct {
+	notificationService service.NotificationService
+	socket              fiber.Handler
+}
+
+// GetNotifications implements NotificationHandler.
+func (nh *notificationHandler) GetNotifications(c *fiber.Ctx) error {
+	return nh.listNotifications(c, "GetNotifications", false)
+}
+
+// GetUnreadNotifications implements NotificationHandler. It is the fallback
+// for clients that cannot keep a WebSocket open.
+func (nh *notificationHandler) GetUnreadNotifications(c *fiber.Ctx) error {
+	return nh.listNotifications(c, "GetUnreadNotifications", true)
+}
+
+// MarkNotificationsRead implements NotificationHandler. Without ids every
+// notification is marked as read.
+func (nh *notificationHandler) MarkNotificationsRead(c *fiber.Ctx) error {
+	var req request.NotificationReadRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] MarkNotificationsRead - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] MarkNotificationsRead - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := nh.markRead(c.Context(), int64(userID), req.IDs)
+	if err != nil {
+		code = "[HANDLER] MarkNotificationsRead - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Notifications marked as read"
+	defaultResponse.Data = result
+
+	return c.JSON(defaultResponse)
+}
+
+// GetSubscriptions implements NotificationHandler.
+func (nh *notificationHandler) GetSubscriptions(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetSubscriptions - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	result, err := nh.notificationService.GetSubscriptions(c.Context(), int64(userID))
+	if err != nil {
+		code = "[HANDLER] GetSubscriptions - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Notification subscriptions fetched successfully"
+	defaultResponse.Data = result
+
+	return c.JSON(defaultResponse)
+}
+
+// UpdateSubscriptions implements NotificationHandler.
+func (nh *notificationHandler) UpdateSubscriptions(c *fiber.Ctx) error {
+	var req request.NotificationSubscriptionsRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] UpdateSubscriptions - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] UpdateSubscriptions - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] UpdateSubscriptions - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := nh.notificationService.UpdateSubscriptions(c.Context(), int64(userID), req.Subscriptions)
+	if err != nil {
+		code = "[HANDLER] UpdateSubscriptions - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrUnknownNotificationType) {
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Notification subscriptions updated successfully"
+	defaultResponse.Data = result
+
+	return c.JSON(defaultResponse)
+}
+
+// Socket implements NotificationHandler. It upgrades the request to a
+// WebSocket delivering the notifications of the signed in user.
+func (nh *notificationHandler) Socket(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	if claims.UserID == 0 {
+		code = "[HANDLER] Socket - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	return nh.socket(c)
+}
+
+// serveSocket sends a hello with the unread count, then every new
+// notification. Clients may send "ping", answered with "pong", and "read"
+// to mark notifications, answered with the new unread count. The server
+// pings idle connections and drops those that stop answering.
+func (nh *notificationHandler) serveSocket(conn *websocket.Conn) {
+	userID := int64(conn.Locals("user").(*entity.JwtData).UserID)
+	// The request is over once upgraded; its context is gone.
+	ctx := context.Background()
+
+	subscription := nh.notificationService.Subscribe(userID)
+	defer subscription.Close()
+
+	unread, err := nh.notificationService.CountUnread(ctx, userID)
+	if err != nil {
+		code := "[HANDLER] serveSocket - 1"
+		log.Errorw(code, err)
+		return
+	}
+	if writeSocket(conn, "hello", response.NotificationUnreadResponse{Unread: unread}) != nil {
+		return
+	}
+
+	conn.SetReadLimit(notificationMaxMessage)
+	conn.SetReadDeadline(time.Now().Add(notificationPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(notificationPongWait))
+	})
+
+	// Only this goroutine writes; the reader hands messages over. The
+	// connection is reused once the handler returns, so the reader must be
+	// gone by then.
+	messages := make(chan request.NotificationSocketRequest)
+	done := make(chan struct{})
+	quit := make(chan struct{})
+	defer func() {
+		close(quit)
+		conn.Close()
+		<-done
+	}()
+	go func() {
+		defer close(done)
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			conn.SetReadDeadline(time.Now().Add(notificationPongWait))
+
+			var req request.NotificationSocketRequest
+			if json.Unmarshal(data, &req) != nil {
+				continue
+			}
+
+			select {
+			case messages <- req:
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(notificationPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case event, ok := <-subscription.C:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(notificationWriteWait))
+				return
+			}
+			if writeSocket(conn, "notification", json.RawMessage(event.Data)) != nil {
+				return
+			}
+		case req := <-messages:
+			switch req.Type {
+			case "ping":
+				err = writeSocket(conn, "pong", nil)
+			case "read":
+				var result *response.NotificationReadResponse
+				result, err = nh.markRead(ctx, userID, req.IDs)
+				if err == nil {
+					err = writeSocket(conn, "read", result)
+				}
+			}
+			if err != nil {
+				return
+			}
+		case <-ping.C:
+			if conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(notificationWriteWait)) != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func (nh *notificationHandler) listNotifications(c *fiber.Ctx, name string, unreadOnly bool) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] " + name + " - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	query := parseQueryString(c)
+	results, totalData, err := nh.notificationService.GetNotifications(c.Context(), int64(userID), unreadOnly, query)
+	if err != nil {
+		code = "[HANDLER] " + name + " - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	notificationResponses := []response.NotificationResponse{}
+	for _, result := range results {
+		notificationResponses = append(notificationResponses, notificationToResponse(result))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Notifications fetched successfully"
+	defaultResponse.Data = notificationResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+func (nh *notificationHandler) markRead(ctx context.Context, userID int64, ids []int64) (*response.NotificationReadResponse, error) {
+	marked, err := nh.notificationService.MarkNotificationsRead(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := nh.notificationService.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.NotificationReadResponse{Marked: marked, Unread: unread}, nil
+}
+
+func writeSocket(conn *websocket.Conn, messageType string, data interface{}) error {
+	res := response.NotificationSocketResponse{Type: messageType}
+	if data != nil {
+		raw, ok := data.(json.RawMessage)
+		if !ok {
+			var err error
+			raw, err = json.Marshal(data)
+			if err != nil {
+				return err
+			}
+		}
+		res.Data = raw
+	}
+
+	conn.SetWriteDeadline(time.Now().Add(notificationWriteWait))
+	return conn.WriteJSON(res)
+}
+
+func notificationToResponse(result entity.NotificationEntity) response.NotificationResponse {
+	res := response.NotificationResponse{
+		ID:           result.ID,
+		Type:         result.Type,
+		Message:      result.Message,
+		Actor:        result.Actor,
+		ContentID:    result.ContentID,
+		ContentTitle: result.ContentTitle,
+		CommentID:    result.CommentID,
+		CreatedAt:    result.CreatedAt.Format(time.RFC3339),
+	}
+	if result.ReadAt != nil {
+		res.ReadAt = result.ReadAt.Format(time.RFC3339)
+	}
+
+	return res
+}
+
+func NewNotificationHandler(notificationService service.NotificationService) NotificationHandler {
+	nh := &notificationHandler{notificationService: notificationService}
+	nh.socket = websocket.New(nh.serveSocket)
+
+	return nh
+}
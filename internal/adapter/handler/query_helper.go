@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"math"
+
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const maxPerPage = 100
+
+func parseQueryString(c *fiber.Ctx) entity.QueryString {
+	query := entity.QueryString{
+		Limit:      c.QueryInt("limit", 10),
+		Page:       c.QueryInt("page", 1),
+		OrderBy:    c.Query("order_by", "created_at"),
+		OrderType:  c.Query("order_type", "desc"),
+		Search:     c.Query("search"),
+		Status:     c.Query("status"),
+		CategoryID: int64(c.QueryInt("category_id", 0)),
+		Tag:        c.Query("tag"),
+	}
+
+	if query.Limit <= 0 || query.Limit > maxPerPage {
+		query.Limit = 10
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+
+	return query
+}
+
+func paginationResponse(totalData int64, query entity.QueryString) *response.PaginationResponse {
+	return &response.PaginationResponse{
+		TotalRecords: int(totalData),
+		Page:         query.Page,
+		PerPage:      query.Limit,
+		TotalPage:    int(math.Ceil(float64(totalData) / float64(query.Limit))),
+	}
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"news-app/internal/adapter/handler/request"
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/service"
+	"news-app/lib/conv"
+	validatorLib "news-app/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// reactorCookie keeps anonymous visitors to one reaction per story.
+const reactorCookie = "reactor_id"
+
+type ReactionHandler interface {
+	GetReactions(c *fiber.Ctx) error
+	SetReaction(c *fiber.Ctx) error
+	DeleteReaction(c *fiber.Ctx) error
+}
+
+type reactionHandler struct {
+	reactionService service.ReactionService
+}
+
+// GetReactions implements ReactionHandler.
+func (rh *reactionHandler) GetReactions(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] GetReactions - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := rh.reactionService.GetReactions(c.Context(), contentID, reactor(c, false))
+	if err != nil {
+		code = "[HANDLER] GetReactions - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Reactions fetched successfully"
+	defaultResponse.Data = reactionSummaryToResponse(*result)
+
+	return c.JSON(defaultResponse)
+}
+
+// SetReaction implements ReactionHandler.
+func (rh *reactionHandler) SetReaction(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] SetReaction - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	var req request.ReactionRequest
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] SetReaction - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] SetReaction - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := rh.reactionService.SetReaction(c.Context(), contentID, reactor(c, true), strings.ToUpper(strings.TrimSpace(req.Reaction)))
+	if err != nil {
+		code = "[HANDLER] SetReaction - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		switch {
+		case errors.Is(err, service.ErrUnknownReaction):
+			errResponse.Meta.Message = "reaction must be one of " + strings.Join(entity.ReactionKinds, ", ")
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Reaction saved successfully"
+	defaultResponse.Data = reactionSummaryToResponse(*result)
+
+	return c.JSON(defaultResponse)
+}
+
+// DeleteReaction implements ReactionHandler.
+func (rh *reactionHandler) DeleteReaction(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentId"))
+	if err != nil {
+		code = "[HANDLER] DeleteReaction - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := rh.reactionService.DeleteReaction(c.Context(), contentID, reactor(c, false))
+	if err != nil {
+		code = "[HANDLER] DeleteReaction - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Reaction removed successfully"
+	defaultResponse.Data = reactionSummaryToResponse(*result)
+
+	return c.JSON(defaultResponse)
+}
+
+// reactor identifies who reacts: the signed-in reader, or else the visitor
+// of the fingerprint cookie, which is handed out first when create is set.
+func reactor(c *fiber.Ctx, create bool) entity.ReactorEntity {
+	if readerID := readerIDFromToken(c); readerID > 0 {
+		return entity.ReactorEntity{ReaderID: readerID}
+	}
+
+	fingerprint := c.Cookies(reactorCookie)
+	if _, err := uuid.Parse(fingerprint); err == nil {
+		return entity.ReactorEntity{Fingerprint: fingerprint}
+	}
+	if !create {
+		return entity.ReactorEntity{}
+	}
+
+	fingerprint = uuid.NewString()
+	c.Cookie(&fiber.Cookie{
+		Name:     reactorCookie,
+		Value:    fingerprint,
+		Path:     "/",
+		Expires:  time.Now().AddDate(1, 0, 0),
+		HTTPOnly: true,
+		Secure:   c.Protocol() == "https",
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	return entity.ReactorEntity{Fingerprint: fingerprint}
+}
+
+// reactionCountsToResponse lists every reaction, with a zero count for
+// those nobody picked yet.
+func reactionCountsToResponse(counts map[string]int64) map[string]int64 {
+	res := map[string]int64{}
+	for _, kind := range entity.ReactionKinds {
+		res[kind] = counts[kind]
+	}
+
+	return res
+}
+
+func reactionSummaryToResponse(result entity.ReactionSummaryEntity) response.ReactionResponse {
+	return response.ReactionResponse{
+		Counts: reactionCountsToResponse(result.Counts),
+		Mine:   result.Mine,
+	}
+}
+
+func NewReactionHandler(reactionService service.ReactionService) ReactionHandler {
+	return &reactionHandler{reactionService: reactionService}
+}
@@ -0,0 +1,439 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"news-app/internal/adapter/handler/request"
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/service"
+	validatorLib "news-app/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type ReaderHandler interface {
+	Register(c *fiber.Ctx) error
+	VerifyEmail(c *fiber.Ctx) error
+	ResendVerification(c *fiber.Ctx) error
+	Login(c *fiber.Ctx) error
+	GetProfile(c *fiber.Ctx) error
+	UpdateProfile(c *fiber.Ctx) error
+	UpdatePassword(c *fiber.Ctx) error
+	DeleteAccount(c *fiber.Ctx) error
+}
+
+type readerHandler struct {
+	readerService service.ReaderService
+}
+
+// Register implements ReaderHandler.
+func (rh *readerHandler) Register(c *fiber.Ctx) error {
+	var req request.RegisterReaderRequest
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] Register - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] Register - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := rh.readerService.Register(c.Context(), entity.ReaderEntity{
+		Name:     req.Name,
+		Email:    req.Email,
+		Password: req.Password,
+	})
+	if err != nil {
+		code = "[HANDLER] Register - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrReaderEmailTaken) {
+			return c.Status(fiber.StatusConflict).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Registration successful, please check your email to verify your address"
+	defaultResponse.Data = readerToResponse(*result)
+
+	return c.Status(fiber.StatusCreated).JSON(defaultResponse)
+}
+
+// VerifyEmail implements ReaderHandler.
+func (rh *readerHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req request.VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] VerifyEmail - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] VerifyEmail - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := rh.readerService.VerifyEmail(c.Context(), req.Token)
+	if err != nil {
+		code = "[HANDLER] VerifyEmail - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrReaderInvalidToken) {
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Email verified successfully"
+	defaultResponse.Data = readerToResponse(*result)
+
+	return c.JSON(defaultResponse)
+}
+
+// ResendVerification implements ReaderHandler. The answer is the same
+// whether or not the address has an account.
+func (rh *readerHandler) ResendVerification(c *fiber.Ctx) error {
+	var req request.ResendVerificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] ResendVerification - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] ResendVerification - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = rh.readerService.ResendVerification(c.Context(), req.Email)
+	if err != nil {
+		code = "[HANDLER] ResendVerification - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "If the address is registered and not verified yet, a new link has been sent"
+	defaultResponse.Data = nil
+
+	return c.JSON(defaultResponse)
+}
+
+// Login implements ReaderHandler.
+func (rh *readerHandler) Login(c *fiber.Ctx) error {
+	req := request.LoginRequest{}
+	res := response.SuccessAuthResponse{}
+
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] Login - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] Login - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := rh.readerService.Login(c.Context(), entity.LoginRequest{
+		Email:    req.Email,
+		Password: req.Password,
+	})
+	if err != nil {
+		code = "[HANDLER] Login - 3"
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		switch {
+		case errors.Is(err, service.ErrReaderInvalidCredentials):
+			return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+		case errors.Is(err, service.ErrReaderNotVerified):
+			return c.Status(fiber.StatusForbidden).JSON(errResponse)
+		}
+
+		log.Errorw(code, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	res.Meta.Status = true
+	res.Meta.Message = "Login success"
+	res.AccessToken = result.Token
+	res.ExpiredAt = result.ExpireAt
+
+	return c.JSON(res)
+}
+
+// GetProfile implements ReaderHandler.
+func (rh *readerHandler) GetProfile(c *fiber.Ctx) error {
+	readerID := readerIDFromToken(c)
+	if readerID == 0 {
+		code = "[HANDLER] GetProfile - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	result, err := rh.readerService.GetReaderByID(c.Context(), readerID)
+	if err != nil {
+		code = "[HANDLER] GetProfile - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Profile fetched successfully"
+	defaultResponse.Data = readerToResponse(*result)
+
+	return c.JSON(defaultResponse)
+}
+
+// UpdateProfile implements ReaderHandler.
+func (rh *readerHandler) UpdateProfile(c *fiber.Ctx) error {
+	readerID := readerIDFromToken(c)
+	if readerID == 0 {
+		code = "[HANDLER] UpdateProfile - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	var req request.UpdateReaderRequest
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] UpdateProfile - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] UpdateProfile - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := rh.readerService.UpdateReader(c.Context(), entity.ReaderEntity{ID: readerID, Name: req.Name}, req.HistoryEnabled)
+	if err != nil {
+		code = "[HANDLER] UpdateProfile - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Profile updated successfully"
+	defaultResponse.Data = readerToResponse(*result)
+
+	return c.JSON(defaultResponse)
+}
+
+// UpdatePassword implements ReaderHandler.
+func (rh *readerHandler) UpdatePassword(c *fiber.Ctx) error {
+	readerID := readerIDFromToken(c)
+	if readerID == 0 {
+		code = "[HANDLER] UpdatePassword - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	var req request.UpdateReaderPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] UpdatePassword - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] UpdatePassword - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = rh.readerService.UpdateReaderPassword(c.Context(), readerID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		code = "[HANDLER] UpdatePassword - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		switch {
+		case errors.Is(err, service.ErrReaderInvalidCredentials):
+			errResponse.Meta.Message = "current password is incorrect"
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Password updated successfully"
+	defaultResponse.Data = nil
+
+	return c.JSON(defaultResponse)
+}
+
+// DeleteAccount implements ReaderHandler.
+func (rh *readerHandler) DeleteAccount(c *fiber.Ctx) error {
+	readerID := readerIDFromToken(c)
+	if readerID == 0 {
+		code = "[HANDLER] DeleteAccount - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	var req request.DeleteReaderRequest
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] DeleteAccount - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] DeleteAccount - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = rh.readerService.DeleteReader(c.Context(), readerID, req.Password)
+	if err != nil {
+		code = "[HANDLER] DeleteAccount - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		switch {
+		case errors.Is(err, service.ErrReaderInvalidCredentials):
+			errResponse.Meta.Message = "password is incorrect"
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Account deleted successfully"
+	defaultResponse.Data = nil
+
+	return c.JSON(defaultResponse)
+}
+
+// readerIDFromToken returns the reader signed in on a route behind
+// CheckReaderToken, or 0 when the token is not a reader token.
+func readerIDFromToken(c *fiber.Ctx) int64 {
+	claims, ok := c.Locals("user").(*entity.JwtData)
+	if !ok || claims.Kind != entity.JwtKindReader {
+		return 0
+	}
+
+	return int64(claims.ReaderID)
+}
+
+func readerToResponse(result entity.ReaderEntity) response.ReaderResponse {
+	return response.ReaderResponse{
+		ID:             result.ID,
+		Name:           result.Name,
+		Email:          result.Email,
+		EmailVerified:  result.EmailVerifiedAt != nil,
+		HistoryEnabled: result.HistoryEnabled,
+		CreatedAt:      result.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewReaderHandler(readerService service.ReaderService) ReaderHandler {
+	return &readerHandler{readerService: readerService}
+}
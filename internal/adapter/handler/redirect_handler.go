@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"news-app/internal/adapter/handler/request"
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/service"
+	"news-app/lib/conv"
+	validatorLib "news-app/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type RedirectHandler interface {
+	GetRedirects(c *fiber.Ctx) error
+	GetRedirectByID(c *fiber.Ctx) error
+	CreateRedirect(c *fiber.Ctx) error
+	UpdateRedirect(c *fiber.Ctx) error
+	DeleteRedirect(c *fiber.Ctx) error
+
+	Redirect(c *fiber.Ctx) error
+}
+
+type redirectHandler struct {
+	redirectService service.RedirectService
+}
+
+// GetRedirects implements RedirectHandler.
+func (rh *redirectHandler) GetRedirects(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetRedirects - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	query := parseQueryString(c)
+	results, totalData, err := rh.redirectService.GetRedirects(c.Context(), query)
+	if err != nil {
+		code = "[HANDLER] GetRedirects - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	redirectResponses := []response.RedirectResponse{}
+	for _, result := range results {
+		redirectResponses = append(redirectResponses, redirectToResponse(result))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Redirects fetched successfully"
+	defaultResponse.Data = redirectResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// GetRedirectByID implements RedirectHandler.
+func (rh *redirectHandler) GetRedirectByID(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetRedirectByID - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("redirectId"))
+	if err != nil {
+		code = "[HANDLER] GetRedirectByID - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := rh.redirectService.GetRedirectByID(c.Context(), id)
+	if err != nil {
+		code = "[HANDLER] GetRedirectByID - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Message = "Redirect fetched successfully"
+	defaultResponse.Data = redirectToResponse(*result)
+
+	return c.JSON(defaultResponse)
+}
+
+// CreateRedirect implements RedirectHandler.
+func (rh *redirectHandler) CreateRedirect(c *fiber.Ctx) error {
+	var req request.RedirectRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] CreateRedirect - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] CreateRedirect - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] CreateRedirect - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	reqEntity := redirectRequestToEntity(req)
+	reqEntity.CreatedByID = int64(userID)
+
+	result, err := rh.redirectService.CreateRedirect(c.Context(), reqEntity)
+	if err != nil {
+		code = "[HANDLER] CreateRedirect - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrRedirectLoop) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Redirect created successfully"
+	defaultResponse.Data = redirectToResponse(*result)
+	return c.Status(fiber.StatusCreated).JSON(defaultResponse)
+}
+
+// UpdateRedirect implements RedirectHandler.
+func (rh *redirectHandler) UpdateRedirect(c *fiber.Ctx) error {
+	var req request.RedirectRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] UpdateRedirect - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("redirectId"))
+	if err != nil {
+		code = "[HANDLER] UpdateRedirect - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		code = "[HANDLER] UpdateRedirect - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err := validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] UpdateRedirect - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	reqEntity := redirectRequestToEntity(req)
+	reqEntity.ID = id
+
+	result, err := rh.redirectService.UpdateRedirect(c.Context(), reqEntity)
+	if err != nil {
+		code = "[HANDLER] UpdateRedirect - 5"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrRedirectLoop) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Redirect updated successfully"
+	defaultResponse.Data = redirectToResponse(*result)
+	return c.JSON(defaultResponse)
+}
+
+// DeleteRedirect implements RedirectHandler.
+func (rh *redirectHandler) DeleteRedirect(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] DeleteRedirect - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("redirectId"))
+	if err != nil {
+		code = "[HANDLER] DeleteRedirect - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = rh.redirectService.DeleteRedirect(c.Context(), id)
+	if err != nil {
+		code = "[HANDLER] DeleteRedirect - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Data = nil
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Redirect deleted successfully"
+	return c.JSON(defaultResponse)
+}
+
+// Redirect implements RedirectHandler. It is mounted before the routes and
+// answers requests for a redirected path with a 301/308 to its final target.
+// 301 redirects only apply to GET and HEAD since clients may turn other
+// methods into GET when following them.
+func (rh *redirectHandler) Redirect(c *fiber.Ctx) error {
+	result, err := rh.redirectService.ResolveRedirect(c.Context(), c.Path())
+	if err != nil || result == nil {
+		return c.Next()
+	}
+
+	if result.StatusCode != fiber.StatusPermanentRedirect && c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead {
+		return c.Next()
+	}
+
+	target := result.TargetPath
+	if query := string(c.Request().URI().QueryString()); query != "" {
+		target += "?" + query
+	}
+
+	return c.Redirect(target, result.StatusCode)
+}
+
+func redirectRequestToEntity(req request.RedirectRequest) entity.RedirectEntity {
+	statusCode := req.StatusCode
+	if statusCode == 0 {
+		statusCode = fiber.StatusMovedPermanently
+	}
+
+	return entity.RedirectEntity{
+		SourcePath: req.SourcePath,
+		TargetPath: req.TargetPath,
+		StatusCode: statusCode,
+	}
+}
+
+func redirectToResponse(result entity.RedirectEntity) response.RedirectResponse {
+	return response.RedirectResponse{
+		ID:         result.ID,
+		SourcePath: result.SourcePath,
+		TargetPath: result.TargetPath,
+		StatusCode: result.StatusCode,
+		HitCount:   result.HitCount,
+		CreatedAt:  result.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewRedirectHandler(redirectService service.RedirectService) RedirectHandler {
+	return &redirectHandler{redirectService: redirectService}
+}
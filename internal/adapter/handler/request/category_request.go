@@ -3,3 +3,7 @@ package request
 type CategoryRequest struct {
 	Title string `json:"title" validate:"required"`
 }
+
+type CategoryMergeRequest struct {
+	SourceIDs []int64 `json:"source_ids" validate:"required,min=1"`
+}
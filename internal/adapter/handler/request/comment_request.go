@@ -0,0 +1,12 @@
+package request
+
+type CommentRequest struct {
+	AuthorName  string `json:"author_name" validate:"required,max=100"`
+	AuthorEmail string `json:"author_email" validate:"required,email,max=200"`
+	Body        string `json:"body" validate:"required,max=5000"`
+	ParentID    int64  `json:"parent_id"`
+}
+
+type CommentBanRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
@@ -0,0 +1,19 @@
+package request
+
+type ContentRequest struct {
+	Title             string   `json:"title" validate:"required,max=200"`
+	Excerpt           string   `json:"excerpt" validate:"max=250"`
+	Description       string   `json:"description" validate:"required"`
+	DescriptionFormat string   `json:"description_format" validate:"omitempty,oneof=html markdown"`
+	MediaID           int64    `json:"media_id"`
+	Tags              []string `json:"tags"`
+	Status            string   `json:"status" validate:"required,oneof=PUBLISH DRAFT REVIEW"`
+	MetaTitle         string   `json:"meta_title" validate:"max=200"`
+	MetaDescription   string   `json:"meta_description" validate:"max=300"`
+	CanonicalURL      string   `json:"canonical_url" validate:"omitempty,url"`
+	NoIndex           bool     `json:"noindex"`
+	CommentsEnabled   *bool    `json:"comments_enabled"`
+	ContentType       string   `json:"content_type" validate:"omitempty,oneof=ARTICLE LIVE_BLOG"`
+	OgMediaID         int64    `json:"og_media_id"`
+	CategoryID        int64    `json:"category_id" validate:"required"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type ReadingHistoryRequest struct {
+	Position int `json:"position" validate:"min=0,max=100"`
+}
@@ -0,0 +1,8 @@
+package request
+
+type LiveBlogEntryRequest struct {
+	Title      string `json:"title" validate:"max=200"`
+	Body       string `json:"body" validate:"required"`
+	BodyFormat string `json:"body_format" validate:"omitempty,oneof=html markdown"`
+	Pinned     bool   `json:"pinned"`
+}
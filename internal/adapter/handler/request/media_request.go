@@ -0,0 +1,16 @@
+package request
+
+type MediaRequest struct {
+	AltText   string   `json:"alt_text" form:"alt_text" validate:"max=255"`
+	Caption   string   `json:"caption" form:"caption"`
+	Credit    string   `json:"credit" form:"credit" validate:"max=255"`
+	Copyright string   `json:"copyright" form:"copyright" validate:"max=255"`
+	License   string   `json:"license" form:"license" validate:"max=100"`
+	Tags      []string `json:"tags" form:"tags"`
+}
+
+type MediaUploadRequest struct {
+	FileName    string `json:"file_name" validate:"required,max=255"`
+	ContentType string `json:"content_type" validate:"required"`
+	Size        int64  `json:"size" validate:"required,min=1"`
+}
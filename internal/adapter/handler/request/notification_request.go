@@ -0,0 +1,17 @@
+package request
+
+type NotificationReadRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+type NotificationSubscriptionsRequest struct {
+	Subscriptions map[string]bool `json:"subscriptions" validate:"required"`
+}
+
+// NotificationSocketRequest is a message a client sends over the
+// notification WebSocket: "ping", or "read" with the ids to mark, all
+// when empty.
+type NotificationSocketRequest struct {
+	Type string  `json:"type"`
+	IDs  []int64 `json:"ids"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type ReactionRequest struct {
+	Reaction string `json:"reaction" validate:"required"`
+}
@@ -0,0 +1,29 @@
+package request
+
+type RegisterReaderRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	Email    string `json:"email" validate:"required,email,max=200"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type UpdateReaderRequest struct {
+	Name           string `json:"name" validate:"required,max=100"`
+	HistoryEnabled *bool  `json:"history_enabled"`
+}
+
+type UpdateReaderPasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,max=72"`
+}
+
+type DeleteReaderRequest struct {
+	Password string `json:"password" validate:"required"`
+}
@@ -0,0 +1,7 @@
+package request
+
+type RedirectRequest struct {
+	SourcePath string `json:"source_path" validate:"required,max=255"`
+	TargetPath string `json:"target_path" validate:"required,max=255"`
+	StatusCode int    `json:"status_code" validate:"omitempty,oneof=301 308"`
+}
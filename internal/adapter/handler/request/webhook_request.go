@@ -0,0 +1,8 @@
+package request
+
+type WebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url,max=2000"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=100"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+	Active     *bool    `json:"active"`
+}
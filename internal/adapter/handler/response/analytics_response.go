@@ -0,0 +1,9 @@
+package response
+
+type AnalyticsReportResponse struct {
+	Report  string                   `json:"report"`
+	From    string                   `json:"from"`
+	To      string                   `json:"to"`
+	Columns []string                 `json:"columns"`
+	Rows    []map[string]interface{} `json:"rows"`
+}
@@ -0,0 +1,35 @@
+package response
+
+type CommentResponse struct {
+	ID           int64               `json:"id"`
+	ParentID     int64               `json:"parent_id,omitempty"`
+	ContentID    int64               `json:"content_id,omitempty"`
+	ContentTitle string              `json:"content_title,omitempty"`
+	ContentSlug  string              `json:"content_slug,omitempty"`
+	AuthorName   string              `json:"author_name"`
+	AuthorEmail  string              `json:"author_email,omitempty"`
+	IP           string              `json:"ip,omitempty"`
+	UserAgent    string              `json:"user_agent,omitempty"`
+	Body         string              `json:"body"`
+	Status       string              `json:"status,omitempty"`
+	SpamScore    float64             `json:"spam_score,omitempty"`
+	SpamChecks   []SpamCheckResponse `json:"spam_checks,omitempty"`
+	TrainedAs    string              `json:"trained_as,omitempty"`
+	ModeratedAt  string              `json:"moderated_at,omitempty"`
+	CreatedAt    string              `json:"created_at"`
+	Replies      []CommentResponse   `json:"replies,omitempty"`
+}
+
+type SpamCheckResponse struct {
+	Filter string  `json:"filter"`
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason,omitempty"`
+}
+
+type CommentBanResponse struct {
+	ID        int64  `json:"id"`
+	Email     string `json:"email"`
+	IP        string `json:"ip"`
+	Reason    string `json:"reason"`
+	CreatedAt string `json:"created_at"`
+}
@@ -0,0 +1,34 @@
+package response
+
+type ContentResponse struct {
+	ID                int64                  `json:"id"`
+	Title             string                 `json:"title"`
+	Slug              string                 `json:"slug"`
+	Excerpt           string                 `json:"excerpt"`
+	Description       string                 `json:"description,omitempty"`
+	DescriptionFormat string                 `json:"description_format,omitempty"`
+	DescriptionHTML   string                 `json:"description_html,omitempty"`
+	Image             string                 `json:"image"`
+	MediaID           int64                  `json:"media_id,omitempty"`
+	Media             *MediaResponse         `json:"media,omitempty"`
+	ImageVariants     []ImageVariantResponse `json:"image_variants,omitempty"`
+	ImageSrcset       string                 `json:"image_srcset,omitempty"`
+	Tags              []string               `json:"tags"`
+	WordCount         int                    `json:"word_count"`
+	ReadingTime       int                    `json:"reading_time"`
+	Status            string                 `json:"status"`
+	MetaTitle         string                 `json:"meta_title"`
+	MetaDescription   string                 `json:"meta_description"`
+	CanonicalURL      string                 `json:"canonical_url"`
+	NoIndex           bool                   `json:"noindex"`
+	CommentsEnabled   bool                   `json:"comments_enabled"`
+	ContentType       string                 `json:"content_type"`
+	Reactions         map[string]int64       `json:"reactions,omitempty"`
+	OgMediaID         int64                  `json:"og_media_id,omitempty"`
+	CategoryID        int64                  `json:"category_id"`
+	CategoryName      string                 `json:"category_name"`
+	CategorySlug      string                 `json:"category_slug"`
+	Author            string                 `json:"author"`
+	CreatedAt         string                 `json:"created_at"`
+	PublishedAt       string                 `json:"published_at,omitempty"`
+}
@@ -0,0 +1,8 @@
+package response
+
+type ImageVariantResponse struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
@@ -0,0 +1,12 @@
+package response
+
+type BookmarkResponse struct {
+	CreatedAt string          `json:"created_at"`
+	Content   ContentResponse `json:"content"`
+}
+
+type ReadingHistoryResponse struct {
+	Position int             `json:"position"`
+	ReadAt   string          `json:"read_at"`
+	Content  ContentResponse `json:"content"`
+}
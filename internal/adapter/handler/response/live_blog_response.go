@@ -0,0 +1,18 @@
+package response
+
+type LiveBlogEntryResponse struct {
+	ID         int64  `json:"id"`
+	Title      string `json:"title,omitempty"`
+	Body       string `json:"body,omitempty"`
+	BodyFormat string `json:"body_format,omitempty"`
+	BodyHTML   string `json:"body_html"`
+	Pinned     bool   `json:"pinned"`
+	Author     string `json:"author,omitempty"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+type LiveBlogResponse struct {
+	LastEventID int64                   `json:"last_event_id"`
+	Entries     []LiveBlogEntryResponse `json:"entries"`
+}
@@ -0,0 +1,30 @@
+package response
+
+type MediaResponse struct {
+	ID         int64                  `json:"id"`
+	URL        string                 `json:"url"`
+	FileName   string                 `json:"file_name"`
+	MimeType   string                 `json:"mime_type"`
+	Size       int64                  `json:"size"`
+	Width      int                    `json:"width"`
+	Height     int                    `json:"height"`
+	AltText    string                 `json:"alt_text"`
+	Caption    string                 `json:"caption"`
+	Credit     string                 `json:"credit"`
+	Copyright  string                 `json:"copyright"`
+	License    string                 `json:"license"`
+	Tags       []string               `json:"tags"`
+	UsageCount int64                  `json:"usage_count"`
+	Variants   []ImageVariantResponse `json:"variants"`
+	Srcset     string                 `json:"srcset"`
+	CreatedAt  string                 `json:"created_at"`
+}
+
+type MediaUploadResponse struct {
+	ID        int64             `json:"id"`
+	ObjectKey string            `json:"object_key"`
+	UploadURL string            `json:"upload_url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt string            `json:"expires_at"`
+}
@@ -0,0 +1,31 @@
+package response
+
+import "encoding/json"
+
+type NotificationResponse struct {
+	ID           int64  `json:"id"`
+	Type         string `json:"type"`
+	Message      string `json:"message"`
+	Actor        string `json:"actor,omitempty"`
+	ContentID    int64  `json:"content_id,omitempty"`
+	ContentTitle string `json:"content_title,omitempty"`
+	CommentID    int64  `json:"comment_id,omitempty"`
+	ReadAt       string `json:"read_at,omitempty"`
+	CreatedAt    string `json:"created_at"`
+}
+
+type NotificationUnreadResponse struct {
+	Unread int64 `json:"unread"`
+}
+
+type NotificationReadResponse struct {
+	Marked int64 `json:"marked"`
+	Unread int64 `json:"unread"`
+}
+
+// NotificationSocketResponse is a message the server sends over the
+// notification WebSocket.
+type NotificationSocketResponse struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
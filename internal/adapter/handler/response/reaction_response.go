@@ -0,0 +1,6 @@
+package response
+
+type ReactionResponse struct {
+	Counts map[string]int64 `json:"counts"`
+	Mine   string           `json:"mine,omitempty"`
+}
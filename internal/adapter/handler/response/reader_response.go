@@ -0,0 +1,10 @@
+package response
+
+type ReaderResponse struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	Email          string `json:"email"`
+	EmailVerified  bool   `json:"email_verified"`
+	HistoryEnabled bool   `json:"history_enabled"`
+	CreatedAt      string `json:"created_at"`
+}
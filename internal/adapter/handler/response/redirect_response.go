@@ -0,0 +1,10 @@
+package response
+
+type RedirectResponse struct {
+	ID         int64  `json:"id"`
+	SourcePath string `json:"source_path"`
+	TargetPath string `json:"target_path"`
+	StatusCode int    `json:"status_code"`
+	HitCount   int64  `json:"hit_count"`
+	CreatedAt  string `json:"created_at"`
+}
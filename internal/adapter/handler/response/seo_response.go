@@ -0,0 +1,18 @@
+package response
+
+import "encoding/json"
+
+type SeoResponse struct {
+	Title        string           `json:"title"`
+	Description  string           `json:"description"`
+	CanonicalURL string           `json:"canonical_url"`
+	Robots       string           `json:"robots"`
+	MetaTags     []SeoTagResponse `json:"meta_tags"`
+	JSONLD       json.RawMessage  `json:"json_ld"`
+}
+
+type SeoTagResponse struct {
+	Name     string `json:"name,omitempty"`
+	Property string `json:"property,omitempty"`
+	Content  string `json:"content"`
+}
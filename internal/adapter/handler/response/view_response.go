@@ -0,0 +1,12 @@
+package response
+
+type MostReadResponse struct {
+	Views   int64           `json:"views"`
+	Content ContentResponse `json:"content"`
+}
+
+type TrendingResponse struct {
+	Score   float64         `json:"score"`
+	Views   int64           `json:"views"`
+	Content ContentResponse `json:"content"`
+}
@@ -0,0 +1,37 @@
+package response
+
+import "encoding/json"
+
+type WebhookResponse struct {
+	ID         int64    `json:"id"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at,omitempty"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID            int64                    `json:"id"`
+	WebhookID     int64                    `json:"webhook_id"`
+	EventID       string                   `json:"event_id"`
+	EventType     string                   `json:"event_type"`
+	Status        string                   `json:"status"`
+	Attempts      int                      `json:"attempts"`
+	ResponseCode  int                      `json:"response_code,omitempty"`
+	NextAttemptAt string                   `json:"next_attempt_at,omitempty"`
+	LastAttemptAt string                   `json:"last_attempt_at,omitempty"`
+	CreatedAt     string                   `json:"created_at"`
+	Payload       json.RawMessage          `json:"payload,omitempty"`
+	AttemptLog    []WebhookAttemptResponse `json:"attempt_log,omitempty"`
+}
+
+type WebhookAttemptResponse struct {
+	ID           int64  `json:"id"`
+	ResponseCode int    `json:"response_code,omitempty"`
+	ResponseBody string `json:"response_body,omitempty"`
+	Error        string `json:"error,omitempty"`
+	DurationMs   int64  `json:"duration_ms"`
+	CreatedAt    string `json:"created_at"`
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"errors"
+
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type SeoHandler interface {
+	GetContentSeo(c *fiber.Ctx) error
+}
+
+type seoHandler struct {
+	seoService service.SeoService
+}
+
+// GetContentSeo implements SeoHandler.
+func (sh *seoHandler) GetContentSeo(c *fiber.Ctx) error {
+	result, err := sh.seoService.GetContentSeo(c.Context(), c.Params("slug"))
+	if err != nil {
+		code = "[HANDLER] GetContentSeo - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	res := response.SeoResponse{
+		Title:        result.Title,
+		Description:  result.Description,
+		CanonicalURL: result.CanonicalURL,
+		Robots:       result.Robots,
+		MetaTags:     []response.SeoTagResponse{},
+		JSONLD:       result.JSONLD,
+	}
+	for _, tag := range result.Tags {
+		tagResponse := response.SeoTagResponse{Content: tag.Content}
+		if tag.Attribute == "property" {
+			tagResponse.Property = tag.Key
+		} else {
+			tagResponse.Name = tag.Key
+		}
+		res.MetaTags = append(res.MetaTags, tagResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "SEO metadata fetched successfully"
+	defaultResponse.Data = res
+
+	return c.JSON(defaultResponse)
+}
+
+func NewSeoHandler(seoService service.SeoService) SeoHandler {
+	return &seoHandler{seoService: seoService}
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"errors"
+
+	"news-app/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type SitemapHandler interface {
+	GetIndex(c *fiber.Ctx) error
+	GetContentSitemap(c *fiber.Ctx) error
+	GetCategorySitemap(c *fiber.Ctx) error
+	GetTagSitemap(c *fiber.Ctx) error
+	GetNewsSitemap(c *fiber.Ctx) error
+}
+
+type sitemapHandler struct {
+	sitemapService service.SitemapService
+}
+
+// GetIndex implements SitemapHandler.
+func (sh *sitemapHandler) GetIndex(c *fiber.Ctx) error {
+	result, err := sh.sitemapService.GetIndex(c.Context(), c.BaseURL())
+	return writeSitemap(c, "[HANDLER] GetIndex - 1", result, err)
+}
+
+// GetContentSitemap implements SitemapHandler.
+func (sh *sitemapHandler) GetContentSitemap(c *fiber.Ctx) error {
+	page, err := c.ParamsInt("page")
+	if err != nil || page < 1 {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+
+	result, err := sh.sitemapService.GetContentSitemap(c.Context(), int64(page))
+	return writeSitemap(c, "[HANDLER] GetContentSitemap - 1", result, err)
+}
+
+// GetCategorySitemap implements SitemapHandler.
+func (sh *sitemapHandler) GetCategorySitemap(c *fiber.Ctx) error {
+	page, err := c.ParamsInt("page")
+	if err != nil || page < 1 {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+
+	result, err := sh.sitemapService.GetCategorySitemap(c.Context(), int64(page))
+	return writeSitemap(c, "[HANDLER] GetCategorySitemap - 1", result, err)
+}
+
+// GetTagSitemap implements SitemapHandler.
+func (sh *sitemapHandler) GetTagSitemap(c *fiber.Ctx) error {
+	page, err := c.ParamsInt("page")
+	if err != nil || page < 1 {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+
+	result, err := sh.sitemapService.GetTagSitemap(c.Context(), int64(page))
+	return writeSitemap(c, "[HANDLER] GetTagSitemap - 1", result, err)
+}
+
+// GetNewsSitemap implements SitemapHandler.
+func (sh *sitemapHandler) GetNewsSitemap(c *fiber.Ctx) error {
+	result, err := sh.sitemapService.GetNewsSitemap(c.Context())
+	return writeSitemap(c, "[HANDLER] GetNewsSitemap - 1", result, err)
+}
+
+func writeSitemap(c *fiber.Ctx, errCode string, body []byte, err error) error {
+	if errors.Is(err, service.ErrSitemapPageNotFound) {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+	if err != nil {
+		code = errCode
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/xml; charset=utf-8")
+	return c.Send(body)
+}
+
+func NewSitemapHandler(sitemapService service.SitemapService) SitemapHandler {
+	return &sitemapHandler{sitemapService: sitemapService}
+}
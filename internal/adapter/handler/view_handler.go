@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/service"
+	"news-app/lib/conv"
+	"news-app/lib/useragent"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type ViewHandler interface {
+	RecordView(c *fiber.Ctx) error
+	GetMostRead(c *fiber.Ctx) error
+	GetTrending(c *fiber.Ctx) error
+}
+
+type viewHandler struct {
+	viewService     service.ViewService
+	trendingService service.TrendingService
+}
+
+// RecordView implements ViewHandler. It answers 204 whether or not the view
+// was counted, so clients cannot tell how they were classified.
+func (vh *viewHandler) RecordView(c *fiber.Ctx) error {
+	id, err := conv.StringToInt64(c.Params("contentId"))
+	// Content ids are INT columns; anything larger could never be stored.
+	if err != nil || id <= 0 || id > math.MaxInt32 {
+		code = "[HANDLER] RecordView - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Invalid content id"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	userAgent := c.Get(fiber.HeaderUserAgent)
+	if useragent.IsBot(userAgent) || isPrefetch(c) {
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+
+	vh.viewService.RecordView(id, entity.ViewerEntity{IP: c.IP(), UserAgent: userAgent})
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetMostRead implements ViewHandler.
+func (vh *viewHandler) GetMostRead(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 10)
+	if limit <= 0 || limit > service.MaxMostRead {
+		limit = 10
+	}
+
+	results, err := vh.viewService.GetMostRead(c.Context(), c.Query("window", "24h"), int64(c.QueryInt("category_id", 0)), limit)
+	if err != nil {
+		code = "[HANDLER] GetMostRead - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrUnknownWindow) {
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	mostReadResponses := []response.MostReadResponse{}
+	for _, result := range results {
+		mostReadResponses = append(mostReadResponses, response.MostReadResponse{
+			Views:   result.Views,
+			Content: contentToResponse(result.Content, false),
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=60")
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Message = "Most read contents fetched successfully"
+	defaultResponse.Data = mostReadResponses
+
+	return c.JSON(defaultResponse)
+}
+
+// GetTrending implements ViewHandler.
+func (vh *viewHandler) GetTrending(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 10)
+	if limit <= 0 || limit > service.MaxTrending {
+		limit = 10
+	}
+
+	results, err := vh.trendingService.GetTrending(c.Context(), int64(c.QueryInt("category_id", 0)), limit)
+	if err != nil {
+		code = "[HANDLER] GetTrending - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	trendingResponses := []response.TrendingResponse{}
+	for _, result := range results {
+		trendingResponses = append(trendingResponses, response.TrendingResponse{
+			Score:   result.Score,
+			Views:   result.Views,
+			Content: contentToResponse(result.Content, false),
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=60")
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Message = "Trending contents fetched successfully"
+	defaultResponse.Data = trendingResponses
+
+	return c.JSON(defaultResponse)
+}
+
+// isPrefetch reports whether the browser is loading the page speculatively,
+// in which case the reader has not seen it yet.
+func isPrefetch(c *fiber.Ctx) bool {
+	return strings.Contains(c.Get("Sec-Purpose"), "prefetch") || strings.Contains(c.Get("Purpose"), "prefetch")
+}
+
+func NewViewHandler(viewService service.ViewService, trendingService service.TrendingService) ViewHandler {
+	return &viewHandler{viewService: viewService, trendingService: trendingService}
+}
@@ -0,0 +1,443 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"news-app/internal/adapter/handler/request"
+	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/service"
+	"news-app/lib/conv"
+	validatorLib "news-app/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type WebhookHandler interface {
+	GetWebhooks(c *fiber.Ctx) error
+	GetWebhookByID(c *fiber.Ctx) error
+	CreateWebhook(c *fiber.Ctx) error
+	UpdateWebhook(c *fiber.Ctx) error
+	DeleteWebhook(c *fiber.Ctx) error
+
+	GetDeliveries(c *fiber.Ctx) error
+	GetDeliveryByID(c *fiber.Ctx) error
+	Redeliver(c *fiber.Ctx) error
+}
+
+type webhookHandler struct {
+	webhookService service.WebhookService
+}
+
+// GetWebhooks implements WebhookHandler.
+func (wh *webhookHandler) GetWebhooks(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetWebhooks - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	query := parseQueryString(c)
+	results, totalData, err := wh.webhookService.GetWebhooks(c.Context(), query)
+	if err != nil {
+		code = "[HANDLER] GetWebhooks - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	webhookResponses := []response.WebhookResponse{}
+	for _, result := range results {
+		webhookResponses = append(webhookResponses, webhookToResponse(result, false))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Webhooks fetched successfully"
+	defaultResponse.Data = webhookResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// GetWebhookByID implements WebhookHandler.
+func (wh *webhookHandler) GetWebhookByID(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetWebhookByID - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("webhookId"))
+	if err != nil {
+		code = "[HANDLER] GetWebhookByID - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	result, err := wh.webhookService.GetWebhookByID(c.Context(), id)
+	if err != nil {
+		code = "[HANDLER] GetWebhookByID - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Webhook fetched successfully"
+	defaultResponse.Data = webhookToResponse(*result, false)
+
+	return c.JSON(defaultResponse)
+}
+
+// CreateWebhook implements WebhookHandler. The signing secret is only ever
+// shown here.
+func (wh *webhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	return wh.saveWebhook(c, "CreateWebhook")
+}
+
+// UpdateWebhook implements WebhookHandler.
+func (wh *webhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	return wh.saveWebhook(c, "UpdateWebhook")
+}
+
+// DeleteWebhook implements WebhookHandler.
+func (wh *webhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] DeleteWebhook - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("webhookId"))
+	if err != nil {
+		code = "[HANDLER] DeleteWebhook - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	err = wh.webhookService.DeleteWebhook(c.Context(), id)
+	if err != nil {
+		code = "[HANDLER] DeleteWebhook - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Webhook deleted successfully"
+	defaultResponse.Data = nil
+
+	return c.JSON(defaultResponse)
+}
+
+// GetDeliveries implements WebhookHandler. The status query parameter
+// filters by delivery status.
+func (wh *webhookHandler) GetDeliveries(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] GetDeliveries - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("webhookId"))
+	if err != nil {
+		code = "[HANDLER] GetDeliveries - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	query := parseQueryString(c)
+	results, totalData, err := wh.webhookService.GetDeliveries(c.Context(), id, query)
+	if err != nil {
+		code = "[HANDLER] GetDeliveries - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	deliveryResponses := []response.WebhookDeliveryResponse{}
+	for _, result := range results {
+		deliveryResponses = append(deliveryResponses, webhookDeliveryToResponse(result))
+	}
+
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Webhook deliveries fetched successfully"
+	defaultResponse.Data = deliveryResponses
+	defaultResponse.Pagination = paginationResponse(totalData, query)
+
+	return c.JSON(defaultResponse)
+}
+
+// GetDeliveryByID implements WebhookHandler.
+func (wh *webhookHandler) GetDeliveryByID(c *fiber.Ctx) error {
+	return wh.delivery(c, "GetDeliveryByID", false)
+}
+
+// Redeliver implements WebhookHandler.
+func (wh *webhookHandler) Redeliver(c *fiber.Ctx) error {
+	return wh.delivery(c, "Redeliver", true)
+}
+
+// delivery returns the delivery in the path, after queuing it again when
+// redeliver is set.
+func (wh *webhookHandler) delivery(c *fiber.Ctx, name string, redeliver bool) error {
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] " + name + " - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	id, err := conv.StringToInt64(c.Params("deliveryId"))
+	if err != nil {
+		code = "[HANDLER] " + name + " - 2"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	var result *entity.WebhookDeliveryEntity
+	if redeliver {
+		result, err = wh.webhookService.Redeliver(c.Context(), id)
+	} else {
+		result, err = wh.webhookService.GetDeliveryByID(c.Context(), id)
+	}
+	if err != nil {
+		code = "[HANDLER] " + name + " - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	defaultResponse.Meta.Message = "Webhook delivery fetched successfully"
+	if redeliver {
+		defaultResponse.Meta.Message = "Webhook delivery queued for redelivery"
+	}
+	res := webhookDeliveryToResponse(*result)
+	res.Payload = json.RawMessage(result.Payload)
+	res.AttemptLog = []response.WebhookAttemptResponse{}
+	for _, attempt := range result.AttemptLog {
+		res.AttemptLog = append(res.AttemptLog, response.WebhookAttemptResponse{
+			ID:           attempt.ID,
+			ResponseCode: attempt.ResponseCode,
+			ResponseBody: attempt.ResponseBody,
+			Error:        attempt.Error,
+			DurationMs:   attempt.Duration.Milliseconds(),
+			CreatedAt:    attempt.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	defaultResponse.Data = res
+
+	return c.JSON(defaultResponse)
+}
+
+// saveWebhook creates a webhook, or updates the one in the path.
+func (wh *webhookHandler) saveWebhook(c *fiber.Ctx, name string) error {
+	var req request.WebhookRequest
+	claims := c.Locals("user").(*entity.JwtData)
+	userID := claims.UserID
+
+	if userID == 0 {
+		code = "[HANDLER] " + name + " - 1"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = "Unauthorized access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errResponse)
+	}
+
+	var id int64
+	if c.Params("webhookId") != "" {
+		id, err = conv.StringToInt64(c.Params("webhookId"))
+		if err != nil {
+			code = "[HANDLER] " + name + " - 2"
+			log.Errorw(code, err)
+			errResponse.Meta.Status = false
+			errResponse.Meta.Message = err.Error()
+
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+	}
+
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] " + name + " - 3"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] " + name + " - 4"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+	}
+
+	reqEntity := entity.WebhookEntity{
+		ID:          id,
+		URL:         req.URL,
+		Secret:      req.Secret,
+		EventTypes:  req.EventTypes,
+		Active:      true,
+		CreatedByID: int64(userID),
+	}
+	if req.Active != nil {
+		reqEntity.Active = *req.Active
+	}
+
+	var result *entity.WebhookEntity
+	if id == 0 {
+		result, err = wh.webhookService.CreateWebhook(c.Context(), reqEntity)
+	} else {
+		result, err = wh.webhookService.UpdateWebhook(c.Context(), reqEntity)
+	}
+	if err != nil {
+		code = "[HANDLER] " + name + " - 5"
+		log.Errorw(code, err)
+		errResponse.Meta.Status = false
+		errResponse.Meta.Message = err.Error()
+
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(errResponse)
+		case errors.Is(err, service.ErrUnknownWebhookEvent), errors.Is(err, service.ErrInvalidWebhookURL):
+			return c.Status(fiber.StatusBadRequest).JSON(errResponse)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errResponse)
+	}
+
+	defaultResponse.Pagination = nil
+	defaultResponse.Meta.Status = true
+	if id == 0 {
+		defaultResponse.Meta.Message = "Webhook created successfully"
+		defaultResponse.Data = webhookToResponse(*result, true)
+		return c.Status(fiber.StatusCreated).JSON(defaultResponse)
+	}
+
+	defaultResponse.Meta.Message = "Webhook updated successfully"
+	defaultResponse.Data = webhookToResponse(*result, req.Secret != "")
+	return c.JSON(defaultResponse)
+}
+
+// webhookToResponse leaves the secret out unless it was just set.
+func webhookToResponse(result entity.WebhookEntity, withSecret bool) response.WebhookResponse {
+	res := response.WebhookResponse{
+		ID:         result.ID,
+		URL:        result.URL,
+		EventTypes: result.EventTypes,
+		Active:     result.Active,
+		CreatedAt:  result.CreatedAt.Format(time.RFC3339),
+	}
+	if withSecret {
+		res.Secret = result.Secret
+	}
+	if result.UpdatedAt != nil {
+		res.UpdatedAt = result.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return res
+}
+
+func webhookDeliveryToResponse(result entity.WebhookDeliveryEntity) response.WebhookDeliveryResponse {
+	res := response.WebhookDeliveryResponse{
+		ID:           result.ID,
+		WebhookID:    result.WebhookID,
+		EventID:      result.EventID,
+		EventType:    result.EventType,
+		Status:       result.Status,
+		Attempts:     result.Attempts,
+		ResponseCode: result.ResponseCode,
+		CreatedAt:    result.CreatedAt.Format(time.RFC3339),
+	}
+	if result.NextAttemptAt != nil && result.Status == entity.WebhookDeliveryPending {
+		res.NextAttemptAt = result.NextAttemptAt.Format(time.RFC3339)
+	}
+	if result.LastAttemptAt != nil {
+		res.LastAttemptAt = result.LastAttemptAt.Format(time.RFC3339)
+	}
+
+	return res
+}
+
+func NewWebhookHandler(webhookService service.WebhookService) WebhookHandler {
+	return &webhookHandler{webhookService: webhookService}
+}
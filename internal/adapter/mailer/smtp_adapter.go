@@ -0,0 +1,88 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"news-app/config"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type MailerAdapter interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+type smtpAdapter struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// Send implements MailerAdapter. Without an SMTP host, as in development,
+// the message is logged instead of sent.
+func (s *smtpAdapter) Send(ctx context.Context, to, subject, body string) error {
+	if s.host == "" {
+		log.Infow("[MAILER] Send", "to", to, "subject", subject, "body", body)
+		return nil
+	}
+
+	msg := strings.Join([]string{
+		"From: " + s.from,
+		"To: " + to,
+		"Subject: " + subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	// The envelope sender is the bare address, without a display name.
+	sender := s.from
+	if addr, err := mail.ParseAddress(s.from); err == nil {
+		sender = addr.Address
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(net.JoinHostPort(s.host, s.port), auth, sender, []string{to}, []byte(msg))
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("send mail to %s: %w", to, err)
+		}
+		return nil
+	}
+}
+
+func NewMailerAdapter(cfg *config.Config) MailerAdapter {
+	port := cfg.Mail.Port
+	if port == "" {
+		port = "587"
+	}
+
+	return &smtpAdapter{
+		host:     cfg.Mail.Host,
+		port:     port,
+		username: cfg.Mail.Username,
+		password: cfg.Mail.Password,
+		from:     cfg.Mail.From,
+	}
+}
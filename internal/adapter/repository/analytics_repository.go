@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type AnalyticsRepository interface {
+	GetPublishedPerDay(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error)
+	GetPublishedPerAuthor(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error)
+	GetPublishedPerCategory(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error)
+	GetViewsPerDay(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error)
+	GetViewsPerContent(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error)
+	GetReadingTime(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error)
+	GetTopTags(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error)
+	GetPendingDrafts(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error)
+}
+
+type analyticsRepository struct {
+	db *gorm.DB
+}
+
+type analyticsGroupRow struct {
+	ID       int64
+	Name     string
+	Contents int64
+	Views    int64
+}
+
+// GetPublishedPerDay implements AnalyticsRepository. Days without any
+// publication are included with a zero count.
+func (a *analyticsRepository) GetPublishedPerDay(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error) {
+	var rows []struct {
+		Day      time.Time
+		Contents int64
+	}
+
+	err := a.db.WithContext(ctx).Raw(`
+		SELECT d::DATE AS day, COUNT(contents.id) AS contents
+		FROM generate_series(?::DATE, ?::DATE, INTERVAL '1 day') AS d
+		LEFT JOIN contents ON contents.published_at::DATE = d::DATE AND contents.status = ?
+			AND (? = 0 OR contents.category_id = ?)
+		GROUP BY d
+		ORDER BY d ASC`,
+		query.From, query.To, entity.ContentStatusPublish, query.CategoryID, query.CategoryID).
+		Scan(&rows).Error
+	if err != nil {
+		code := "[REPOSITORY] GetPublishedPerDay - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := &entity.AnalyticsReportEntity{Columns: []string{"day", "contents"}, Rows: [][]interface{}{}}
+	for _, row := range rows {
+		res.Rows = append(res.Rows, []interface{}{row.Day, row.Contents})
+	}
+
+	return res, nil
+}
+
+// GetPublishedPerAuthor implements AnalyticsRepository.
+func (a *analyticsRepository) GetPublishedPerAuthor(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error) {
+	var rows []analyticsGroupRow
+
+	err := a.db.WithContext(ctx).Raw(`
+		SELECT users.id, users.name, COUNT(contents.id) AS contents, COALESCE(SUM(v.views), 0) AS views
+		FROM contents
+		JOIN users ON users.id = contents.created_by_id
+		LEFT JOIN (`+analyticsViewsSubquery+`) AS v ON v.content_id = contents.id
+		WHERE contents.status = ? AND contents.published_at::DATE BETWEEN ?::DATE AND ?::DATE
+			AND (? = 0 OR contents.category_id = ?)
+		GROUP BY users.id, users.name
+		ORDER BY contents DESC, users.name ASC`,
+		query.From, query.To, entity.ContentStatusPublish, query.From, query.To, query.CategoryID, query.CategoryID).
+		Scan(&rows).Error
+	if err != nil {
+		code := "[REPOSITORY] GetPublishedPerAuthor - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return groupRowsToReport([]string{"author_id", "author", "contents", "views"}, rows), nil
+}
+
+// GetPublishedPerCategory implements AnalyticsRepository.
+func (a *analyticsRepository) GetPublishedPerCategory(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error) {
+	var rows []analyticsGroupRow
+
+	err := a.db.WithContext(ctx).Raw(`
+		SELECT categories.id, categories.title AS name, COUNT(contents.id) AS contents, COALESCE(SUM(v.views), 0) AS views
+		FROM contents
+		JOIN categories ON categories.id = contents.category_id
+		LEFT JOIN (`+analyticsViewsSubquery+`) AS v ON v.content_id = contents.id
+		WHERE contents.status = ? AND contents.published_at::DATE BETWEEN ?::DATE AND ?::DATE
+		GROUP BY categories.id, categories.title
+		ORDER BY contents DESC, categories.title ASC`,
+		query.From, query.To, entity.ContentStatusPublish, query.From, query.To).
+		Scan(&rows).Error
+	if err != nil {
+		code := "[REPOSITORY] GetPublishedPerCategory - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return groupRowsToReport([]string{"category_id", "category", "contents", "views"}, rows), nil
+}
+
+// GetViewsPerDay implements AnalyticsRepository. The series covers one
+// content when ContentID is set, a category when CategoryID is set, and the
+// whole site otherwise.
+func (a *analyticsRepository) GetViewsPerDay(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error) {
+	var rows []struct {
+		Day   time.Time
+		Views int64
+	}
+
+	err := a.db.WithContext(ctx).Raw(`
+		SELECT d::DATE AS day, COALESCE(SUM(cv.views), 0) AS views
+		FROM generate_series(?::DATE, ?::DATE, INTERVAL '1 day') AS d
+		LEFT JOIN content_views_daily AS cv ON cv.day = d::DATE
+			AND (? = 0 OR cv.content_id = ?)
+			AND (? = 0 OR cv.content_id IN (SELECT id FROM contents WHERE category_id = ?))
+		GROUP BY d
+		ORDER BY d ASC`,
+		query.From, query.To, query.ContentID, query.ContentID, query.CategoryID, query.CategoryID).
+		Scan(&rows).Error
+	if err != nil {
+		code := "[REPOSITORY] GetViewsPerDay - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := &entity.AnalyticsReportEntity{Columns: []string{"day", "views"}, Rows: [][]interface{}{}}
+	for _, row := range rows {
+		res.Rows = append(res.Rows, []interface{}{row.Day, row.Views})
+	}
+
+	return res, nil
+}
+
+// GetViewsPerContent implements AnalyticsRepository.
+func (a *analyticsRepository) GetViewsPerContent(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error) {
+	var rows []struct {
+		ID          int64
+		Title       string
+		Category    string
+		PublishedAt *time.Time
+		Views       int64
+	}
+
+	err := a.db.WithContext(ctx).Raw(`
+		SELECT contents.id, contents.title, categories.title AS category, contents.published_at, v.views
+		FROM (`+analyticsViewsSubquery+`) AS v
+		JOIN contents ON contents.id = v.content_id
+		JOIN categories ON categories.id = contents.category_id
+		WHERE (? = 0 OR contents.category_id = ?)
+		ORDER BY v.views DESC, contents.id DESC
+		LIMIT ?`,
+		query.From, query.To, query.CategoryID, query.CategoryID, query.Limit).
+		Scan(&rows).Error
+	if err != nil {
+		code := "[REPOSITORY] GetViewsPerContent - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := &entity.AnalyticsReportEntity{Columns: []string{"content_id", "title", "category", "published_at", "views"}, Rows: [][]interface{}{}}
+	for _, row := range rows {
+		res.Rows = append(res.Rows, []interface{}{row.ID, row.Title, row.Category, row.PublishedAt, row.Views})
+	}
+
+	return res, nil
+}
+
+// GetReadingTime implements AnalyticsRepository. The last row, with a zero
+// category id, covers all categories.
+func (a *analyticsRepository) GetReadingTime(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error) {
+	var rows []struct {
+		ID                 int64
+		Name               string
+		Contents           int64
+		AverageReadingTime float64
+		AverageWordCount   float64
+	}
+
+	err := a.db.WithContext(ctx).Raw(`
+		SELECT COALESCE(categories.id, 0) AS id, COALESCE(categories.title, 'All') AS name, COUNT(contents.id) AS contents,
+			ROUND(AVG(contents.reading_time), 2) AS average_reading_time, ROUND(AVG(contents.word_count), 0) AS average_word_count
+		FROM contents
+		JOIN categories ON categories.id = contents.category_id
+		WHERE contents.status = ? AND contents.published_at::DATE BETWEEN ?::DATE AND ?::DATE
+		GROUP BY GROUPING SETS ((categories.id, categories.title), ())
+		ORDER BY GROUPING(categories.id) ASC, contents DESC`,
+		entity.ContentStatusPublish, query.From, query.To).
+		Scan(&rows).Error
+	if err != nil {
+		code := "[REPOSITORY] GetReadingTime - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := &entity.AnalyticsReportEntity{
+		Columns: []string{"category_id", "category", "contents", "average_reading_time", "average_word_count"},
+		Rows:    [][]interface{}{},
+	}
+	for _, row := range rows {
+		res.Rows = append(res.Rows, []interface{}{row.ID, row.Name, row.Contents, row.AverageReadingTime, row.AverageWordCount})
+	}
+
+	return res, nil
+}
+
+// GetTopTags implements AnalyticsRepository.
+func (a *analyticsRepository) GetTopTags(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error) {
+	var rows []struct {
+		Tag      string
+		Contents int64
+		Views    int64
+	}
+
+	err := a.db.WithContext(ctx).Raw(`
+		SELECT lower(trim(tag)) AS tag, COUNT(contents.id) AS contents, COALESCE(SUM(v.views), 0) AS views
+		FROM contents
+		CROSS JOIN LATERAL unnest(string_to_array(contents.tags, ',')) AS tag
+		LEFT JOIN (`+analyticsViewsSubquery+`) AS v ON v.content_id = contents.id
+		WHERE contents.status = ? AND trim(tag) <> '' AND contents.published_at::DATE BETWEEN ?::DATE AND ?::DATE
+			AND (? = 0 OR contents.category_id = ?)
+		GROUP BY lower(trim(tag))
+		ORDER BY contents DESC, views DESC, tag ASC
+		LIMIT ?`,
+		query.From, query.To, entity.ContentStatusPublish, query.From, query.To, query.CategoryID, query.CategoryID, query.Limit).
+		Scan(&rows).Error
+	if err != nil {
+		code := "[REPOSITORY] GetTopTags - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := &entity.AnalyticsReportEntity{Columns: []string{"tag", "contents", "views"}, Rows: [][]interface{}{}}
+	for _, row := range rows {
+		res.Rows = append(res.Rows, []interface{}{row.Tag, row.Contents, row.Views})
+	}
+
+	return res, nil
+}
+
+// GetPendingDrafts implements AnalyticsRepository. Drafts, including those
+// in review, are listed oldest first whatever the date range, since those
+// waited the longest.
+func (a *analyticsRepository) GetPendingDrafts(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error) {
+	var rows []struct {
+		ID        int64
+		Title     string
+		Author    string
+		Category  string
+		WordCount int
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+
+	err := a.db.WithContext(ctx).Raw(`
+		SELECT contents.id, contents.title, users.name AS author, categories.title AS category, contents.word_count,
+			contents.created_at, COALESCE(contents.updated_at, contents.created_at) AS updated_at
+		FROM contents
+		JOIN users ON users.id = contents.created_by_id
+		JOIN categories ON categories.id = contents.category_id
+		WHERE contents.status IN ? AND (? = 0 OR contents.category_id = ?)
+		ORDER BY COALESCE(contents.updated_at, contents.created_at) ASC
+		LIMIT ?`,
+		[]string{entity.ContentStatusDraft, entity.ContentStatusReview}, query.CategoryID, query.CategoryID, query.Limit).
+		Scan(&rows).Error
+	if err != nil {
+		code := "[REPOSITORY] GetPendingDrafts - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := &entity.AnalyticsReportEntity{
+		Columns: []string{"content_id", "title", "author", "category", "word_count", "created_at", "updated_at"},
+		Rows:    [][]interface{}{},
+	}
+	for _, row := range rows {
+		res.Rows = append(res.Rows, []interface{}{row.ID, row.Title, row.Author, row.Category, row.WordCount, row.CreatedAt, row.UpdatedAt})
+	}
+
+	return res, nil
+}
+
+// analyticsViewsSubquery totals the daily views of every content between
+// two dates, both included. It takes the dates as its two arguments.
+const analyticsViewsSubquery = `SELECT content_id, SUM(views) AS views FROM content_views_daily
+	WHERE day BETWEEN ?::DATE AND ?::DATE GROUP BY content_id`
+
+func groupRowsToReport(columns []string, rows []analyticsGroupRow) *entity.AnalyticsReportEntity {
+	res := &entity.AnalyticsReportEntity{Columns: columns, Rows: [][]interface{}{}}
+	for _, row := range rows {
+		res.Rows = append(res.Rows, []interface{}{row.ID, row.Name, row.Contents, row.Views})
+	}
+
+	return res
+}
+
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
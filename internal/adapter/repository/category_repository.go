@@ -7,6 +7,7 @@ import (
 
 	"news-app/internal/core/domain/entity"
 	"news-app/internal/core/domain/model"
+	"news-app/lib/slug"
 
 	"github.com/gofiber/fiber/v2/log"
 	"gorm.io/gorm"
@@ -17,7 +18,9 @@ type CategoryRepository interface {
 	GetCategoryByID(ctx context.Context, id int16) (*entity.CategoryEntity, error)
 	CreateCategory(ctx context.Context, req entity.CategoryEntity) (*entity.CategoryEntity, error)
 	UpdateCategory(ctx context.Context, req entity.CategoryEntity) (*entity.CategoryEntity, error)
+	GetCategoryBySlug(ctx context.Context, categorySlug string) (*entity.CategoryEntity, error)
 	DeleteCategory(ctx context.Context, id int16) error
+	MergeCategories(ctx context.Context, req entity.CategoryMergeEntity) error
 }
 
 type categoryRepository struct {
@@ -26,37 +29,43 @@ type categoryRepository struct {
 
 // CreateCategory implements CategoryRepository.
 func (c *categoryRepository) CreateCategory(ctx context.Context, req entity.CategoryEntity) (*entity.CategoryEntity, error) {
-	var countSlug int64
-	err = c.db.Table("categories").Where("slug = ?", req.Slug).Count(&countSlug).Error
-	if err != nil {
-		code := "[REPOSITORY] CreateCategory - 1"
-		log.Errorw(code, err)
-		return nil, err
-	}
-
-	countSlug = countSlug + 1
-	slug := fmt.Sprintf("%s-%d", req.Slug, countSlug)
 	modelCategory := model.Category{
 		Title:       req.Title,
-		Slug:        slug,
 		CreatedByID: int64(req.UserEntity.ID),
 	}
 
-	err = c.db.Create(&modelCategory).Error
+	err := dbFromContext(ctx, c.db).Transaction(func(tx *gorm.DB) error {
+		var err error
+		modelCategory.Slug, err = slug.Unique(tx, "categories", req.Slug, 0)
+		if err != nil {
+			code := "[REPOSITORY] CreateCategory - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Create(&modelCategory).Error
+		if err != nil {
+			code := "[REPOSITORY] CreateCategory - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
 	if err != nil {
-		code = "[REPOSITORY] CreateCategory - 2"
-		log.Errorw(code, err)
 		return nil, err
 	}
 
-	return nil, err
+	req.ID = int16(modelCategory.ID)
+	req.Slug = modelCategory.Slug
+	return &req, nil
 }
 
 // DeleteCategory implements CategoryRepository.
 func (c *categoryRepository) DeleteCategory(ctx context.Context, id int16) error {
 	var count int64
 
-	err = c.db.Table("contents").Where("category_id = ?", id).Count(&count).Error
+	err := dbFromContext(ctx, c.db).Table("contents").Where("category_id = ?", id).Count(&count).Error
 	if err != nil {
 		code := "[REPOSITORY] DeleteCategory - 1"
 		log.Errorw(code, err)
@@ -67,13 +76,20 @@ func (c *categoryRepository) DeleteCategory(ctx context.Context, id int16) error
 		return errors.New("cannot delete a category that has  associated contents")
 	}
 
-	err = c.db.Where("id = ?", id).Delete(&model.Category{}).Error
+	err = dbFromContext(ctx, c.db).Where("id = ?", id).Delete(&model.Category{}).Error
 	if err != nil {
 		code := "[REPOSITORY] DeleteCategory - 2"
 		log.Errorw(code, err)
 		return err
 	}
 
+	err = dbFromContext(ctx, c.db).Where("entity_type = ? AND entity_id = ?", "categories", id).Delete(&model.SlugHistory{}).Error
+	if err != nil {
+		code := "[REPOSITORY] DeleteCategory - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
 	return nil
 }
 
@@ -117,7 +133,7 @@ func (c *categoryRepository) GetCategories(ctx context.Context) ([]entity.Catego
 func (c *categoryRepository) GetCategoryByID(ctx context.Context, id int16) (*entity.CategoryEntity, error) {
 	var modelCategory model.Category
 
-	err := c.db.Where("id = ?", id).Preload("User").First(&modelCategory).Error
+	err := dbFromContext(ctx, c.db).Where("id = ?", id).Preload("User").First(&modelCategory).Error
 	if err != nil {
 		code := "[REPOSITORY] GetCategoryByID - 1"
 		log.Errorw(code, err)
@@ -138,35 +154,162 @@ func (c *categoryRepository) GetCategoryByID(ctx context.Context, id int16) (*en
 
 // UpdateCategory implements CategoryRepository.
 func (c *categoryRepository) UpdateCategory(ctx context.Context, req entity.CategoryEntity) (*entity.CategoryEntity, error) {
-	var countSlug int64
-	err := c.db.Table("categories").Where("slug = ?", req.Slug).Count(&countSlug).Error
+	err := dbFromContext(ctx, c.db).Transaction(func(tx *gorm.DB) error {
+		var current model.Category
+		err := tx.Where("id = ?", req.ID).First(&current).Error
+		if err != nil {
+			code := "[REPOSITORY] UpdateCategory - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		newSlug := current.Slug
+		if req.Slug != "" && req.Slug != current.Slug {
+			newSlug, err = slug.Unique(tx, "categories", req.Slug, current.ID)
+			if err != nil {
+				code := "[REPOSITORY] UpdateCategory - 2"
+				log.Errorw(code, err)
+				return err
+			}
+		}
+
+		modelCategory := model.Category{
+			Title:       req.Title,
+			Slug:        newSlug,
+			CreatedByID: int64(req.UserEntity.ID),
+		}
+
+		err = tx.Where("id = ?", req.ID).Updates(&modelCategory).Error
+		if err != nil {
+			code := "[REPOSITORY] UpdateCategory - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = slug.Record(tx, "categories", current.ID, current.Slug, newSlug)
+		if err != nil {
+			code := "[REPOSITORY] UpdateCategory - 4"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = recordSlugRedirect(tx, CategoryPathPrefix+current.Slug, CategoryPathPrefix+newSlug)
+		if err != nil {
+			code := "[REPOSITORY] UpdateCategory - 5"
+			log.Errorw(code, err)
+			return err
+		}
+
+		req.Slug = newSlug
+		return nil
+	})
 	if err != nil {
-		code := "[REPOSITORY] UpdateCategory - 1"
-		log.Errorw(code, err)
 		return nil, err
 	}
 
-	countSlug = countSlug + 1
-	slug := req.Slug
+	return &req, nil
+}
 
-	if countSlug == 1 {
-		slug = fmt.Sprintf("%s-%d", req.Slug, countSlug)
-	}
+// GetCategoryBySlug implements CategoryRepository.
+func (c *categoryRepository) GetCategoryBySlug(ctx context.Context, categorySlug string) (*entity.CategoryEntity, error) {
+	var modelCategory model.Category
 
-	modelCategory := model.Category{
-		Title:       req.Title,
-		Slug:        slug,
-		CreatedByID: int64(req.UserEntity.ID),
+	err := dbFromContext(ctx, c.db).Where("slug = ?", categorySlug).Preload("User").First(&modelCategory).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		id, errHistory := slug.Resolve(dbFromContext(ctx, c.db), "categories", categorySlug)
+		if errHistory == nil {
+			err = dbFromContext(ctx, c.db).Where("id = ?", id).Preload("User").First(&modelCategory).Error
+		}
 	}
-
-	err = c.db.Where("id = ?", req.ID).Updates(&modelCategory).Error
 	if err != nil {
-		code := "[REPOSITORY] UpdateCategory - 2"
+		code := "[REPOSITORY] GetCategoryBySlug - 1"
 		log.Errorw(code, err)
 		return nil, err
 	}
 
-	return nil, err
+	return &entity.CategoryEntity{
+		ID:    int16(modelCategory.ID),
+		Title: modelCategory.Title,
+		Slug:  modelCategory.Slug,
+		UserEntity: entity.UserEntity{
+			ID:    int16(modelCategory.User.ID),
+			Name:  modelCategory.User.Name,
+			Email: modelCategory.User.Email,
+		},
+	}, nil
+}
+
+// MergeCategories implements CategoryRepository.
+func (c *categoryRepository) MergeCategories(ctx context.Context, req entity.CategoryMergeEntity) error {
+	return dbFromContext(ctx, c.db).Transaction(func(tx *gorm.DB) error {
+		var target model.Category
+		err := tx.Where("id = ?", req.TargetID).First(&target).Error
+		if err != nil {
+			code := "[REPOSITORY] MergeCategories - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		var sources []model.Category
+		err = tx.Where("id IN ?", req.SourceIDs).Find(&sources).Error
+		if err != nil {
+			code := "[REPOSITORY] MergeCategories - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		if len(sources) != len(req.SourceIDs) {
+			code := "[REPOSITORY] MergeCategories - 3"
+			err = fmt.Errorf("one or more source categories not found: %w", gorm.ErrRecordNotFound)
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Table("contents").Where("category_id IN ?", req.SourceIDs).Update("category_id", target.ID).Error
+		if err != nil {
+			code := "[REPOSITORY] MergeCategories - 4"
+			log.Errorw(code, err)
+			return err
+		}
+
+		sourceIDs := make([]int64, 0, len(sources))
+		for _, source := range sources {
+			sourceIDs = append(sourceIDs, source.ID)
+		}
+
+		// Former slugs of the sources follow them into the target.
+		err = slug.Repoint(tx, "categories", sourceIDs, target.ID)
+		if err != nil {
+			code := "[REPOSITORY] MergeCategories - 5"
+			log.Errorw(code, err)
+			return err
+		}
+
+		for _, source := range sources {
+			err = slug.Record(tx, "categories", target.ID, source.Slug, target.Slug)
+			if err != nil {
+				code := "[REPOSITORY] MergeCategories - 6"
+				log.Errorw(code, err)
+				return err
+			}
+
+			err = recordSlugRedirect(tx, CategoryPathPrefix+source.Slug, CategoryPathPrefix+target.Slug)
+			if err != nil {
+				code := "[REPOSITORY] MergeCategories - 7"
+				log.Errorw(code, err)
+				return err
+			}
+		}
+
+		err = tx.Where("id IN ?", req.SourceIDs).Delete(&model.Category{}).Error
+		if err != nil {
+			code := "[REPOSITORY] MergeCategories - 8"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
 }
 
 func NewCategoryRepository(db *gorm.DB) CategoryRepository {
@@ -0,0 +1,512 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CommentRepository interface {
+	GetApprovedComments(ctx context.Context, contentID int64, query entity.QueryString) ([]entity.CommentEntity, int64, error)
+	GetComments(ctx context.Context, query entity.QueryString) ([]entity.CommentEntity, int64, error)
+	GetCommentByID(ctx context.Context, id int64) (*entity.CommentEntity, error)
+	CreateComment(ctx context.Context, req entity.CommentEntity) (*entity.CommentEntity, error)
+	ModerateComment(ctx context.Context, id int64, status string, moderatorID int64) error
+	BanCommentAuthor(ctx context.Context, id int64, reason string, moderatorID int64) (*entity.CommentBanEntity, error)
+	IsCommentAuthorBanned(ctx context.Context, email, ip string) (bool, error)
+	GetCommentBans(ctx context.Context, query entity.QueryString) ([]entity.CommentBanEntity, int64, error)
+	DeleteCommentBan(ctx context.Context, id int64) error
+	CountRecentDuplicates(ctx context.Context, bodyHash, email, ip string, since time.Time) (int64, error)
+	GetSpamCorpus(ctx context.Context) (*entity.SpamCorpusEntity, error)
+	TrainComment(ctx context.Context, id int64, label string, tokens []string) (string, error)
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+// GetApprovedComments implements CommentRepository. Top level comments are
+// paginated oldest first, each with all of its approved replies.
+func (c *commentRepository) GetApprovedComments(ctx context.Context, contentID int64, query entity.QueryString) ([]entity.CommentEntity, int64, error) {
+	var modelComments []model.Comment
+	var totalData int64
+
+	db := c.db.WithContext(ctx).Model(&model.Comment{}).
+		Where("content_id = ? AND parent_id IS NULL AND status = ?", contentID, entity.CommentStatusApproved).
+		Session(&gorm.Session{})
+
+	err := db.Count(&totalData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetApprovedComments - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	err = db.Preload("Replies", func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ?", entity.CommentStatusApproved).Order("created_at ASC, id ASC")
+	}).
+		Order("created_at ASC, id ASC").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&modelComments).Error
+	if err != nil {
+		code := "[REPOSITORY] GetApprovedComments - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	res := []entity.CommentEntity{}
+	for _, val := range modelComments {
+		res = append(res, commentToEntity(val))
+	}
+
+	return res, totalData, nil
+}
+
+// GetComments implements CommentRepository. Without a status filter it
+// returns the moderation queue: every comment no editor has looked at yet,
+// oldest first, except those the spam filters already caught.
+func (c *commentRepository) GetComments(ctx context.Context, query entity.QueryString) ([]entity.CommentEntity, int64, error) {
+	var modelComments []model.Comment
+	var totalData int64
+
+	db := c.db.WithContext(ctx).Model(&model.Comment{})
+	order := "comments.created_at ASC, comments.id ASC"
+	if query.Status != "" {
+		db = db.Where("comments.status = ?", query.Status)
+		order = "comments.created_at DESC, comments.id DESC"
+	} else {
+		db = db.Where("comments.moderated_at IS NULL AND comments.status <> ?", entity.CommentStatusSpam)
+	}
+	if query.Search != "" {
+		search := "%" + query.Search + "%"
+		db = db.Where("comments.author_name ILIKE ? OR comments.author_email ILIKE ? OR comments.body ILIKE ?", search, search, search)
+	}
+	db = db.Session(&gorm.Session{})
+
+	err := db.Count(&totalData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetComments - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	err = db.Preload("Content", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id, title, slug")
+	}).
+		Order(order).
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&modelComments).Error
+	if err != nil {
+		code := "[REPOSITORY] GetComments - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	res := []entity.CommentEntity{}
+	for _, val := range modelComments {
+		res = append(res, commentToEntity(val))
+	}
+
+	return res, totalData, nil
+}
+
+// GetCommentByID implements CommentRepository.
+func (c *commentRepository) GetCommentByID(ctx context.Context, id int64) (*entity.CommentEntity, error) {
+	var modelComment model.Comment
+
+	err := c.db.WithContext(ctx).Where("id = ?", id).First(&modelComment).Error
+	if err != nil {
+		code := "[REPOSITORY] GetCommentByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := commentToEntity(modelComment)
+	return &res, nil
+}
+
+// CreateComment implements CommentRepository.
+func (c *commentRepository) CreateComment(ctx context.Context, req entity.CommentEntity) (*entity.CommentEntity, error) {
+	spamChecks, err := json.Marshal(req.SpamChecks)
+	if err != nil {
+		code := "[REPOSITORY] CreateComment - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	modelComment := model.Comment{
+		ContentID:   req.ContentID,
+		AuthorName:  req.AuthorName,
+		AuthorEmail: req.AuthorEmail,
+		IP:          req.IP,
+		UserAgent:   req.UserAgent,
+		Body:        req.Body,
+		BodyHash:    req.BodyHash,
+		Status:      req.Status,
+		SpamScore:   req.SpamScore,
+		SpamChecks:  string(spamChecks),
+	}
+	if req.ParentID > 0 {
+		modelComment.ParentID = &req.ParentID
+	}
+
+	err = c.db.WithContext(ctx).Create(&modelComment).Error
+	if err != nil {
+		code := "[REPOSITORY] CreateComment - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := commentToEntity(modelComment)
+	return &res, nil
+}
+
+// ModerateComment implements CommentRepository.
+func (c *commentRepository) ModerateComment(ctx context.Context, id int64, status string, moderatorID int64) error {
+	result := c.db.WithContext(ctx).Model(&model.Comment{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          status,
+		"moderated_by_id": moderatorID,
+		"moderated_at":    time.Now(),
+		"updated_at":      gorm.Expr("CURRENT_TIMESTAMP"),
+	})
+	if result.Error != nil {
+		code := "[REPOSITORY] ModerateComment - 1"
+		log.Errorw(code, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// BanCommentAuthor implements CommentRepository. The email address and IP
+// of the comment's author are banned, and the comment together with every
+// other comment of theirs still waiting for moderation is rejected.
+func (c *commentRepository) BanCommentAuthor(ctx context.Context, id int64, reason string, moderatorID int64) (*entity.CommentBanEntity, error) {
+	var modelBan model.CommentBan
+
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var modelComment model.Comment
+		err := tx.Where("id = ?", id).First(&modelComment).Error
+		if err != nil {
+			code := "[REPOSITORY] BanCommentAuthor - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		modelBan = model.CommentBan{
+			Email:       strings.ToLower(modelComment.AuthorEmail),
+			IP:          modelComment.IP,
+			Reason:      reason,
+			CreatedByID: &moderatorID,
+		}
+		err = tx.Create(&modelBan).Error
+		if err != nil {
+			code := "[REPOSITORY] BanCommentAuthor - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Model(&model.Comment{}).
+			Where("id = ? OR (moderated_at IS NULL AND (lower(author_email) = ? OR (ip <> '' AND ip = ?)))", id, modelBan.Email, modelBan.IP).
+			Updates(map[string]interface{}{
+				"status":          entity.CommentStatusRejected,
+				"moderated_by_id": moderatorID,
+				"moderated_at":    time.Now(),
+				"updated_at":      gorm.Expr("CURRENT_TIMESTAMP"),
+			}).Error
+		if err != nil {
+			code := "[REPOSITORY] BanCommentAuthor - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := commentBanToEntity(modelBan)
+	return &res, nil
+}
+
+// IsCommentAuthorBanned implements CommentRepository.
+func (c *commentRepository) IsCommentAuthorBanned(ctx context.Context, email, ip string) (bool, error) {
+	var count int64
+
+	err := c.db.WithContext(ctx).Model(&model.CommentBan{}).
+		Where("(email <> '' AND lower(email) = lower(?)) OR (ip <> '' AND ip = ?)", email, ip).
+		Limit(1).
+		Count(&count).Error
+	if err != nil {
+		code := "[REPOSITORY] IsCommentAuthorBanned - 1"
+		log.Errorw(code, err)
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GetCommentBans implements CommentRepository.
+func (c *commentRepository) GetCommentBans(ctx context.Context, query entity.QueryString) ([]entity.CommentBanEntity, int64, error) {
+	var modelBans []model.CommentBan
+	var totalData int64
+
+	db := c.db.WithContext(ctx).Model(&model.CommentBan{})
+	if query.Search != "" {
+		search := "%" + query.Search + "%"
+		db = db.Where("email ILIKE ? OR ip ILIKE ? OR reason ILIKE ?", search, search, search)
+	}
+	db = db.Session(&gorm.Session{})
+
+	err := db.Count(&totalData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetCommentBans - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	err = db.Order("created_at DESC, id DESC").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&modelBans).Error
+	if err != nil {
+		code := "[REPOSITORY] GetCommentBans - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	res := []entity.CommentBanEntity{}
+	for _, val := range modelBans {
+		res = append(res, commentBanToEntity(val))
+	}
+
+	return res, totalData, nil
+}
+
+// DeleteCommentBan implements CommentRepository.
+func (c *commentRepository) DeleteCommentBan(ctx context.Context, id int64) error {
+	result := c.db.WithContext(ctx).Where("id = ?", id).Delete(&model.CommentBan{})
+	if result.Error != nil {
+		code := "[REPOSITORY] DeleteCommentBan - 1"
+		log.Errorw(code, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// CountRecentDuplicates implements CommentRepository. It counts comments
+// with the same normalized body sent since the given time from the same
+// email address or IP.
+func (c *commentRepository) CountRecentDuplicates(ctx context.Context, bodyHash, email, ip string, since time.Time) (int64, error) {
+	var count int64
+
+	err := c.db.WithContext(ctx).Model(&model.Comment{}).
+		Where("body_hash = ? AND created_at >= ?", bodyHash, since).
+		Where("lower(author_email) = lower(?) OR (ip <> '' AND ip = ?)", email, ip).
+		Count(&count).Error
+	if err != nil {
+		code := "[REPOSITORY] CountRecentDuplicates - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetSpamCorpus implements CommentRepository.
+func (c *commentRepository) GetSpamCorpus(ctx context.Context) (*entity.SpamCorpusEntity, error) {
+	var tokens []model.CommentSpamToken
+	var docs []struct {
+		TrainedAs string
+		Documents int
+	}
+
+	err := c.db.WithContext(ctx).Where("spam_count > 0 OR ham_count > 0").Find(&tokens).Error
+	if err != nil {
+		code := "[REPOSITORY] GetSpamCorpus - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	err = c.db.WithContext(ctx).Model(&model.Comment{}).
+		Select("trained_as, COUNT(*) AS documents").
+		Where("trained_as <> ''").
+		Group("trained_as").
+		Scan(&docs).Error
+	if err != nil {
+		code := "[REPOSITORY] GetSpamCorpus - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := &entity.SpamCorpusEntity{SpamTokens: map[string]int{}, HamTokens: map[string]int{}}
+	for _, val := range tokens {
+		if val.SpamCount > 0 {
+			res.SpamTokens[val.Token] = val.SpamCount
+		}
+		if val.HamCount > 0 {
+			res.HamTokens[val.Token] = val.HamCount
+		}
+	}
+	for _, val := range docs {
+		switch val.TrainedAs {
+		case entity.CommentTrainedSpam:
+			res.SpamDocs = val.Documents
+		case entity.CommentTrainedHam:
+			res.HamDocs = val.Documents
+		}
+	}
+
+	return res, nil
+}
+
+// TrainComment implements CommentRepository. It records the comment as an
+// example of label, or of nothing when label is empty, moving its tokens
+// out of the label it was trained as before. It returns that previous
+// label.
+func (c *commentRepository) TrainComment(ctx context.Context, id int64, label string, tokens []string) (string, error) {
+	var previous string
+
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var modelComment model.Comment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id, trained_as").Where("id = ?", id).First(&modelComment).Error
+		if err != nil {
+			code := "[REPOSITORY] TrainComment - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		previous = modelComment.TrainedAs
+		if previous == label {
+			return nil
+		}
+
+		if previous != "" {
+			err = addSpamTokens(tx, previous, tokens, -1)
+			if err != nil {
+				code := "[REPOSITORY] TrainComment - 2"
+				log.Errorw(code, err)
+				return err
+			}
+		}
+
+		if label != "" {
+			err = addSpamTokens(tx, label, tokens, 1)
+			if err != nil {
+				code := "[REPOSITORY] TrainComment - 3"
+				log.Errorw(code, err)
+				return err
+			}
+		}
+
+		err = tx.Model(&model.Comment{}).Where("id = ?", id).Update("trained_as", label).Error
+		if err != nil {
+			code := "[REPOSITORY] TrainComment - 4"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return previous, nil
+}
+
+// addSpamTokens adds delta to the label count of every token. Counts never
+// go below zero.
+func addSpamTokens(tx *gorm.DB, label string, tokens []string, delta int) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	spamDelta, hamDelta := 0, delta
+	if label == entity.CommentTrainedSpam {
+		spamDelta, hamDelta = delta, 0
+	}
+
+	rows := make([]string, 0, len(tokens))
+	args := make([]interface{}, 0, len(tokens)*3)
+	for _, token := range tokens {
+		rows = append(rows, "(?, GREATEST(?::INT, 0), GREATEST(?::INT, 0))")
+		args = append(args, token, spamDelta, hamDelta)
+	}
+
+	return tx.Exec(`INSERT INTO comment_spam_tokens (token, spam_count, ham_count) VALUES `+strings.Join(rows, ", ")+`
+		ON CONFLICT (token) DO UPDATE SET
+			spam_count = GREATEST(comment_spam_tokens.spam_count + ?, 0),
+			ham_count = GREATEST(comment_spam_tokens.ham_count + ?, 0)`, append(args, spamDelta, hamDelta)...).Error
+}
+
+func commentToEntity(val model.Comment) entity.CommentEntity {
+	res := entity.CommentEntity{
+		ID:           val.ID,
+		ContentID:    val.ContentID,
+		ContentTitle: val.Content.Title,
+		ContentSlug:  val.Content.Slug,
+		AuthorName:   val.AuthorName,
+		AuthorEmail:  val.AuthorEmail,
+		IP:           val.IP,
+		UserAgent:    val.UserAgent,
+		Body:         val.Body,
+		BodyHash:     val.BodyHash,
+		Status:       val.Status,
+		SpamScore:    val.SpamScore,
+		TrainedAs:    val.TrainedAs,
+		ModeratedAt:  val.ModeratedAt,
+		CreatedAt:    val.CreatedAt,
+	}
+	if val.ParentID != nil {
+		res.ParentID = *val.ParentID
+	}
+	if val.ModeratedByID != nil {
+		res.ModeratedByID = *val.ModeratedByID
+	}
+	if val.SpamChecks != "" {
+		_ = json.Unmarshal([]byte(val.SpamChecks), &res.SpamChecks)
+	}
+	for _, reply := range val.Replies {
+		res.Replies = append(res.Replies, commentToEntity(reply))
+	}
+
+	return res
+}
+
+func commentBanToEntity(val model.CommentBan) entity.CommentBanEntity {
+	res := entity.CommentBanEntity{
+		ID:        val.ID,
+		Email:     val.Email,
+		IP:        val.IP,
+		Reason:    val.Reason,
+		CreatedAt: val.CreatedAt,
+	}
+	if val.CreatedByID != nil {
+		res.CreatedByID = *val.CreatedByID
+	}
+
+	return res
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
@@ -0,0 +1,438 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+	"news-app/lib/richtext"
+	"news-app/lib/slug"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+var contentOrderColumns = map[string]string{
+	"created_at":   "contents.created_at",
+	"updated_at":   "contents.updated_at",
+	"title":        "contents.title",
+	"published_at": "contents.published_at",
+}
+
+type ContentRepository interface {
+	GetContents(ctx context.Context, query entity.QueryString) ([]entity.ContentEntity, int64, error)
+	GetContentByID(ctx context.Context, id int64) (*entity.ContentEntity, error)
+	GetContentBySlug(ctx context.Context, contentSlug string, publishedOnly bool) (*entity.ContentEntity, error)
+	CreateContent(ctx context.Context, req entity.ContentEntity) (*entity.ContentEntity, error)
+	UpdateContent(ctx context.Context, req entity.ContentEntity) (*entity.ContentEntity, error)
+	DeleteContent(ctx context.Context, id int64) error
+	GetRelatedContents(ctx context.Context, content entity.ContentEntity, limit int) ([]entity.ContentEntity, error)
+}
+
+type contentRepository struct {
+	db *gorm.DB
+}
+
+// GetContents implements ContentRepository.
+func (c *contentRepository) GetContents(ctx context.Context, query entity.QueryString) ([]entity.ContentEntity, int64, error) {
+	var modelContents []model.Content
+	var totalData int64
+
+	db := dbFromContext(ctx, c.db).Model(&model.Content{})
+	if query.Search != "" {
+		search := "%" + query.Search + "%"
+		db = db.Where("contents.title ILIKE ? OR contents.exerpt ILIKE ?", search, search)
+	}
+	if query.Status != "" {
+		db = db.Where("contents.status = ?", query.Status)
+	}
+	if query.CategoryID > 0 {
+		db = db.Where("contents.category_id = ?", query.CategoryID)
+	}
+	if query.Tag != "" {
+		db = db.Where("EXISTS (SELECT 1 FROM unnest(string_to_array(contents.tags, ',')) AS tag WHERE lower(trim(tag)) = lower(?))", query.Tag)
+	}
+	db = db.Session(&gorm.Session{})
+
+	err := db.Count(&totalData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetContents - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	orderBy, ok := contentOrderColumns[query.OrderBy]
+	if !ok {
+		orderBy = contentOrderColumns["created_at"]
+	}
+	orderType := "DESC"
+	if strings.EqualFold(query.OrderType, "asc") {
+		orderType = "ASC"
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	page := query.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	err = db.Preload("User").Preload("Category").Preload("Media").Preload("OgMedia").
+		Order(orderBy + " " + orderType).
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&modelContents).Error
+	if err != nil {
+		code := "[REPOSITORY] GetContents - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	res := []entity.ContentEntity{}
+	for _, val := range modelContents {
+		res = append(res, contentToEntity(val))
+	}
+
+	return res, totalData, nil
+}
+
+// GetContentByID implements ContentRepository.
+func (c *contentRepository) GetContentByID(ctx context.Context, id int64) (*entity.ContentEntity, error) {
+	var modelContent model.Content
+
+	err := dbFromContext(ctx, c.db).Where("id = ?", id).Preload("User").Preload("Category").Preload("Media").Preload("OgMedia").First(&modelContent).Error
+	if err != nil {
+		code := "[REPOSITORY] GetContentByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := contentToEntity(modelContent)
+	return &res, nil
+}
+
+// GetContentBySlug implements ContentRepository.
+func (c *contentRepository) GetContentBySlug(ctx context.Context, contentSlug string, publishedOnly bool) (*entity.ContentEntity, error) {
+	var modelContent model.Content
+
+	db := dbFromContext(ctx, c.db).Preload("User").Preload("Category").Preload("Media").Preload("OgMedia")
+	if publishedOnly {
+		db = db.Where("status = ?", entity.ContentStatusPublish).Preload("ReactionCounts")
+	}
+	db = db.Session(&gorm.Session{})
+
+	err := db.Where("slug = ?", contentSlug).First(&modelContent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		id, errHistory := slug.Resolve(dbFromContext(ctx, c.db), "contents", contentSlug)
+		if errHistory == nil {
+			err = db.Where("id = ?", id).First(&modelContent).Error
+		}
+	}
+	if err != nil {
+		code := "[REPOSITORY] GetContentBySlug - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := contentToEntity(modelContent)
+	return &res, nil
+}
+
+// CreateContent implements ContentRepository.
+func (c *contentRepository) CreateContent(ctx context.Context, req entity.ContentEntity) (*entity.ContentEntity, error) {
+	modelContent := model.Content{
+		Title:             req.Title,
+		Exerpt:            req.Excerpt,
+		Description:       req.Description,
+		DescriptionFormat: req.DescriptionFormat,
+		DescriptionHTML:   req.DescriptionHTML,
+		Tags:              strings.Join(req.Tags, ","),
+		WordCount:         req.WordCount,
+		ReadingTime:       req.ReadingTime,
+		Status:            req.Status,
+		MetaTitle:         req.MetaTitle,
+		MetaDescription:   req.MetaDescription,
+		CanonicalURL:      req.CanonicalURL,
+		Noindex:           req.NoIndex,
+		CommentsEnabled:   req.CommentsEnabled,
+		ContentType:       req.ContentType,
+		CategoryID:        req.CategoryID,
+		CreatedByID:       req.CreatedByID,
+	}
+	if req.MediaID > 0 {
+		modelContent.MediaID = &req.MediaID
+	}
+	if req.OgMediaID > 0 {
+		modelContent.OgMediaID = &req.OgMediaID
+	}
+	if req.Status == entity.ContentStatusPublish {
+		now := time.Now()
+		modelContent.PublishedAt = &now
+	}
+
+	err := dbFromContext(ctx, c.db).Transaction(func(tx *gorm.DB) error {
+		var err error
+		modelContent.Slug, err = slug.Unique(tx, "contents", req.Slug, 0)
+		if err != nil {
+			code := "[REPOSITORY] CreateContent - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Create(&modelContent).Error
+		if err != nil {
+			code := "[REPOSITORY] CreateContent - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req.ID = modelContent.ID
+	req.Slug = modelContent.Slug
+	req.CreatedAt = modelContent.CreatedAt
+	req.PublishedAt = modelContent.PublishedAt
+	return &req, nil
+}
+
+// UpdateContent implements ContentRepository.
+func (c *contentRepository) UpdateContent(ctx context.Context, req entity.ContentEntity) (*entity.ContentEntity, error) {
+	err := dbFromContext(ctx, c.db).Transaction(func(tx *gorm.DB) error {
+		var current model.Content
+		err := tx.Where("id = ?", req.ID).First(&current).Error
+		if err != nil {
+			code := "[REPOSITORY] UpdateContent - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		newSlug := current.Slug
+		if req.Slug != "" && req.Slug != current.Slug {
+			newSlug, err = slug.Unique(tx, "contents", req.Slug, current.ID)
+			if err != nil {
+				code := "[REPOSITORY] UpdateContent - 2"
+				log.Errorw(code, err)
+				return err
+			}
+		}
+
+		updates := map[string]interface{}{
+			"title":              req.Title,
+			"slug":               newSlug,
+			"exerpt":             req.Excerpt,
+			"description":        req.Description,
+			"description_format": req.DescriptionFormat,
+			"description_html":   req.DescriptionHTML,
+			"media_id":           nil,
+			"meta_title":         req.MetaTitle,
+			"meta_description":   req.MetaDescription,
+			"canonical_url":      req.CanonicalURL,
+			"noindex":            req.NoIndex,
+			"comments_enabled":   req.CommentsEnabled,
+			"content_type":       req.ContentType,
+			"og_media_id":        nil,
+			"tags":               strings.Join(req.Tags, ","),
+			"word_count":         req.WordCount,
+			"reading_time":       req.ReadingTime,
+			"status":             req.Status,
+			"category_id":        req.CategoryID,
+			"updated_at":         gorm.Expr("CURRENT_TIMESTAMP"),
+		}
+
+		if req.MediaID > 0 {
+			updates["media_id"] = req.MediaID
+		}
+		if req.OgMediaID > 0 {
+			updates["og_media_id"] = req.OgMediaID
+		}
+
+		// The first publication date is kept when a story is unpublished
+		// and published again.
+		req.PublishedAt = current.PublishedAt
+		if req.Status == entity.ContentStatusPublish && current.PublishedAt == nil {
+			now := time.Now()
+			updates["published_at"] = now
+			req.PublishedAt = &now
+		}
+
+		err = tx.Model(&model.Content{}).Where("id = ?", req.ID).Updates(updates).Error
+		if err != nil {
+			code := "[REPOSITORY] UpdateContent - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = slug.Record(tx, "contents", current.ID, current.Slug, newSlug)
+		if err != nil {
+			code := "[REPOSITORY] UpdateContent - 4"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = recordSlugRedirect(tx, ContentPathPrefix+current.Slug, ContentPathPrefix+newSlug)
+		if err != nil {
+			code := "[REPOSITORY] UpdateContent - 5"
+			log.Errorw(code, err)
+			return err
+		}
+
+		req.Slug = newSlug
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// DeleteContent implements ContentRepository.
+func (c *contentRepository) DeleteContent(ctx context.Context, id int64) error {
+	err := dbFromContext(ctx, c.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ?", id).Delete(&model.Content{}).Error
+		if err != nil {
+			code := "[REPOSITORY] DeleteContent - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Where("entity_type = ? AND entity_id = ?", "contents", id).Delete(&model.SlugHistory{}).Error
+		if err != nil {
+			code := "[REPOSITORY] DeleteContent - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// relatedScore ranks a published story against another one. Every shared
+// tag counts 3, the same category 2, and title and excerpt similarity up to
+// about 3; the last term fades from 1 to 0.5 over the first week so newer
+// stories win ties.
+const relatedScore = `3 * (SELECT count(*) FROM unnest(string_to_array(contents.tags, ',')) AS tag WHERE lower(trim(tag)) IN ?)
+	+ CASE WHEN contents.category_id = ? THEN 2 ELSE 0 END
+	+ 5 * ts_rank(contents.search_vector, replace(plainto_tsquery('simple', ?)::text, '&', '|')::tsquery)
+	+ 1.0 / (1 + EXTRACT(EPOCH FROM (NOW() - contents.published_at)) / 604800)`
+
+// GetRelatedContents implements ContentRepository. Only stories sharing a
+// tag, the category or some words with content are considered.
+func (c *contentRepository) GetRelatedContents(ctx context.Context, content entity.ContentEntity, limit int) ([]entity.ContentEntity, error) {
+	var modelContents []model.Content
+
+	tags := []string{}
+	for _, tag := range content.Tags {
+		tags = append(tags, strings.ToLower(tag))
+	}
+	terms := content.Title + " " + strings.Join(content.Tags, " ")
+
+	err := dbFromContext(ctx, c.db).
+		Select("contents.*, ("+relatedScore+") AS related_score", tags, content.CategoryID, terms).
+		Where("contents.id <> ? AND contents.status = ? AND contents.published_at IS NOT NULL", content.ID, entity.ContentStatusPublish).
+		Where(c.db.Where("EXISTS (SELECT 1 FROM unnest(string_to_array(contents.tags, ',')) AS tag WHERE lower(trim(tag)) IN ?)", tags).
+			Or("contents.category_id = ?", content.CategoryID).
+			Or("contents.search_vector @@ replace(plainto_tsquery('simple', ?)::text, '&', '|')::tsquery", terms)).
+		Preload("User").Preload("Category").Preload("Media").
+		Order("related_score DESC, contents.published_at DESC").
+		Limit(limit).
+		Find(&modelContents).Error
+	if err != nil {
+		code := "[REPOSITORY] GetRelatedContents - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := []entity.ContentEntity{}
+	for _, val := range modelContents {
+		res = append(res, contentToEntity(val))
+	}
+
+	return res, nil
+}
+
+func contentToEntity(val model.Content) entity.ContentEntity {
+	tags := []string{}
+	for _, tag := range strings.Split(val.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	res := entity.ContentEntity{
+		ID:                val.ID,
+		Title:             val.Title,
+		Slug:              val.Slug,
+		Excerpt:           val.Exerpt,
+		Description:       val.Description,
+		DescriptionFormat: val.DescriptionFormat,
+		DescriptionHTML:   val.DescriptionHTML,
+		Tags:              tags,
+		WordCount:         val.WordCount,
+		ReadingTime:       val.ReadingTime,
+		Status:            val.Status,
+		MetaTitle:         val.MetaTitle,
+		MetaDescription:   val.MetaDescription,
+		CanonicalURL:      val.CanonicalURL,
+		NoIndex:           val.Noindex,
+		CommentsEnabled:   val.CommentsEnabled,
+		ContentType:       val.ContentType,
+		CategoryID:        val.CategoryID,
+		CreatedByID:       val.CreatedByID,
+		CreatedAt:         val.CreatedAt,
+		UpdatedAt:         val.UpdatedAt,
+		PublishedAt:       val.PublishedAt,
+		User: entity.UserEntity{
+			ID:    int16(val.User.ID),
+			Name:  val.User.Name,
+			Email: val.User.Email,
+		},
+		Category: entity.CategoryEntity{
+			ID:    int16(val.Category.ID),
+			Title: val.Category.Title,
+			Slug:  val.Category.Slug,
+		},
+	}
+	// Rows saved before rendering existed only hold the raw HTML.
+	if res.DescriptionHTML == "" && res.Description != "" {
+		res.DescriptionHTML = richtext.Sanitize(res.Description)
+	}
+	if val.ReactionCounts != nil {
+		res.Reactions = map[string]int64{}
+		for _, count := range val.ReactionCounts {
+			res.Reactions[count.Reaction] = count.Count
+		}
+	}
+	if val.MediaID != nil {
+		res.MediaID = *val.MediaID
+	}
+	if val.Media != nil {
+		media := mediaToEntity(*val.Media)
+		res.Media = &media
+		res.Image = media.URL
+	}
+	if val.OgMediaID != nil {
+		res.OgMediaID = *val.OgMediaID
+	}
+	if val.OgMedia != nil {
+		media := mediaToEntity(*val.OgMedia)
+		res.OgMedia = &media
+	}
+
+	return res
+}
+
+func NewContentRepository(db *gorm.DB) ContentRepository {
+	return &contentRepository{db: db}
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LibraryRepository interface {
+	GetBookmarks(ctx context.Context, readerID int64, query entity.QueryString) ([]entity.BookmarkEntity, int64, error)
+	AddBookmark(ctx context.Context, readerID, contentID int64) error
+	DeleteBookmark(ctx context.Context, readerID, contentID int64) error
+	GetReadingHistory(ctx context.Context, readerID int64, query entity.QueryString) ([]entity.ReadingHistoryEntity, int64, error)
+	SaveReadingHistory(ctx context.Context, req entity.ReadingHistoryEntity) error
+	DeleteReadingHistory(ctx context.Context, readerID, contentID int64) error
+	ClearReadingHistory(ctx context.Context, readerID int64) (int64, error)
+}
+
+type libraryRepository struct {
+	db *gorm.DB
+}
+
+// GetBookmarks implements LibraryRepository. Newest bookmarks come first;
+// stories that are no longer published are left out.
+func (l *libraryRepository) GetBookmarks(ctx context.Context, readerID int64, query entity.QueryString) ([]entity.BookmarkEntity, int64, error) {
+	var modelBookmarks []model.ReaderBookmark
+	var totalData int64
+
+	db := l.db.WithContext(ctx).Model(&model.ReaderBookmark{}).
+		Joins("JOIN contents ON contents.id = reader_bookmarks.content_id").
+		Where("reader_bookmarks.reader_id = ? AND contents.status = ?", readerID, entity.ContentStatusPublish).
+		Session(&gorm.Session{})
+
+	err := db.Count(&totalData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetBookmarks - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	err = libraryPreload(db).
+		Order("reader_bookmarks.created_at DESC, reader_bookmarks.content_id DESC").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&modelBookmarks).Error
+	if err != nil {
+		code := "[REPOSITORY] GetBookmarks - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	res := []entity.BookmarkEntity{}
+	for _, val := range modelBookmarks {
+		res = append(res, entity.BookmarkEntity{
+			ReaderID:  val.ReaderID,
+			ContentID: val.ContentID,
+			Content:   contentToEntity(val.Content),
+			CreatedAt: val.CreatedAt,
+		})
+	}
+
+	return res, totalData, nil
+}
+
+// AddBookmark implements LibraryRepository. Adding a bookmark twice keeps
+// the first one.
+func (l *libraryRepository) AddBookmark(ctx context.Context, readerID, contentID int64) error {
+	err = l.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&model.ReaderBookmark{
+		ReaderID:  readerID,
+		ContentID: contentID,
+		CreatedAt: time.Now(),
+	}).Error
+	if err != nil {
+		code := "[REPOSITORY] AddBookmark - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteBookmark implements LibraryRepository.
+func (l *libraryRepository) DeleteBookmark(ctx context.Context, readerID, contentID int64) error {
+	result := l.db.WithContext(ctx).Where("reader_id = ? AND content_id = ?", readerID, contentID).Delete(&model.ReaderBookmark{})
+	if result.Error != nil {
+		code := "[REPOSITORY] DeleteBookmark - 1"
+		log.Errorw(code, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetReadingHistory implements LibraryRepository. The most recently read
+// stories come first.
+func (l *libraryRepository) GetReadingHistory(ctx context.Context, readerID int64, query entity.QueryString) ([]entity.ReadingHistoryEntity, int64, error) {
+	var modelHistory []model.ReaderHistory
+	var totalData int64
+
+	db := l.db.WithContext(ctx).Model(&model.ReaderHistory{}).
+		Joins("JOIN contents ON contents.id = reader_history.content_id").
+		Where("reader_history.reader_id = ? AND contents.status = ?", readerID, entity.ContentStatusPublish).
+		Session(&gorm.Session{})
+
+	err := db.Count(&totalData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetReadingHistory - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	err = libraryPreload(db).
+		Order("reader_history.read_at DESC, reader_history.content_id DESC").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&modelHistory).Error
+	if err != nil {
+		code := "[REPOSITORY] GetReadingHistory - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	res := []entity.ReadingHistoryEntity{}
+	for _, val := range modelHistory {
+		res = append(res, entity.ReadingHistoryEntity{
+			ReaderID:  val.ReaderID,
+			ContentID: val.ContentID,
+			Content:   contentToEntity(val.Content),
+			Position:  val.Position,
+			ReadAt:    val.ReadAt,
+		})
+	}
+
+	return res, totalData, nil
+}
+
+// SaveReadingHistory implements LibraryRepository. Reading a story again
+// moves it to the top of the history with the new position.
+func (l *libraryRepository) SaveReadingHistory(ctx context.Context, req entity.ReadingHistoryEntity) error {
+	err = l.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "reader_id"}, {Name: "content_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"position", "read_at"}),
+	}).Create(&model.ReaderHistory{
+		ReaderID:  req.ReaderID,
+		ContentID: req.ContentID,
+		Position:  req.Position,
+		ReadAt:    time.Now(),
+	}).Error
+	if err != nil {
+		code := "[REPOSITORY] SaveReadingHistory - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteReadingHistory implements LibraryRepository.
+func (l *libraryRepository) DeleteReadingHistory(ctx context.Context, readerID, contentID int64) error {
+	result := l.db.WithContext(ctx).Where("reader_id = ? AND content_id = ?", readerID, contentID).Delete(&model.ReaderHistory{})
+	if result.Error != nil {
+		code := "[REPOSITORY] DeleteReadingHistory - 1"
+		log.Errorw(code, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// ClearReadingHistory implements LibraryRepository.
+func (l *libraryRepository) ClearReadingHistory(ctx context.Context, readerID int64) (int64, error) {
+	result := l.db.WithContext(ctx).Where("reader_id = ?", readerID).Delete(&model.ReaderHistory{})
+	if result.Error != nil {
+		code := "[REPOSITORY] ClearReadingHistory - 1"
+		log.Errorw(code, result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// libraryPreload loads what a story card shows.
+func libraryPreload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Content").Preload("Content.User").Preload("Content.Category").Preload("Content.Media")
+}
+
+func NewLibraryRepository(db *gorm.DB) LibraryRepository {
+	return &libraryRepository{db: db}
+}
@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type LiveBlogRepository interface {
+	GetEntries(ctx context.Context, contentID int64, query entity.QueryString) ([]entity.LiveBlogEntryEntity, int64, error)
+	GetEntriesByID(ctx context.Context, contentID int64, ids []int64) ([]entity.LiveBlogEntryEntity, error)
+	GetEntryByID(ctx context.Context, contentID, id int64) (*entity.LiveBlogEntryEntity, error)
+	CreateEntry(ctx context.Context, req entity.LiveBlogEntryEntity) (*entity.LiveBlogEntryEntity, *entity.LiveBlogEventEntity, error)
+	UpdateEntry(ctx context.Context, req entity.LiveBlogEntryEntity) (*entity.LiveBlogEntryEntity, *entity.LiveBlogEventEntity, error)
+	DeleteEntry(ctx context.Context, contentID, id int64) (*entity.LiveBlogEventEntity, error)
+	GetEventsSince(ctx context.Context, contentID, afterID int64, limit int) ([]entity.LiveBlogEventEntity, error)
+	GetLastEventID(ctx context.Context, contentID int64) (int64, error)
+}
+
+type liveBlogRepository struct {
+	db *gorm.DB
+}
+
+// GetEntries implements LiveBlogRepository. Pinned entries come first, then
+// the newest.
+func (l *liveBlogRepository) GetEntries(ctx context.Context, contentID int64, query entity.QueryString) ([]entity.LiveBlogEntryEntity, int64, error) {
+	var modelEntries []model.LiveBlogEntry
+	var totalData int64
+
+	db := l.db.WithContext(ctx).Model(&model.LiveBlogEntry{}).Where("content_id = ?", contentID).Session(&gorm.Session{})
+
+	err := db.Count(&totalData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetEntries - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	err = db.Preload("User").
+		Order("pinned DESC, created_at DESC, id DESC").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&modelEntries).Error
+	if err != nil {
+		code := "[REPOSITORY] GetEntries - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	res := []entity.LiveBlogEntryEntity{}
+	for _, val := range modelEntries {
+		res = append(res, liveBlogEntryToEntity(val))
+	}
+
+	return res, totalData, nil
+}
+
+// GetEntriesByID implements LiveBlogRepository. Ids of deleted entries are
+// skipped.
+func (l *liveBlogRepository) GetEntriesByID(ctx context.Context, contentID int64, ids []int64) ([]entity.LiveBlogEntryEntity, error) {
+	res := []entity.LiveBlogEntryEntity{}
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	var modelEntries []model.LiveBlogEntry
+	err := l.db.WithContext(ctx).Preload("User").Where("content_id = ? AND id IN ?", contentID, ids).Find(&modelEntries).Error
+	if err != nil {
+		code := "[REPOSITORY] GetEntriesByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	for _, val := range modelEntries {
+		res = append(res, liveBlogEntryToEntity(val))
+	}
+
+	return res, nil
+}
+
+// GetEntryByID implements LiveBlogRepository.
+func (l *liveBlogRepository) GetEntryByID(ctx context.Context, contentID, id int64) (*entity.LiveBlogEntryEntity, error) {
+	var modelEntry model.LiveBlogEntry
+
+	err := l.db.WithContext(ctx).Preload("User").Where("content_id = ? AND id = ?", contentID, id).First(&modelEntry).Error
+	if err != nil {
+		code := "[REPOSITORY] GetEntryByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := liveBlogEntryToEntity(modelEntry)
+	return &res, nil
+}
+
+// CreateEntry implements LiveBlogRepository. The entry and its event are
+// written together, so no change is ever missing from the event log.
+func (l *liveBlogRepository) CreateEntry(ctx context.Context, req entity.LiveBlogEntryEntity) (*entity.LiveBlogEntryEntity, *entity.LiveBlogEventEntity, error) {
+	modelEntry := model.LiveBlogEntry{
+		ContentID:  req.ContentID,
+		Title:      req.Title,
+		Body:       req.Body,
+		BodyFormat: req.BodyFormat,
+		BodyHTML:   req.BodyHTML,
+		Pinned:     req.Pinned,
+	}
+	if req.CreatedByID > 0 {
+		modelEntry.CreatedByID = &req.CreatedByID
+	}
+
+	var event *entity.LiveBlogEventEntity
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&modelEntry).Error
+		if err != nil {
+			code := "[REPOSITORY] CreateEntry - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		event, err = addLiveBlogEvent(tx, req.ContentID, modelEntry.ID, entity.LiveBlogEventCreated)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry, err := l.GetEntryByID(ctx, req.ContentID, modelEntry.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entry, event, nil
+}
+
+// UpdateEntry implements LiveBlogRepository.
+func (l *liveBlogRepository) UpdateEntry(ctx context.Context, req entity.LiveBlogEntryEntity) (*entity.LiveBlogEntryEntity, *entity.LiveBlogEventEntity, error) {
+	var event *entity.LiveBlogEventEntity
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.LiveBlogEntry{}).Where("content_id = ? AND id = ?", req.ContentID, req.ID).Updates(map[string]interface{}{
+			"title":       req.Title,
+			"body":        req.Body,
+			"body_format": req.BodyFormat,
+			"body_html":   req.BodyHTML,
+			"pinned":      req.Pinned,
+			"updated_at":  time.Now(),
+		})
+		if result.Error != nil {
+			code := "[REPOSITORY] UpdateEntry - 1"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var err error
+		event, err = addLiveBlogEvent(tx, req.ContentID, req.ID, entity.LiveBlogEventUpdated)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry, err := l.GetEntryByID(ctx, req.ContentID, req.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entry, event, nil
+}
+
+// DeleteEntry implements LiveBlogRepository.
+func (l *liveBlogRepository) DeleteEntry(ctx context.Context, contentID, id int64) (*entity.LiveBlogEventEntity, error) {
+	var event *entity.LiveBlogEventEntity
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("content_id = ? AND id = ?", contentID, id).Delete(&model.LiveBlogEntry{})
+		if result.Error != nil {
+			code := "[REPOSITORY] DeleteEntry - 1"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var err error
+		event, err = addLiveBlogEvent(tx, contentID, id, entity.LiveBlogEventDeleted)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// GetEventsSince implements LiveBlogRepository. Events come oldest first.
+func (l *liveBlogRepository) GetEventsSince(ctx context.Context, contentID, afterID int64, limit int) ([]entity.LiveBlogEventEntity, error) {
+	var modelEvents []model.LiveBlogEvent
+
+	err := l.db.WithContext(ctx).
+		Where("content_id = ? AND id > ?", contentID, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&modelEvents).Error
+	if err != nil {
+		code := "[REPOSITORY] GetEventsSince - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := []entity.LiveBlogEventEntity{}
+	for _, val := range modelEvents {
+		res = append(res, liveBlogEventToEntity(val))
+	}
+
+	return res, nil
+}
+
+// GetLastEventID implements LiveBlogRepository. It returns 0 for a live blog
+// without events.
+func (l *liveBlogRepository) GetLastEventID(ctx context.Context, contentID int64) (int64, error) {
+	var lastID int64
+
+	err := l.db.WithContext(ctx).Model(&model.LiveBlogEvent{}).
+		Where("content_id = ?", contentID).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&lastID).Error
+	if err != nil {
+		code := "[REPOSITORY] GetLastEventID - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return lastID, nil
+}
+
+func addLiveBlogEvent(tx *gorm.DB, contentID, entryID int64, eventType string) (*entity.LiveBlogEventEntity, error) {
+	modelEvent := model.LiveBlogEvent{ContentID: contentID, EntryID: entryID, Type: eventType}
+
+	err := tx.Create(&modelEvent).Error
+	if err != nil {
+		code := "[REPOSITORY] addLiveBlogEvent - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := liveBlogEventToEntity(modelEvent)
+	return &res, nil
+}
+
+func liveBlogEntryToEntity(val model.LiveBlogEntry) entity.LiveBlogEntryEntity {
+	res := entity.LiveBlogEntryEntity{
+		ID:         val.ID,
+		ContentID:  val.ContentID,
+		Title:      val.Title,
+		Body:       val.Body,
+		BodyFormat: val.BodyFormat,
+		BodyHTML:   val.BodyHTML,
+		Pinned:     val.Pinned,
+		CreatedAt:  val.CreatedAt,
+		UpdatedAt:  val.UpdatedAt,
+	}
+	if val.CreatedByID != nil {
+		res.CreatedByID = *val.CreatedByID
+	}
+	if val.User != nil {
+		res.Author = val.User.Name
+	}
+
+	return res
+}
+
+func liveBlogEventToEntity(val model.LiveBlogEvent) entity.LiveBlogEventEntity {
+	return entity.LiveBlogEventEntity{
+		ID:        val.ID,
+		ContentID: val.ContentID,
+		EntryID:   val.EntryID,
+		Type:      val.Type,
+		CreatedAt: val.CreatedAt,
+	}
+}
+
+func NewLiveBlogRepository(db *gorm.DB) LiveBlogRepository {
+	return &liveBlogRepository{db: db}
+}
@@ -0,0 +1,481 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+	"news-app/lib/imaging"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrMediaInUse = errors.New("media is still used by one or more contents")
+
+// mediaUsageSelect adds the number of contents using each media row as the
+// usage_count column.
+const mediaUsageSelect = "media.*, (SELECT COUNT(*) FROM contents WHERE contents.media_id = media.id OR contents.og_media_id = media.id) AS usage_count"
+
+type MediaRepository interface {
+	GetMedia(ctx context.Context, query entity.QueryString) ([]entity.MediaEntity, int64, error)
+	GetMediaByID(ctx context.Context, id int64) (*entity.MediaEntity, error)
+	CreateMedia(ctx context.Context, req entity.MediaEntity) (*entity.MediaEntity, error)
+	UpdateMedia(ctx context.Context, req entity.MediaEntity) (*entity.MediaEntity, error)
+	DeleteMedia(ctx context.Context, id int64) error
+
+	CreateUpload(ctx context.Context, req entity.MediaUploadEntity) (*entity.MediaUploadEntity, error)
+	GetUploadByID(ctx context.Context, id int64) (*entity.MediaUploadEntity, error)
+	CompleteUpload(ctx context.Context, uploadID int64, req entity.MediaEntity) (*entity.MediaEntity, error)
+	GetExpiredUploads(ctx context.Context, before time.Time, limit int) ([]entity.MediaUploadEntity, error)
+	DeleteUpload(ctx context.Context, id int64) error
+
+	GetMediaFiles(ctx context.Context) ([]entity.MediaEntity, error)
+	GetUploadKeys(ctx context.Context) ([]string, error)
+	GetDescriptionsContaining(ctx context.Context, text string) ([]string, error)
+	GetLiveBlogBodiesContaining(ctx context.Context, text string) ([]string, error)
+}
+
+type mediaRepository struct {
+	db *gorm.DB
+}
+
+// GetMedia implements MediaRepository.
+func (m *mediaRepository) GetMedia(ctx context.Context, query entity.QueryString) ([]entity.MediaEntity, int64, error) {
+	var modelMedia []model.Media
+	var totalData int64
+
+	db := m.db.WithContext(ctx).Model(&model.Media{})
+	if query.Search != "" {
+		search := "%" + query.Search + "%"
+		db = db.Where("media.file_name ILIKE ? OR media.alt_text ILIKE ? OR media.caption ILIKE ? OR media.credit ILIKE ?", search, search, search, search)
+	}
+	if query.Tag != "" {
+		db = db.Where("EXISTS (SELECT 1 FROM unnest(string_to_array(media.tags, ',')) AS tag WHERE lower(trim(tag)) = lower(?))", query.Tag)
+	}
+	db = db.Session(&gorm.Session{})
+
+	err := db.Count(&totalData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetMedia - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	page := query.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	err = db.Select(mediaUsageSelect).
+		Order("media.created_at DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&modelMedia).Error
+	if err != nil {
+		code := "[REPOSITORY] GetMedia - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	res := []entity.MediaEntity{}
+	for _, val := range modelMedia {
+		res = append(res, mediaToEntity(val))
+	}
+
+	return res, totalData, nil
+}
+
+// GetMediaByID implements MediaRepository.
+func (m *mediaRepository) GetMediaByID(ctx context.Context, id int64) (*entity.MediaEntity, error) {
+	var modelMedia model.Media
+
+	err := m.db.WithContext(ctx).Select(mediaUsageSelect).Where("media.id = ?", id).First(&modelMedia).Error
+	if err != nil {
+		code := "[REPOSITORY] GetMediaByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := mediaToEntity(modelMedia)
+	return &res, nil
+}
+
+// CreateMedia implements MediaRepository.
+func (m *mediaRepository) CreateMedia(ctx context.Context, req entity.MediaEntity) (*entity.MediaEntity, error) {
+	modelMedia := model.Media{
+		URL:       req.URL,
+		FileName:  req.FileName,
+		MimeType:  req.MimeType,
+		Size:      req.Size,
+		Width:     req.Width,
+		Height:    req.Height,
+		AltText:   req.AltText,
+		Caption:   req.Caption,
+		Credit:    req.Credit,
+		Copyright: req.Copyright,
+		License:   req.License,
+		Tags:      strings.Join(req.Tags, ","),
+		Variants:  variantsToColumn(req.Variants),
+	}
+	if req.UploadedByID > 0 {
+		modelMedia.UploadedByID = &req.UploadedByID
+	}
+
+	err := m.db.WithContext(ctx).Create(&modelMedia).Error
+	if err != nil {
+		code := "[REPOSITORY] CreateMedia - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	req.ID = modelMedia.ID
+	req.CreatedAt = modelMedia.CreatedAt
+	return &req, nil
+}
+
+// UpdateMedia implements MediaRepository. Only the editorial metadata can
+// change; the stored file and its dimensions are fixed at upload.
+func (m *mediaRepository) UpdateMedia(ctx context.Context, req entity.MediaEntity) (*entity.MediaEntity, error) {
+	result := m.db.WithContext(ctx).Model(&model.Media{}).Where("id = ?", req.ID).Updates(map[string]interface{}{
+		"alt_text":   req.AltText,
+		"caption":    req.Caption,
+		"credit":     req.Credit,
+		"copyright":  req.Copyright,
+		"license":    req.License,
+		"tags":       strings.Join(req.Tags, ","),
+		"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+	})
+	if result.Error != nil {
+		code := "[REPOSITORY] UpdateMedia - 1"
+		log.Errorw(code, result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		code := "[REPOSITORY] UpdateMedia - 2"
+		log.Errorw(code, gorm.ErrRecordNotFound)
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &req, nil
+}
+
+// DeleteMedia implements MediaRepository. The row is locked before the usage
+// check so a content cannot start referencing it until the delete is done.
+func (m *mediaRepository) DeleteMedia(ctx context.Context, id int64) error {
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var modelMedia model.Media
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&modelMedia).Error
+		if err != nil {
+			code := "[REPOSITORY] DeleteMedia - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		var usage int64
+		err = tx.Model(&model.Content{}).Where("media_id = ? OR og_media_id = ?", id, id).Count(&usage).Error
+		if err != nil {
+			code := "[REPOSITORY] DeleteMedia - 2"
+			log.Errorw(code, err)
+			return err
+		}
+		if usage > 0 {
+			return ErrMediaInUse
+		}
+
+		err = tx.Where("id = ?", id).Delete(&model.Media{}).Error
+		if err != nil {
+			code := "[REPOSITORY] DeleteMedia - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// CreateUpload implements MediaRepository.
+func (m *mediaRepository) CreateUpload(ctx context.Context, req entity.MediaUploadEntity) (*entity.MediaUploadEntity, error) {
+	modelUpload := model.MediaUpload{
+		ObjectKey:   req.ObjectKey,
+		FileName:    req.FileName,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		ExpiresAt:   req.ExpiresAt,
+	}
+	if req.UploadedByID > 0 {
+		modelUpload.UploadedByID = &req.UploadedByID
+	}
+
+	err := m.db.WithContext(ctx).Create(&modelUpload).Error
+	if err != nil {
+		code := "[REPOSITORY] CreateUpload - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	req.ID = modelUpload.ID
+	req.CreatedAt = modelUpload.CreatedAt
+	return &req, nil
+}
+
+// GetUploadByID implements MediaRepository.
+func (m *mediaRepository) GetUploadByID(ctx context.Context, id int64) (*entity.MediaUploadEntity, error) {
+	var modelUpload model.MediaUpload
+
+	err := m.db.WithContext(ctx).Where("id = ?", id).First(&modelUpload).Error
+	if err != nil {
+		code := "[REPOSITORY] GetUploadByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := mediaUploadToEntity(modelUpload)
+	return &res, nil
+}
+
+// CompleteUpload implements MediaRepository. The pending upload is removed
+// in the same transaction that registers the media, so it is completed at
+// most once and the cleanup never deletes a registered file.
+func (m *mediaRepository) CompleteUpload(ctx context.Context, uploadID int64, req entity.MediaEntity) (*entity.MediaEntity, error) {
+	modelMedia := model.Media{
+		URL:       req.URL,
+		FileName:  req.FileName,
+		MimeType:  req.MimeType,
+		Size:      req.Size,
+		Width:     req.Width,
+		Height:    req.Height,
+		AltText:   req.AltText,
+		Caption:   req.Caption,
+		Credit:    req.Credit,
+		Copyright: req.Copyright,
+		License:   req.License,
+		Tags:      strings.Join(req.Tags, ","),
+		Variants:  variantsToColumn(req.Variants),
+	}
+	if req.UploadedByID > 0 {
+		modelMedia.UploadedByID = &req.UploadedByID
+	}
+
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", uploadID).Delete(&model.MediaUpload{})
+		if result.Error != nil {
+			code := "[REPOSITORY] CompleteUpload - 1"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		err := tx.Create(&modelMedia).Error
+		if err != nil {
+			code := "[REPOSITORY] CompleteUpload - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req.ID = modelMedia.ID
+	req.CreatedAt = modelMedia.CreatedAt
+	return &req, nil
+}
+
+// GetExpiredUploads implements MediaRepository.
+func (m *mediaRepository) GetExpiredUploads(ctx context.Context, before time.Time, limit int) ([]entity.MediaUploadEntity, error) {
+	var modelUploads []model.MediaUpload
+
+	err := m.db.WithContext(ctx).Where("expires_at < ?", before).Order("expires_at ASC").Limit(limit).Find(&modelUploads).Error
+	if err != nil {
+		code := "[REPOSITORY] GetExpiredUploads - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := []entity.MediaUploadEntity{}
+	for _, val := range modelUploads {
+		res = append(res, mediaUploadToEntity(val))
+	}
+
+	return res, nil
+}
+
+// DeleteUpload implements MediaRepository.
+func (m *mediaRepository) DeleteUpload(ctx context.Context, id int64) error {
+	err := m.db.WithContext(ctx).Where("id = ?", id).Delete(&model.MediaUpload{}).Error
+	if err != nil {
+		code := "[REPOSITORY] DeleteUpload - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetMediaFiles implements MediaRepository. Only the URL, dimensions and stored
+// variants of each media row are loaded, which is all that tells which
+// bucket objects it uses.
+func (m *mediaRepository) GetMediaFiles(ctx context.Context) ([]entity.MediaEntity, error) {
+	var modelMedia []model.Media
+
+	err := m.db.WithContext(ctx).Select("id", "url", "width", "height", "variants").Find(&modelMedia).Error
+	if err != nil {
+		code := "[REPOSITORY] GetMediaFiles - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := make([]entity.MediaEntity, 0, len(modelMedia))
+	for _, val := range modelMedia {
+		res = append(res, mediaToEntity(val))
+	}
+
+	return res, nil
+}
+
+// GetUploadKeys implements MediaRepository.
+func (m *mediaRepository) GetUploadKeys(ctx context.Context) ([]string, error) {
+	var keys []string
+
+	err := m.db.WithContext(ctx).Model(&model.MediaUpload{}).Pluck("object_key", &keys).Error
+	if err != nil {
+		code := "[REPOSITORY] GetUploadKeys - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// GetDescriptionsContaining implements MediaRepository. It returns the body
+// of every content mentioning text, such as the bucket's public URL.
+func (m *mediaRepository) GetDescriptionsContaining(ctx context.Context, text string) ([]string, error) {
+	var descriptions []string
+
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+	err := m.db.WithContext(ctx).Model(&model.Content{}).Where("description LIKE ?", "%"+escaped+"%").Pluck("description", &descriptions).Error
+	if err != nil {
+		code := "[REPOSITORY] GetDescriptionsContaining - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return descriptions, nil
+}
+
+// GetLiveBlogBodiesContaining implements MediaRepository. It returns the
+// body of every live blog entry mentioning text.
+func (m *mediaRepository) GetLiveBlogBodiesContaining(ctx context.Context, text string) ([]string, error) {
+	var bodies []string
+
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+	err := m.db.WithContext(ctx).Model(&model.LiveBlogEntry{}).Where("body LIKE ?", "%"+escaped+"%").Pluck("body", &bodies).Error
+	if err != nil {
+		code := "[REPOSITORY] GetLiveBlogBodiesContaining - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return bodies, nil
+}
+
+func mediaUploadToEntity(val model.MediaUpload) entity.MediaUploadEntity {
+	res := entity.MediaUploadEntity{
+		ID:          val.ID,
+		ObjectKey:   val.ObjectKey,
+		FileName:    val.FileName,
+		ContentType: val.ContentType,
+		Size:        val.Size,
+		ExpiresAt:   val.ExpiresAt,
+		CreatedAt:   val.CreatedAt,
+	}
+	if val.UploadedByID != nil {
+		res.UploadedByID = *val.UploadedByID
+	}
+
+	return res
+}
+
+func mediaToEntity(val model.Media) entity.MediaEntity {
+	tags := []string{}
+	for _, tag := range strings.Split(val.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	res := entity.MediaEntity{
+		ID:         val.ID,
+		URL:        val.URL,
+		FileName:   val.FileName,
+		MimeType:   val.MimeType,
+		Size:       val.Size,
+		Width:      val.Width,
+		Height:     val.Height,
+		AltText:    val.AltText,
+		Caption:    val.Caption,
+		Credit:     val.Credit,
+		Copyright:  val.Copyright,
+		License:    val.License,
+		Tags:       tags,
+		Variants:   variantsFromColumn(val.Variants),
+		UsageCount: val.UsageCount,
+		CreatedAt:  val.CreatedAt,
+		UpdatedAt:  val.UpdatedAt,
+	}
+	if val.UploadedByID != nil {
+		res.UploadedByID = *val.UploadedByID
+	}
+
+	return res
+}
+
+// variantsToColumn stores the names and sizes of the variants written for
+// a media row; their URLs follow from its own.
+func variantsToColumn(variants []entity.ImageVariantEntity) string {
+	res := make([]imaging.Variant, 0, len(variants))
+	for _, variant := range variants {
+		res = append(res, imaging.Variant{Name: variant.Name, Width: variant.Width, Height: variant.Height})
+	}
+
+	return imaging.FormatVariants(res)
+}
+
+func variantsFromColumn(value string) []entity.ImageVariantEntity {
+	if value == "" {
+		return nil
+	}
+
+	variants, err := imaging.ParseVariants(value)
+	if err != nil {
+		code := "[REPOSITORY] variantsFromColumn - 1"
+		log.Errorw(code, err)
+		return nil
+	}
+
+	res := make([]entity.ImageVariantEntity, 0, len(variants))
+	for _, variant := range variants {
+		res = append(res, entity.ImageVariantEntity{Name: variant.Name, Width: variant.Width, Height: variant.Height})
+	}
+
+	return res
+}
+
+func NewMediaRepository(db *gorm.DB) MediaRepository {
+	return &mediaRepository{db: db}
+}
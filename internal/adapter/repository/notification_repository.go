@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	GetRecipients(ctx context.Context, notificationType string, userIDs []int64) ([]int64, error)
+	CreateNotifications(ctx context.Context, reqs []entity.NotificationEntity) ([]entity.NotificationEntity, error)
+	GetNotifications(ctx context.Context, userID int64, unreadOnly bool, query entity.QueryString) ([]entity.NotificationEntity, int64, error)
+	CountUnread(ctx context.Context, userID int64) (int64, error)
+	MarkNotificationsRead(ctx context.Context, userID int64, ids []int64) (int64, error)
+	GetSubscriptions(ctx context.Context, userID int64) (map[string]bool, error)
+	SaveSubscriptions(ctx context.Context, userID int64, subscriptions map[string]bool) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// GetRecipients implements NotificationRepository. Staff who did not turn
+// the type off are subscribed; with userIDs only those are considered.
+func (n *notificationRepository) GetRecipients(ctx context.Context, notificationType string, userIDs []int64) ([]int64, error) {
+	var ids []int64
+
+	db := n.db.WithContext(ctx).Table("users").
+		Joins("LEFT JOIN notification_subscriptions ON notification_subscriptions.user_id = users.id AND notification_subscriptions.type = ?", notificationType).
+		Where("COALESCE(notification_subscriptions.enabled, TRUE)")
+	if userIDs != nil {
+		db = db.Where("users.id IN ?", userIDs)
+	}
+
+	err := db.Order("users.id").Pluck("users.id", &ids).Error
+	if err != nil {
+		code := "[REPOSITORY] GetRecipients - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// CreateNotifications implements NotificationRepository. The notifications
+// are returned with their actor and story, ready to be pushed.
+func (n *notificationRepository) CreateNotifications(ctx context.Context, reqs []entity.NotificationEntity) ([]entity.NotificationEntity, error) {
+	if len(reqs) == 0 {
+		return []entity.NotificationEntity{}, nil
+	}
+
+	now := time.Now()
+	modelNotifications := make([]model.Notification, 0, len(reqs))
+	for _, req := range reqs {
+		modelNotification := model.Notification{
+			UserID:    req.UserID,
+			Type:      req.Type,
+			Message:   req.Message,
+			CreatedAt: now,
+		}
+		if req.ActorID > 0 {
+			modelNotification.ActorID = &req.ActorID
+		}
+		if req.ContentID > 0 {
+			modelNotification.ContentID = &req.ContentID
+		}
+		if req.CommentID > 0 {
+			modelNotification.CommentID = &req.CommentID
+		}
+
+		modelNotifications = append(modelNotifications, modelNotification)
+	}
+
+	err := n.db.WithContext(ctx).Create(&modelNotifications).Error
+	if err != nil {
+		code := "[REPOSITORY] CreateNotifications - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(modelNotifications))
+	for _, val := range modelNotifications {
+		ids = append(ids, val.ID)
+	}
+
+	modelNotifications = nil
+	err = notificationPreload(n.db.WithContext(ctx)).Where("notifications.id IN ?", ids).Order("notifications.id").Find(&modelNotifications).Error
+	if err != nil {
+		code := "[REPOSITORY] CreateNotifications - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := []entity.NotificationEntity{}
+	for _, val := range modelNotifications {
+		res = append(res, notificationToEntity(val))
+	}
+
+	return res, nil
+}
+
+// GetNotifications implements NotificationRepository. Newest notifications
+// come first.
+func (n *notificationRepository) GetNotifications(ctx context.Context, userID int64, unreadOnly bool, query entity.QueryString) ([]entity.NotificationEntity, int64, error) {
+	var modelNotifications []model.Notification
+	var totalData int64
+
+	db := n.db.WithContext(ctx).Model(&model.Notification{}).Where("notifications.user_id = ?", userID)
+	if unreadOnly {
+		db = db.Where("notifications.read_at IS NULL")
+	}
+	db = db.Session(&gorm.Session{})
+
+	err := db.Count(&totalData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetNotifications - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	err = notificationPreload(db).
+		Order("notifications.id DESC").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&modelNotifications).Error
+	if err != nil {
+		code := "[REPOSITORY] GetNotifications - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	res := []entity.NotificationEntity{}
+	for _, val := range modelNotifications {
+		res = append(res, notificationToEntity(val))
+	}
+
+	return res, totalData, nil
+}
+
+// CountUnread implements NotificationRepository.
+func (n *notificationRepository) CountUnread(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+
+	err := n.db.WithContext(ctx).Model(&model.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	if err != nil {
+		code := "[REPOSITORY] CountUnread - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkNotificationsRead implements NotificationRepository. Without ids all
+// notifications of the user are marked.
+func (n *notificationRepository) MarkNotificationsRead(ctx context.Context, userID int64, ids []int64) (int64, error) {
+	db := n.db.WithContext(ctx).Model(&model.Notification{}).Where("user_id = ? AND read_at IS NULL", userID)
+	if len(ids) > 0 {
+		db = db.Where("id IN ?", ids)
+	}
+
+	result := db.Update("read_at", time.Now())
+	if result.Error != nil {
+		code := "[REPOSITORY] MarkNotificationsRead - 1"
+		log.Errorw(code, result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// GetSubscriptions implements NotificationRepository. Only the types the
+// user changed are returned.
+func (n *notificationRepository) GetSubscriptions(ctx context.Context, userID int64) (map[string]bool, error) {
+	var modelSubscriptions []model.NotificationSubscription
+
+	err := n.db.WithContext(ctx).Where("user_id = ?", userID).Find(&modelSubscriptions).Error
+	if err != nil {
+		code := "[REPOSITORY] GetSubscriptions - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := map[string]bool{}
+	for _, val := range modelSubscriptions {
+		res[val.Type] = val.Enabled
+	}
+
+	return res, nil
+}
+
+// SaveSubscriptions implements NotificationRepository. Types left out are
+// kept as they are.
+func (n *notificationRepository) SaveSubscriptions(ctx context.Context, userID int64, subscriptions map[string]bool) error {
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	modelSubscriptions := make([]model.NotificationSubscription, 0, len(subscriptions))
+	for notificationType, enabled := range subscriptions {
+		modelSubscriptions = append(modelSubscriptions, model.NotificationSubscription{
+			UserID:  userID,
+			Type:    notificationType,
+			Enabled: enabled,
+		})
+	}
+
+	err = n.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+	}).Create(&modelSubscriptions).Error
+	if err != nil {
+		code := "[REPOSITORY] SaveSubscriptions - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+func notificationPreload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Actor").Preload("Content", func(db *gorm.DB) *gorm.DB {
+		return db.Select("id", "title")
+	})
+}
+
+func notificationToEntity(val model.Notification) entity.NotificationEntity {
+	res := entity.NotificationEntity{
+		ID:        val.ID,
+		UserID:    val.UserID,
+		Type:      val.Type,
+		Message:   val.Message,
+		ReadAt:    val.ReadAt,
+		CreatedAt: val.CreatedAt,
+	}
+	if val.ActorID != nil {
+		res.ActorID = *val.ActorID
+	}
+	if val.Actor != nil {
+		res.Actor = val.Actor.Name
+	}
+	if val.ContentID != nil {
+		res.ContentID = *val.ContentID
+	}
+	if val.Content != nil {
+		res.ContentTitle = val.Content.Title
+	}
+	if val.CommentID != nil {
+		res.CommentID = *val.CommentID
+	}
+
+	return res
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type OutboxRepository interface {
+	AddEvents(ctx context.Context, events []entity.DomainEventEntity) error
+	ClaimDueEvents(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.DomainEventEntity, error)
+	MarkEventProcessed(ctx context.Context, id int64, now time.Time) error
+	MarkEventFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+	DeleteProcessedEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// AddEvents implements OutboxRepository. It joins the transaction of ctx,
+// so the events are stored if and only if the change they describe is.
+func (o *outboxRepository) AddEvents(ctx context.Context, events []entity.DomainEventEntity) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	modelEvents := make([]model.OutboxEvent, 0, len(events))
+	for _, event := range events {
+		modelEvents = append(modelEvents, model.OutboxEvent{
+			EventID:       event.EventID,
+			AggregateType: event.AggregateType,
+			AggregateID:   event.AggregateID,
+			EventType:     event.Type,
+			Payload:       event.Payload,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+
+	err := dbFromContext(ctx, o.db).Create(&modelEvents).Error
+	if err != nil {
+		code := "[REPOSITORY] AddEvents - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// ClaimDueEvents implements OutboxRepository. Events come oldest first and
+// are not due again before leaseUntil, so concurrent relays skip them and a
+// relay that dies while handling them only delays them.
+func (o *outboxRepository) ClaimDueEvents(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.DomainEventEntity, error) {
+	var ids []int64
+
+	err := o.db.WithContext(ctx).Raw(`UPDATE outbox SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE processed_at IS NULL AND next_attempt_at <= ?
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`,
+		leaseUntil, now, limit).Scan(&ids).Error
+	if err != nil {
+		code := "[REPOSITORY] ClaimDueEvents - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := []entity.DomainEventEntity{}
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	var modelEvents []model.OutboxEvent
+	err = o.db.WithContext(ctx).Where("id IN ?", ids).Order("id").Find(&modelEvents).Error
+	if err != nil {
+		code := "[REPOSITORY] ClaimDueEvents - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	for _, val := range modelEvents {
+		res = append(res, entity.DomainEventEntity{
+			ID:            val.ID,
+			EventID:       val.EventID,
+			AggregateType: val.AggregateType,
+			AggregateID:   val.AggregateID,
+			Type:          val.EventType,
+			Payload:       val.Payload,
+			Attempts:      val.Attempts,
+			CreatedAt:     val.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+// MarkEventProcessed implements OutboxRepository.
+func (o *outboxRepository) MarkEventProcessed(ctx context.Context, id int64, now time.Time) error {
+	err := o.db.WithContext(ctx).Model(&model.OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"processed_at": now,
+		"attempts":     gorm.Expr("attempts + 1"),
+		"last_error":   "",
+	}).Error
+	if err != nil {
+		code := "[REPOSITORY] MarkEventProcessed - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// MarkEventFailed implements OutboxRepository.
+func (o *outboxRepository) MarkEventFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	err := o.db.WithContext(ctx).Model(&model.OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"next_attempt_at": nextAttemptAt,
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      lastError,
+	}).Error
+	if err != nil {
+		code := "[REPOSITORY] MarkEventFailed - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteProcessedEvents implements OutboxRepository.
+func (o *outboxRepository) DeleteProcessedEvents(ctx context.Context, before time.Time) (int64, error) {
+	result := o.db.WithContext(ctx).Where("processed_at < ?", before).Delete(&model.OutboxEvent{})
+	if result.Error != nil {
+		code := "[REPOSITORY] DeleteProcessedEvents - 1"
+		log.Errorw(code, result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
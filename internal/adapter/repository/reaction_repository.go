@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReactionRepository interface {
+	GetReactionCounts(ctx context.Context, contentID int64) (map[string]int64, error)
+	GetReaction(ctx context.Context, contentID int64, reactor entity.ReactorEntity) (string, error)
+	SetReaction(ctx context.Context, contentID int64, reactor entity.ReactorEntity, reaction string) error
+	DeleteReaction(ctx context.Context, contentID int64, reactor entity.ReactorEntity) error
+}
+
+type reactionRepository struct {
+	db *gorm.DB
+}
+
+// GetReactionCounts implements ReactionRepository. Counts come from the
+// counter table, never from the reactions themselves.
+func (r *reactionRepository) GetReactionCounts(ctx context.Context, contentID int64) (map[string]int64, error) {
+	var counts []model.ContentReactionCount
+
+	err = r.db.WithContext(ctx).Where("content_id = ?", contentID).Find(&counts).Error
+	if err != nil {
+		code := "[REPOSITORY] GetReactionCounts - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := map[string]int64{}
+	for _, val := range counts {
+		res[val.Reaction] = val.Count
+	}
+
+	return res, nil
+}
+
+// GetReaction implements ReactionRepository. It returns an empty string
+// when the reactor has not reacted.
+func (r *reactionRepository) GetReaction(ctx context.Context, contentID int64, reactor entity.ReactorEntity) (string, error) {
+	var modelReaction model.ContentReaction
+
+	err = reactorScope(r.db.WithContext(ctx), contentID, reactor).First(&modelReaction).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		code := "[REPOSITORY] GetReaction - 1"
+		log.Errorw(code, err)
+		return "", err
+	}
+
+	return modelReaction.Reaction, nil
+}
+
+// SetReaction implements ReactionRepository. A reactor has one reaction per
+// content; setting another one replaces it. The counters move in the same
+// transaction.
+func (r *reactionRepository) SetReaction(ctx context.Context, contentID int64, reactor entity.ReactorEntity, reaction string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		modelReaction := model.ContentReaction{ContentID: contentID, Reaction: reaction}
+		if reactor.ReaderID > 0 {
+			modelReaction.ReaderID = &reactor.ReaderID
+		} else {
+			modelReaction.Fingerprint = &reactor.Fingerprint
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&modelReaction)
+		if result.Error != nil {
+			code := "[REPOSITORY] SetReaction - 1"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return addReactionCount(tx, contentID, reaction, 1)
+		}
+
+		// The reactor had reacted already, or did so at the same moment.
+		var existing model.ContentReaction
+		err := reactorScope(tx, contentID, reactor).Clauses(clause.Locking{Strength: "UPDATE"}).First(&existing).Error
+		if err != nil {
+			code := "[REPOSITORY] SetReaction - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		if existing.Reaction == reaction {
+			return nil
+		}
+
+		err = tx.Model(&existing).Updates(map[string]interface{}{
+			"reaction":   reaction,
+			"updated_at": time.Now(),
+		}).Error
+		if err != nil {
+			code := "[REPOSITORY] SetReaction - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = addReactionCount(tx, contentID, existing.Reaction, -1)
+		if err != nil {
+			return err
+		}
+
+		return addReactionCount(tx, contentID, reaction, 1)
+	})
+}
+
+// DeleteReaction implements ReactionRepository.
+func (r *reactionRepository) DeleteReaction(ctx context.Context, contentID int64, reactor entity.ReactorEntity) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var deleted []model.ContentReaction
+
+		err := reactorScope(tx, contentID, reactor).Clauses(clause.Returning{}).Delete(&deleted).Error
+		if err != nil {
+			code := "[REPOSITORY] DeleteReaction - 1"
+			log.Errorw(code, err)
+			return err
+		}
+		if len(deleted) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return addReactionCount(tx, contentID, deleted[0].Reaction, -1)
+	})
+}
+
+func reactorScope(db *gorm.DB, contentID int64, reactor entity.ReactorEntity) *gorm.DB {
+	db = db.Where("content_id = ?", contentID)
+	if reactor.ReaderID > 0 {
+		return db.Where("reader_id = ?", reactor.ReaderID)
+	}
+
+	return db.Where("fingerprint = ?", reactor.Fingerprint)
+}
+
+func addReactionCount(tx *gorm.DB, contentID int64, reaction string, delta int64) error {
+	err := tx.Exec(`INSERT INTO content_reaction_counts (content_id, reaction, count) VALUES (?, ?, GREATEST(?::BIGINT, 0))
+		ON CONFLICT (content_id, reaction) DO UPDATE SET count = GREATEST(content_reaction_counts.count + ?::BIGINT, 0)`,
+		contentID, reaction, delta, delta).Error
+	if err != nil {
+		code := "[REPOSITORY] addReactionCount - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+func NewReactionRepository(db *gorm.DB) ReactionRepository {
+	return &reactionRepository{db: db}
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type ReaderRepository interface {
+	CreateReader(ctx context.Context, req entity.ReaderEntity, tokenHash string) (*entity.ReaderEntity, error)
+	GetReaderByID(ctx context.Context, id int64) (*entity.ReaderEntity, error)
+	GetReaderByEmail(ctx context.Context, email string) (*entity.ReaderEntity, error)
+	SetReaderVerificationToken(ctx context.Context, id int64, tokenHash string) error
+	VerifyReaderEmail(ctx context.Context, tokenHash string, sentAfter time.Time) (*entity.ReaderEntity, error)
+	UpdateReader(ctx context.Context, req entity.ReaderEntity) (*entity.ReaderEntity, error)
+	UpdateReaderPassword(ctx context.Context, id int64, password string) error
+	DeleteReader(ctx context.Context, id int64) error
+}
+
+type readerRepository struct {
+	db *gorm.DB
+}
+
+// CreateReader implements ReaderRepository. The reader starts unverified
+// with the hash of the token sent to their address.
+func (r *readerRepository) CreateReader(ctx context.Context, req entity.ReaderEntity, tokenHash string) (*entity.ReaderEntity, error) {
+	now := time.Now()
+	modelReader := model.Reader{
+		Name:               req.Name,
+		Email:              req.Email,
+		Password:           req.Password,
+		VerificationToken:  &tokenHash,
+		VerificationSentAt: &now,
+	}
+
+	err = r.db.WithContext(ctx).Create(&modelReader).Error
+	if err != nil {
+		code := "[REPOSITORY] CreateReader - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := readerToEntity(modelReader)
+	return &res, nil
+}
+
+// GetReaderByID implements ReaderRepository.
+func (r *readerRepository) GetReaderByID(ctx context.Context, id int64) (*entity.ReaderEntity, error) {
+	var modelReader model.Reader
+
+	err = r.db.WithContext(ctx).Where("id = ?", id).First(&modelReader).Error
+	if err != nil {
+		code := "[REPOSITORY] GetReaderByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := readerToEntity(modelReader)
+	return &res, nil
+}
+
+// GetReaderByEmail implements ReaderRepository. Addresses are compared
+// without case.
+func (r *readerRepository) GetReaderByEmail(ctx context.Context, email string) (*entity.ReaderEntity, error) {
+	var modelReader model.Reader
+
+	err = r.db.WithContext(ctx).Where("lower(email) = ?", strings.ToLower(email)).First(&modelReader).Error
+	if err != nil {
+		code := "[REPOSITORY] GetReaderByEmail - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := readerToEntity(modelReader)
+	return &res, nil
+}
+
+// SetReaderVerificationToken implements ReaderRepository. A new token
+// replaces the one sent before.
+func (r *readerRepository) SetReaderVerificationToken(ctx context.Context, id int64, tokenHash string) error {
+	err = r.db.WithContext(ctx).Model(&model.Reader{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"verification_token":   tokenHash,
+		"verification_sent_at": time.Now(),
+	}).Error
+	if err != nil {
+		code := "[REPOSITORY] SetReaderVerificationToken - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// VerifyReaderEmail implements ReaderRepository. The token is used up; it
+// returns gorm.ErrRecordNotFound when no token sent after sentAfter
+// matches.
+func (r *readerRepository) VerifyReaderEmail(ctx context.Context, tokenHash string, sentAfter time.Time) (*entity.ReaderEntity, error) {
+	var modelReader model.Reader
+
+	err = r.db.WithContext(ctx).Where("verification_token = ? AND verification_sent_at >= ?", tokenHash, sentAfter).First(&modelReader).Error
+	if err != nil {
+		code := "[REPOSITORY] VerifyReaderEmail - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	// Matching the token again makes a token used twice at the same time
+	// verify only once.
+	result := r.db.WithContext(ctx).Model(&model.Reader{}).
+		Where("id = ? AND verification_token = ?", modelReader.ID, tokenHash).
+		Updates(map[string]interface{}{
+			"email_verified_at":  time.Now(),
+			"verification_token": nil,
+			"updated_at":         time.Now(),
+		})
+	if result.Error != nil {
+		code := "[REPOSITORY] VerifyReaderEmail - 2"
+		log.Errorw(code, result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return r.GetReaderByID(ctx, modelReader.ID)
+}
+
+// UpdateReader implements ReaderRepository. Turning the reading history off
+// also forgets what was recorded so far.
+func (r *readerRepository) UpdateReader(ctx context.Context, req entity.ReaderEntity) (*entity.ReaderEntity, error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Reader{}).Where("id = ?", req.ID).Updates(map[string]interface{}{
+			"name":            req.Name,
+			"history_enabled": req.HistoryEnabled,
+			"updated_at":      time.Now(),
+		})
+		if result.Error != nil {
+			code := "[REPOSITORY] UpdateReader - 1"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if !req.HistoryEnabled {
+			err := tx.Where("reader_id = ?", req.ID).Delete(&model.ReaderHistory{}).Error
+			if err != nil {
+				code := "[REPOSITORY] UpdateReader - 2"
+				log.Errorw(code, err)
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetReaderByID(ctx, req.ID)
+}
+
+// UpdateReaderPassword implements ReaderRepository.
+func (r *readerRepository) UpdateReaderPassword(ctx context.Context, id int64, password string) error {
+	err = r.db.WithContext(ctx).Model(&model.Reader{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"password":   password,
+		"updated_at": time.Now(),
+	}).Error
+	if err != nil {
+		code := "[REPOSITORY] UpdateReaderPassword - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteReader implements ReaderRepository. The account is removed for
+// good. Its reactions go with it through the foreign key, so they are
+// taken off the reaction counters first.
+func (r *readerRepository) DeleteReader(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`UPDATE content_reaction_counts SET count = GREATEST(content_reaction_counts.count - reader_reactions.total, 0)
+			FROM (SELECT content_id, reaction, COUNT(*) AS total FROM content_reactions WHERE reader_id = ? GROUP BY content_id, reaction) AS reader_reactions
+			WHERE content_reaction_counts.content_id = reader_reactions.content_id AND content_reaction_counts.reaction = reader_reactions.reaction`,
+			id).Error
+		if err != nil {
+			code := "[REPOSITORY] DeleteReader - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&model.Reader{})
+		if result.Error != nil {
+			code := "[REPOSITORY] DeleteReader - 2"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+func readerToEntity(val model.Reader) entity.ReaderEntity {
+	return entity.ReaderEntity{
+		ID:                 val.ID,
+		Name:               val.Name,
+		Email:              val.Email,
+		Password:           val.Password,
+		EmailVerifiedAt:    val.EmailVerifiedAt,
+		HistoryEnabled:     val.HistoryEnabled,
+		VerificationSentAt: val.VerificationSentAt,
+		CreatedAt:          val.CreatedAt,
+	}
+}
+
+func NewReaderRepository(db *gorm.DB) ReaderRepository {
+	return &readerRepository{db: db}
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	CategoryPathPrefix = "/api/categories/"
+	ContentPathPrefix  = "/api/contents/"
+)
+
+type RedirectRepository interface {
+	GetRedirects(ctx context.Context, query entity.QueryString) ([]entity.RedirectEntity, int64, error)
+	GetAllRedirects(ctx context.Context) ([]entity.RedirectEntity, error)
+	GetRedirectByID(ctx context.Context, id int64) (*entity.RedirectEntity, error)
+	CreateRedirect(ctx context.Context, req entity.RedirectEntity) (*entity.RedirectEntity, error)
+	UpdateRedirect(ctx context.Context, req entity.RedirectEntity) (*entity.RedirectEntity, error)
+	DeleteRedirect(ctx context.Context, id int64) error
+	IncrementHitCount(ctx context.Context, id int64) error
+}
+
+type redirectRepository struct {
+	db *gorm.DB
+}
+
+// GetRedirects implements RedirectRepository.
+func (r *redirectRepository) GetRedirects(ctx context.Context, query entity.QueryString) ([]entity.RedirectEntity, int64, error) {
+	var modelRedirects []model.Redirect
+	var totalData int64
+
+	db := r.db.WithContext(ctx).Model(&model.Redirect{})
+	if query.Search != "" {
+		search := "%" + query.Search + "%"
+		db = db.Where("source_path ILIKE ? OR target_path ILIKE ?", search, search)
+	}
+	db = db.Session(&gorm.Session{})
+
+	err := db.Count(&totalData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetRedirects - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	page := query.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	err = db.Order("created_at DESC").Limit(limit).Offset((page - 1) * limit).Find(&modelRedirects).Error
+	if err != nil {
+		code := "[REPOSITORY] GetRedirects - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	res := []entity.RedirectEntity{}
+	for _, val := range modelRedirects {
+		res = append(res, redirectToEntity(val))
+	}
+
+	return res, totalData, nil
+}
+
+// GetAllRedirects implements RedirectRepository.
+func (r *redirectRepository) GetAllRedirects(ctx context.Context) ([]entity.RedirectEntity, error) {
+	var modelRedirects []model.Redirect
+
+	err := r.db.WithContext(ctx).Find(&modelRedirects).Error
+	if err != nil {
+		code := "[REPOSITORY] GetAllRedirects - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := []entity.RedirectEntity{}
+	for _, val := range modelRedirects {
+		res = append(res, redirectToEntity(val))
+	}
+
+	return res, nil
+}
+
+// GetRedirectByID implements RedirectRepository.
+func (r *redirectRepository) GetRedirectByID(ctx context.Context, id int64) (*entity.RedirectEntity, error) {
+	var modelRedirect model.Redirect
+
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&modelRedirect).Error
+	if err != nil {
+		code := "[REPOSITORY] GetRedirectByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := redirectToEntity(modelRedirect)
+	return &res, nil
+}
+
+// CreateRedirect implements RedirectRepository.
+func (r *redirectRepository) CreateRedirect(ctx context.Context, req entity.RedirectEntity) (*entity.RedirectEntity, error) {
+	modelRedirect := model.Redirect{
+		SourcePath: req.SourcePath,
+		TargetPath: req.TargetPath,
+		StatusCode: req.StatusCode,
+	}
+	if req.CreatedByID > 0 {
+		modelRedirect.CreatedByID = &req.CreatedByID
+	}
+
+	err := r.db.WithContext(ctx).Create(&modelRedirect).Error
+	if err != nil {
+		code := "[REPOSITORY] CreateRedirect - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := redirectToEntity(modelRedirect)
+	return &res, nil
+}
+
+// UpdateRedirect implements RedirectRepository.
+func (r *redirectRepository) UpdateRedirect(ctx context.Context, req entity.RedirectEntity) (*entity.RedirectEntity, error) {
+	err := r.db.WithContext(ctx).Model(&model.Redirect{}).Where("id = ?", req.ID).Updates(map[string]interface{}{
+		"source_path": req.SourcePath,
+		"target_path": req.TargetPath,
+		"status_code": req.StatusCode,
+		"updated_at":  gorm.Expr("CURRENT_TIMESTAMP"),
+	}).Error
+	if err != nil {
+		code := "[REPOSITORY] UpdateRedirect - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// DeleteRedirect implements RedirectRepository.
+func (r *redirectRepository) DeleteRedirect(ctx context.Context, id int64) error {
+	err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.Redirect{}).Error
+	if err != nil {
+		code := "[REPOSITORY] DeleteRedirect - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// IncrementHitCount implements RedirectRepository.
+func (r *redirectRepository) IncrementHitCount(ctx context.Context, id int64) error {
+	err := r.db.WithContext(ctx).Model(&model.Redirect{}).Where("id = ?", id).
+		UpdateColumn("hit_count", gorm.Expr("hit_count + 1")).Error
+	if err != nil {
+		code := "[REPOSITORY] IncrementHitCount - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// recordSlugRedirect adds a permanent redirect from the old public path to
+// the new one inside the caller's transaction. Redirects that pointed at the
+// old path are moved along so no chains build up, and a redirect whose source
+// is the new path is dropped since that path is live again.
+func recordSlugRedirect(tx *gorm.DB, sourcePath, targetPath string) error {
+	if sourcePath == targetPath {
+		return nil
+	}
+
+	err := tx.Where("source_path = ?", targetPath).Delete(&model.Redirect{}).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Model(&model.Redirect{}).Where("target_path = ?", sourcePath).Updates(map[string]interface{}{
+		"target_path": targetPath,
+		"updated_at":  gorm.Expr("CURRENT_TIMESTAMP"),
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "source_path"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"target_path": targetPath,
+			"updated_at":  gorm.Expr("CURRENT_TIMESTAMP"),
+		}),
+	}).Create(&model.Redirect{
+		SourcePath: sourcePath,
+		TargetPath: targetPath,
+		StatusCode: 301,
+	}).Error
+}
+
+func redirectToEntity(val model.Redirect) entity.RedirectEntity {
+	res := entity.RedirectEntity{
+		ID:         val.ID,
+		SourcePath: val.SourcePath,
+		TargetPath: val.TargetPath,
+		StatusCode: val.StatusCode,
+		HitCount:   val.HitCount,
+		CreatedAt:  val.CreatedAt,
+	}
+	if val.CreatedByID != nil {
+		res.CreatedByID = *val.CreatedByID
+	}
+
+	return res
+}
+
+func NewRedirectRepository(db *gorm.DB) RedirectRepository {
+	return &redirectRepository{db: db}
+}
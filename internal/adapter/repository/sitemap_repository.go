@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type SitemapRepository interface {
+	GetContentEntries(ctx context.Context, fromID, toID int64) ([]entity.SitemapEntryEntity, error)
+	GetCategoryEntries(ctx context.Context, fromID, toID int64) ([]entity.SitemapEntryEntity, error)
+	GetTagEntries(ctx context.Context) ([]entity.SitemapEntryEntity, error)
+	GetContentPageLastMods(ctx context.Context, pageSize int64) (map[int64]time.Time, error)
+	GetCategoryPageLastMods(ctx context.Context, pageSize int64) (map[int64]time.Time, error)
+	GetNewsContents(ctx context.Context, since time.Time, limit int) ([]entity.ContentEntity, error)
+}
+
+type sitemapRepository struct {
+	db *gorm.DB
+}
+
+type sitemapPageRow struct {
+	Page    int64
+	LastMod time.Time
+}
+
+// GetContentEntries implements SitemapRepository.
+func (s *sitemapRepository) GetContentEntries(ctx context.Context, fromID, toID int64) ([]entity.SitemapEntryEntity, error) {
+	var res []entity.SitemapEntryEntity
+
+	err := s.db.WithContext(ctx).Table("contents").
+		Select("id, slug, COALESCE(updated_at, published_at, created_at) AS last_mod").
+		Where("status = ? AND NOT noindex AND id >= ? AND id <= ?", entity.ContentStatusPublish, fromID, toID).
+		Order("id ASC").
+		Scan(&res).Error
+	if err != nil {
+		code := "[REPOSITORY] GetContentEntries - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetCategoryEntries implements SitemapRepository.
+func (s *sitemapRepository) GetCategoryEntries(ctx context.Context, fromID, toID int64) ([]entity.SitemapEntryEntity, error) {
+	var res []entity.SitemapEntryEntity
+
+	err := s.db.WithContext(ctx).Table("categories").
+		Select("id, slug, COALESCE(updated_at, created_at) AS last_mod").
+		Where("id >= ? AND id <= ?", fromID, toID).
+		Order("id ASC").
+		Scan(&res).Error
+	if err != nil {
+		code := "[REPOSITORY] GetCategoryEntries - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetTagEntries implements SitemapRepository.
+func (s *sitemapRepository) GetTagEntries(ctx context.Context) ([]entity.SitemapEntryEntity, error) {
+	var res []entity.SitemapEntryEntity
+
+	err := s.db.WithContext(ctx).Raw(`
+		SELECT lower(trim(tag)) AS slug, MAX(COALESCE(c.updated_at, c.created_at)) AS last_mod
+		FROM contents c, unnest(string_to_array(c.tags, ',')) AS tag
+		WHERE c.status = ? AND trim(tag) <> ''
+		GROUP BY lower(trim(tag))
+		ORDER BY slug ASC`, entity.ContentStatusPublish).
+		Scan(&res).Error
+	if err != nil {
+		code := "[REPOSITORY] GetTagEntries - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetContentPageLastMods implements SitemapRepository.
+func (s *sitemapRepository) GetContentPageLastMods(ctx context.Context, pageSize int64) (map[int64]time.Time, error) {
+	var rows []sitemapPageRow
+
+	err := s.db.WithContext(ctx).Table("contents").
+		Select("(id - 1) / ? + 1 AS page, MAX(COALESCE(updated_at, published_at, created_at)) AS last_mod", pageSize).
+		Where("status = ? AND NOT noindex", entity.ContentStatusPublish).
+		Group("page").
+		Scan(&rows).Error
+	if err != nil {
+		code := "[REPOSITORY] GetContentPageLastMods - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return pageLastMods(rows), nil
+}
+
+// GetCategoryPageLastMods implements SitemapRepository.
+func (s *sitemapRepository) GetCategoryPageLastMods(ctx context.Context, pageSize int64) (map[int64]time.Time, error) {
+	var rows []sitemapPageRow
+
+	err := s.db.WithContext(ctx).Table("categories").
+		Select("(id - 1) / ? + 1 AS page, MAX(COALESCE(updated_at, created_at)) AS last_mod", pageSize).
+		Group("page").
+		Scan(&rows).Error
+	if err != nil {
+		code := "[REPOSITORY] GetCategoryPageLastMods - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return pageLastMods(rows), nil
+}
+
+// GetNewsContents implements SitemapRepository.
+func (s *sitemapRepository) GetNewsContents(ctx context.Context, since time.Time, limit int) ([]entity.ContentEntity, error) {
+	var modelContents []model.Content
+
+	err := s.db.WithContext(ctx).
+		Where("status = ? AND NOT noindex AND published_at >= ?", entity.ContentStatusPublish, since).
+		Order("published_at DESC").
+		Limit(limit).
+		Find(&modelContents).Error
+	if err != nil {
+		code := "[REPOSITORY] GetNewsContents - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := []entity.ContentEntity{}
+	for _, val := range modelContents {
+		res = append(res, contentToEntity(val))
+	}
+
+	return res, nil
+}
+
+func pageLastMods(rows []sitemapPageRow) map[int64]time.Time {
+	res := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		res[row.Page] = row.LastMod
+	}
+
+	return res
+}
+
+func NewSitemapRepository(db *gorm.DB) SitemapRepository {
+	return &sitemapRepository{db: db}
+}
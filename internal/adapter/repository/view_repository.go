@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// viewBatchSize keeps the number of bind parameters of one insert well
+// below the Postgres limit.
+const viewBatchSize = 1000
+
+// ErrViewCountRejected means Postgres refused the values themselves, so
+// writing the same counts again can never succeed.
+var ErrViewCountRejected = errors.New("view counts were rejected")
+
+type ViewRepository interface {
+	AddViews(ctx context.Context, counts []entity.ContentViewCountEntity) error
+	GetMostRead(ctx context.Context, query entity.MostReadQueryEntity) ([]entity.MostReadEntity, error)
+	DeleteHourlyViewsBefore(ctx context.Context, before time.Time) (int64, error)
+	RecomputeTrending(ctx context.Context, query entity.TrendingQueryEntity) (int64, error)
+	GetTrending(ctx context.Context, categoryID int64, limit int) ([]entity.TrendingEntity, error)
+}
+
+type viewRepository struct {
+	db *gorm.DB
+}
+
+// AddViews implements ViewRepository. Counts are added to both the hourly
+// and the daily counters in one transaction; counts for contents that no
+// longer exist are dropped.
+func (v *viewRepository) AddViews(ctx context.Context, counts []entity.ContentViewCountEntity) error {
+	err := v.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for start := 0; start < len(counts); start += viewBatchSize {
+			batch := counts[start:min(start+viewBatchSize, len(counts))]
+
+			rows := make([]string, 0, len(batch))
+			args := make([]interface{}, 0, len(batch)*3)
+			for _, count := range batch {
+				rows = append(rows, "(?::INT, ?::TIMESTAMP, ?::BIGINT)")
+				args = append(args, count.ContentID, count.Hour.UTC(), count.Views)
+			}
+			values := strings.Join(rows, ", ")
+
+			err := tx.Exec(`INSERT INTO content_views_hourly (content_id, hour, views)
+				SELECT v.content_id, v.hour, v.views FROM (VALUES `+values+`) AS v(content_id, hour, views)
+				JOIN contents ON contents.id = v.content_id
+				ON CONFLICT (content_id, hour) DO UPDATE SET views = content_views_hourly.views + EXCLUDED.views`, args...).Error
+			if err != nil {
+				code := "[REPOSITORY] AddViews - 1"
+				log.Errorw(code, err)
+				return err
+			}
+
+			err = tx.Exec(`INSERT INTO content_views_daily (content_id, day, views)
+				SELECT v.content_id, v.hour::DATE, SUM(v.views) FROM (VALUES `+values+`) AS v(content_id, hour, views)
+				JOIN contents ON contents.id = v.content_id
+				GROUP BY v.content_id, v.hour::DATE
+				ON CONFLICT (content_id, day) DO UPDATE SET views = content_views_daily.views + EXCLUDED.views`, args...).Error
+			if err != nil {
+				code := "[REPOSITORY] AddViews - 2"
+				log.Errorw(code, err)
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	// Data exceptions and integrity violations, such as an id out of the
+	// INT range, come from the values rather than from the database.
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")) {
+		return fmt.Errorf("%w: %w", ErrViewCountRejected, err)
+	}
+
+	return err
+}
+
+// GetMostRead implements ViewRepository.
+func (v *viewRepository) GetMostRead(ctx context.Context, query entity.MostReadQueryEntity) ([]entity.MostReadEntity, error) {
+	var ranked []struct {
+		ContentID int64
+		Views     int64
+	}
+
+	db := v.db.WithContext(ctx)
+	if query.Daily {
+		db = db.Table("content_views_daily AS cv").Where("cv.day >= ?::DATE", query.Since.UTC())
+	} else {
+		db = db.Table("content_views_hourly AS cv").Where("cv.hour >= ?", query.Since.UTC())
+	}
+	db = db.Select("cv.content_id, SUM(cv.views) AS views").
+		Joins("JOIN contents ON contents.id = cv.content_id").
+		Where("contents.status = ?", entity.ContentStatusPublish)
+	if query.CategoryID > 0 {
+		db = db.Where("contents.category_id = ?", query.CategoryID)
+	}
+
+	err := db.Group("cv.content_id").
+		Order("SUM(cv.views) DESC, cv.content_id DESC").
+		Limit(query.Limit).
+		Scan(&ranked).Error
+	if err != nil {
+		code := "[REPOSITORY] GetMostRead - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(ranked))
+	for _, val := range ranked {
+		ids = append(ids, val.ContentID)
+	}
+
+	contents, err := v.contentsByID(ctx, ids)
+	if err != nil {
+		code := "[REPOSITORY] GetMostRead - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := []entity.MostReadEntity{}
+	for _, val := range ranked {
+		content, ok := contents[val.ContentID]
+		if !ok {
+			continue
+		}
+
+		res = append(res, entity.MostReadEntity{Content: content, Views: val.Views})
+	}
+
+	return res, nil
+}
+
+// DeleteHourlyViewsBefore implements ViewRepository. The daily counters are
+// kept.
+func (v *viewRepository) DeleteHourlyViewsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := v.db.WithContext(ctx).Where("hour < ?", before.UTC()).Delete(&model.ContentViewHourly{})
+	if result.Error != nil {
+		code := "[REPOSITORY] DeleteHourlyViewsBefore - 1"
+		log.Errorw(code, result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// RecomputeTrending implements ViewRepository. The ranking is replaced in
+// one transaction, so readers see either the old or the new one. A story
+// scores the views of the window, each decayed exponentially by its age,
+// divided by (hours since publication + 2) ^ gravity.
+func (v *viewRepository) RecomputeTrending(ctx context.Context, query entity.TrendingQueryEntity) (int64, error) {
+	var inserted int64
+
+	err := v.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("1 = 1").Delete(&model.TrendingContent{}).Error
+		if err != nil {
+			code := "[REPOSITORY] RecomputeTrending - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		result := tx.Exec(`INSERT INTO trending_contents (content_id, category_id, score, views, computed_at)
+			SELECT contents.id, contents.category_id,
+				SUM(cv.views * EXP(-EXTRACT(EPOCH FROM ((NOW() AT TIME ZONE 'UTC') - cv.hour)) / ?))
+					/ POWER(GREATEST(EXTRACT(EPOCH FROM (NOW() - contents.published_at)) / 3600, 0) + 2, ?),
+				SUM(cv.views), CURRENT_TIMESTAMP
+			FROM content_views_hourly AS cv
+			JOIN contents ON contents.id = cv.content_id
+			WHERE cv.hour >= (NOW() AT TIME ZONE 'UTC') - ? * INTERVAL '1 second'
+				AND contents.status = ? AND contents.published_at IS NOT NULL
+			GROUP BY contents.id`,
+			query.Decay.Seconds(), query.Gravity, query.Window.Seconds(), entity.ContentStatusPublish)
+		if result.Error != nil {
+			code := "[REPOSITORY] RecomputeTrending - 2"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+
+		inserted = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return inserted, nil
+}
+
+// GetTrending implements ViewRepository. Stories unpublished since the last
+// recompute are left out.
+func (v *viewRepository) GetTrending(ctx context.Context, categoryID int64, limit int) ([]entity.TrendingEntity, error) {
+	var ranked []model.TrendingContent
+
+	db := v.db.WithContext(ctx).
+		Select("trending_contents.*").
+		Joins("JOIN contents ON contents.id = trending_contents.content_id").
+		Where("contents.status = ?", entity.ContentStatusPublish)
+	if categoryID > 0 {
+		db = db.Where("trending_contents.category_id = ?", categoryID)
+	}
+
+	err := db.Order("trending_contents.score DESC, trending_contents.content_id DESC").
+		Limit(limit).
+		Find(&ranked).Error
+	if err != nil {
+		code := "[REPOSITORY] GetTrending - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(ranked))
+	for _, val := range ranked {
+		ids = append(ids, val.ContentID)
+	}
+
+	contents, err := v.contentsByID(ctx, ids)
+	if err != nil {
+		code := "[REPOSITORY] GetTrending - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := []entity.TrendingEntity{}
+	for _, val := range ranked {
+		content, ok := contents[val.ContentID]
+		if !ok {
+			continue
+		}
+
+		res = append(res, entity.TrendingEntity{Content: content, Score: val.Score, Views: val.Views})
+	}
+
+	return res, nil
+}
+
+// contentsByID loads the contents of a ranking in one query.
+func (v *viewRepository) contentsByID(ctx context.Context, ids []int64) (map[int64]entity.ContentEntity, error) {
+	res := map[int64]entity.ContentEntity{}
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	var modelContents []model.Content
+	err := v.db.WithContext(ctx).Where("id IN ?", ids).Preload("User").Preload("Category").Preload("Media").Find(&modelContents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, val := range modelContents {
+		res[val.ID] = contentToEntity(val)
+	}
+
+	return res, nil
+}
+
+func NewViewRepository(db *gorm.DB) ViewRepository {
+	return &viewRepository{db: db}
+}
@@ -0,0 +1,397 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"news-app/internal/core/domain/entity"
+	"news-app/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	GetWebhooks(ctx context.Context, query entity.QueryString) ([]entity.WebhookEntity, int64, error)
+	GetWebhookByID(ctx context.Context, id int64) (*entity.WebhookEntity, error)
+	CreateWebhook(ctx context.Context, req entity.WebhookEntity) (*entity.WebhookEntity, error)
+	UpdateWebhook(ctx context.Context, req entity.WebhookEntity) (*entity.WebhookEntity, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+
+	EnqueueDeliveries(ctx context.Context, eventID, eventType, payload string) (int64, error)
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.WebhookDeliveryEntity, error)
+	RecordDeliveryAttempt(ctx context.Context, attempt entity.WebhookAttemptEntity, status string, nextAttemptAt *time.Time) error
+	GetDeliveries(ctx context.Context, webhookID int64, query entity.QueryString) ([]entity.WebhookDeliveryEntity, int64, error)
+	GetDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDeliveryEntity, error)
+	RedeliverDelivery(ctx context.Context, id int64, now time.Time) error
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// GetWebhooks implements WebhookRepository.
+func (w *webhookRepository) GetWebhooks(ctx context.Context, query entity.QueryString) ([]entity.WebhookEntity, int64, error) {
+	var modelWebhooks []model.Webhook
+	var totalData int64
+
+	db := w.db.WithContext(ctx).Model(&model.Webhook{})
+	if query.Search != "" {
+		db = db.Where("url ILIKE ?", "%"+query.Search+"%")
+	}
+	db = db.Session(&gorm.Session{})
+
+	err := db.Count(&totalData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetWebhooks - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	err = db.Order("id DESC").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&modelWebhooks).Error
+	if err != nil {
+		code := "[REPOSITORY] GetWebhooks - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	res := []entity.WebhookEntity{}
+	for _, val := range modelWebhooks {
+		res = append(res, webhookToEntity(val))
+	}
+
+	return res, totalData, nil
+}
+
+// GetWebhookByID implements WebhookRepository.
+func (w *webhookRepository) GetWebhookByID(ctx context.Context, id int64) (*entity.WebhookEntity, error) {
+	var modelWebhook model.Webhook
+
+	err := w.db.WithContext(ctx).Where("id = ?", id).First(&modelWebhook).Error
+	if err != nil {
+		code := "[REPOSITORY] GetWebhookByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := webhookToEntity(modelWebhook)
+	return &res, nil
+}
+
+// CreateWebhook implements WebhookRepository.
+func (w *webhookRepository) CreateWebhook(ctx context.Context, req entity.WebhookEntity) (*entity.WebhookEntity, error) {
+	modelWebhook := model.Webhook{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: strings.Join(req.EventTypes, ","),
+		Active:     req.Active,
+		CreatedAt:  time.Now(),
+	}
+	if req.CreatedByID > 0 {
+		modelWebhook.CreatedByID = &req.CreatedByID
+	}
+
+	err := w.db.WithContext(ctx).Create(&modelWebhook).Error
+	if err != nil {
+		code := "[REPOSITORY] CreateWebhook - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := webhookToEntity(modelWebhook)
+	return &res, nil
+}
+
+// UpdateWebhook implements WebhookRepository. The secret is kept unless a
+// new one is given.
+func (w *webhookRepository) UpdateWebhook(ctx context.Context, req entity.WebhookEntity) (*entity.WebhookEntity, error) {
+	updates := map[string]interface{}{
+		"url":         req.URL,
+		"event_types": strings.Join(req.EventTypes, ","),
+		"active":      req.Active,
+		"updated_at":  time.Now(),
+	}
+	if req.Secret != "" {
+		updates["secret"] = req.Secret
+	}
+
+	result := w.db.WithContext(ctx).Model(&model.Webhook{}).Where("id = ?", req.ID).Updates(updates)
+	if result.Error != nil {
+		code := "[REPOSITORY] UpdateWebhook - 1"
+		log.Errorw(code, result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return w.GetWebhookByID(ctx, req.ID)
+}
+
+// DeleteWebhook implements WebhookRepository. Its deliveries go with it.
+func (w *webhookRepository) DeleteWebhook(ctx context.Context, id int64) error {
+	result := w.db.WithContext(ctx).Where("id = ?", id).Delete(&model.Webhook{})
+	if result.Error != nil {
+		code := "[REPOSITORY] DeleteWebhook - 1"
+		log.Errorw(code, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// EnqueueDeliveries implements WebhookRepository. Every active webhook
+// subscribed to the event gets a delivery, due right away, unless it already
+// has one for eventID.
+func (w *webhookRepository) EnqueueDeliveries(ctx context.Context, eventID, eventType, payload string) (int64, error) {
+	now := time.Now()
+
+	result := w.db.WithContext(ctx).Exec(`INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+		SELECT id, ?, ?, ?, ?, ?, ? FROM webhooks
+		WHERE active AND ? = ANY(string_to_array(event_types, ','))
+		ON CONFLICT (webhook_id, event_id) DO NOTHING`,
+		eventID, eventType, payload, entity.WebhookDeliveryPending, now, now, eventType)
+	if result.Error != nil {
+		code := "[REPOSITORY] EnqueueDeliveries - 1"
+		log.Errorw(code, result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// ClaimDueDeliveries implements WebhookRepository. The claimed deliveries
+// are not due again before leaseUntil, so concurrent workers skip them and
+// a worker that dies while sending only delays them. Deliveries of
+// inactive webhooks wait until the webhook is active again.
+func (w *webhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.WebhookDeliveryEntity, error) {
+	var ids []int64
+
+	err := w.db.WithContext(ctx).Raw(`UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT webhook_deliveries.id FROM webhook_deliveries
+			JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+			WHERE webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ? AND webhooks.active
+			ORDER BY webhook_deliveries.next_attempt_at
+			LIMIT ?
+			FOR UPDATE OF webhook_deliveries SKIP LOCKED
+		)
+		RETURNING id`,
+		leaseUntil, entity.WebhookDeliveryPending, now, limit).Scan(&ids).Error
+	if err != nil {
+		code := "[REPOSITORY] ClaimDueDeliveries - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := []entity.WebhookDeliveryEntity{}
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	var modelDeliveries []model.WebhookDelivery
+	err = w.db.WithContext(ctx).Preload("Webhook").Where("id IN ?", ids).Order("id").Find(&modelDeliveries).Error
+	if err != nil {
+		code := "[REPOSITORY] ClaimDueDeliveries - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	for _, val := range modelDeliveries {
+		res = append(res, webhookDeliveryToEntity(val))
+	}
+
+	return res, nil
+}
+
+// RecordDeliveryAttempt implements WebhookRepository. The attempt is logged
+// and the delivery moved on in one transaction.
+func (w *webhookRepository) RecordDeliveryAttempt(ctx context.Context, attempt entity.WebhookAttemptEntity, status string, nextAttemptAt *time.Time) error {
+	modelAttempt := model.WebhookDeliveryAttempt{
+		DeliveryID:   attempt.DeliveryID,
+		ResponseBody: attempt.ResponseBody,
+		Error:        attempt.Error,
+		DurationMs:   attempt.Duration.Milliseconds(),
+		CreatedAt:    attempt.CreatedAt,
+	}
+	if attempt.ResponseCode > 0 {
+		modelAttempt.ResponseCode = &attempt.ResponseCode
+	}
+
+	return w.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&modelAttempt).Error
+		if err != nil {
+			code := "[REPOSITORY] RecordDeliveryAttempt - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Model(&model.WebhookDelivery{}).Where("id = ?", attempt.DeliveryID).Updates(map[string]interface{}{
+			"status":          status,
+			"attempts":        gorm.Expr("attempts + 1"),
+			"response_code":   modelAttempt.ResponseCode,
+			"next_attempt_at": nextAttemptAt,
+			"last_attempt_at": attempt.CreatedAt,
+			"updated_at":      time.Now(),
+		}).Error
+		if err != nil {
+			code := "[REPOSITORY] RecordDeliveryAttempt - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// GetDeliveries implements WebhookRepository. Newest deliveries come first;
+// the payloads and attempts are left out.
+func (w *webhookRepository) GetDeliveries(ctx context.Context, webhookID int64, query entity.QueryString) ([]entity.WebhookDeliveryEntity, int64, error) {
+	var modelDeliveries []model.WebhookDelivery
+	var totalData int64
+
+	db := w.db.WithContext(ctx).Model(&model.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	db = db.Session(&gorm.Session{})
+
+	err := db.Count(&totalData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetDeliveries - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	err = db.Omit("payload").
+		Order("id DESC").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&modelDeliveries).Error
+	if err != nil {
+		code := "[REPOSITORY] GetDeliveries - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	res := []entity.WebhookDeliveryEntity{}
+	for _, val := range modelDeliveries {
+		res = append(res, webhookDeliveryToEntity(val))
+	}
+
+	return res, totalData, nil
+}
+
+// GetDeliveryByID implements WebhookRepository. The delivery comes with its
+// payload and every attempt, oldest first.
+func (w *webhookRepository) GetDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDeliveryEntity, error) {
+	var modelDelivery model.WebhookDelivery
+
+	err := w.db.WithContext(ctx).
+		Preload("AttemptLog", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		}).
+		Where("id = ?", id).
+		First(&modelDelivery).Error
+	if err != nil {
+		code := "[REPOSITORY] GetDeliveryByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := webhookDeliveryToEntity(modelDelivery)
+	return &res, nil
+}
+
+// RedeliverDelivery implements WebhookRepository. The delivery is queued
+// again with a fresh set of retries; its attempts stay in the log.
+func (w *webhookRepository) RedeliverDelivery(ctx context.Context, id int64, now time.Time) error {
+	result := w.db.WithContext(ctx).Model(&model.WebhookDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          entity.WebhookDeliveryPending,
+		"attempts":        0,
+		"next_attempt_at": now,
+		"updated_at":      now,
+	})
+	if result.Error != nil {
+		code := "[REPOSITORY] RedeliverDelivery - 1"
+		log.Errorw(code, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func webhookToEntity(val model.Webhook) entity.WebhookEntity {
+	res := entity.WebhookEntity{
+		ID:         val.ID,
+		URL:        val.URL,
+		Secret:     val.Secret,
+		EventTypes: []string{},
+		Active:     val.Active,
+		CreatedAt:  val.CreatedAt,
+		UpdatedAt:  val.UpdatedAt,
+	}
+	if val.EventTypes != "" {
+		res.EventTypes = strings.Split(val.EventTypes, ",")
+	}
+	if val.CreatedByID != nil {
+		res.CreatedByID = *val.CreatedByID
+	}
+
+	return res
+}
+
+func webhookDeliveryToEntity(val model.WebhookDelivery) entity.WebhookDeliveryEntity {
+	res := entity.WebhookDeliveryEntity{
+		ID:            val.ID,
+		WebhookID:     val.WebhookID,
+		EventID:       val.EventID,
+		EventType:     val.EventType,
+		Payload:       val.Payload,
+		Status:        val.Status,
+		Attempts:      val.Attempts,
+		NextAttemptAt: val.NextAttemptAt,
+		LastAttemptAt: val.LastAttemptAt,
+		AttemptLog:    []entity.WebhookAttemptEntity{},
+		CreatedAt:     val.CreatedAt,
+		UpdatedAt:     val.UpdatedAt,
+	}
+	if val.Webhook.ID > 0 {
+		webhook := webhookToEntity(val.Webhook)
+		res.Webhook = &webhook
+	}
+	if val.ResponseCode != nil {
+		res.ResponseCode = *val.ResponseCode
+	}
+	for _, attempt := range val.AttemptLog {
+		logged := entity.WebhookAttemptEntity{
+			ID:           attempt.ID,
+			DeliveryID:   attempt.DeliveryID,
+			ResponseBody: attempt.ResponseBody,
+			Error:        attempt.Error,
+			Duration:     time.Duration(attempt.DurationMs) * time.Millisecond,
+			CreatedAt:    attempt.CreatedAt,
+		}
+		if attempt.ResponseCode != nil {
+			logged.ResponseCode = *attempt.ResponseCode
+		}
+
+		res.AttemptLog = append(res.AttemptLog, logged)
+	}
+
+	return res
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
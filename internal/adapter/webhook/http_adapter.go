@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"news-app/config"
+	"news-app/internal/core/domain/entity"
+	webhookLib "news-app/lib/webhook"
+)
+
+const (
+	// sendTimeout bounds one delivery attempt; slow receivers are retried
+	// like failing ones.
+	sendTimeout = 10 * time.Second
+
+	// maxResponseBody is how much of a response is kept in the delivery
+	// log.
+	maxResponseBody = 2048
+)
+
+var ErrForbiddenAddress = errors.New("webhook receiver resolves to a private or local address")
+
+// sharedAddressSpace is the carrier-grade NAT range, which netip does not
+// count as private.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+type WebhookAdapter interface {
+	Send(ctx context.Context, req entity.WebhookRequestEntity) (*entity.WebhookResponseEntity, error)
+}
+
+type httpAdapter struct {
+	client    *http.Client
+	userAgent string
+}
+
+// Send implements WebhookAdapter. The payload is posted as JSON and signed
+// with the secret of the webhook. Any response is returned, whatever its
+// status; an error means there was none.
+func (h *httpAdapter) Send(ctx context.Context, req entity.WebhookRequestEntity) (*entity.WebhookResponseEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Now().Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", h.userAgent)
+	httpReq.Header.Set(webhookLib.HeaderDelivery, strconv.FormatInt(req.DeliveryID, 10))
+	httpReq.Header.Set(webhookLib.HeaderEvent, req.EventType)
+	httpReq.Header.Set(webhookLib.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(webhookLib.HeaderSignature, webhookLib.Sign(req.Secret, timestamp, req.Payload))
+
+	resp, err := h.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	// The rest is drained so the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	// Postgres text takes neither invalid UTF-8 nor NUL bytes.
+	logged := strings.ToValidUTF8(strings.ReplaceAll(string(body), "\x00", ""), "")
+
+	return &entity.WebhookResponseEntity{StatusCode: resp.StatusCode, Body: logged}, nil
+}
+
+// rejectInternal is the dialer control of the adapter. It runs on the
+// resolved address of every connection, so receivers cannot reach the
+// internal network, the cloud metadata endpoint or the app itself, even by
+// pointing a public name at a private address after they were saved.
+func rejectInternal(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr := addrPort.Addr().Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+func newHTTPAdapter(userAgent string, control func(network, address string, c syscall.RawConn) error) *httpAdapter {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Through a proxy only the proxy's address would be checked.
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   sendTimeout,
+		KeepAlive: 30 * time.Second,
+		Control:   control,
+	}).DialContext
+
+	return &httpAdapter{
+		client: &http.Client{
+			Transport: transport,
+			// Redirects are not followed: a receiver that moved has to be
+			// updated, not silently sent the payload elsewhere.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		userAgent: userAgent,
+	}
+}
+
+func NewWebhookAdapter(cfg *config.Config) WebhookAdapter {
+	userAgent := "news-app-webhooks"
+	if cfg.App.SiteName != "" {
+		userAgent = cfg.App.SiteName + " Webhooks"
+	}
+
+	return newHTTPAdapter(userAgent, rejectInternal)
+}
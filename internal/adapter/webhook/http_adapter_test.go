@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"news-app/config"
+	"news-app/internal/core/domain/entity"
+	webhookLib "news-app/lib/webhook"
+)
+
+func TestSend(t *testing.T) {
+	payload := []byte(`{"event":"content.published","id":1}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(webhookLib.HeaderTimestamp), 10, 64)
+		if err != nil {
+			t.Errorf("timestamp header: %v", err)
+		}
+
+		switch {
+		case r.Method != http.MethodPost:
+			t.Errorf("method = %s, want POST", r.Method)
+		case string(body) != string(payload):
+			t.Errorf("body = %s, want %s", body, payload)
+		case r.Header.Get(webhookLib.HeaderDelivery) != "42":
+			t.Errorf("delivery header = %q, want 42", r.Header.Get(webhookLib.HeaderDelivery))
+		case r.Header.Get(webhookLib.HeaderEvent) != "content.published":
+			t.Errorf("event header = %q, want content.published", r.Header.Get(webhookLib.HeaderEvent))
+		case !webhookLib.Verify("secret", r.Header.Get(webhookLib.HeaderSignature), timestamp, body):
+			t.Error("signature does not verify")
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("ok\x00"))
+	}))
+	defer server.Close()
+
+	// The test server listens on loopback, which the default control
+	// refuses.
+	adapter := newHTTPAdapter("test", nil)
+	res, err := adapter.Send(context.Background(), entity.WebhookRequestEntity{
+		URL:        server.URL,
+		Secret:     "secret",
+		DeliveryID: 42,
+		EventType:  "content.published",
+		Payload:    payload,
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if res.StatusCode != http.StatusAccepted {
+		t.Errorf("StatusCode = %d, want %d", res.StatusCode, http.StatusAccepted)
+	}
+	if res.Body != "ok" {
+		t.Errorf("Body = %q, want %q", res.Body, "ok")
+	}
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/moved" {
+			t.Error("redirect was followed")
+		}
+		http.Redirect(w, r, "/moved", http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	res, err := newHTTPAdapter("test", nil).Send(context.Background(), entity.WebhookRequestEntity{URL: server.URL})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if res.StatusCode != http.StatusTemporaryRedirect {
+		t.Errorf("StatusCode = %d, want %d", res.StatusCode, http.StatusTemporaryRedirect)
+	}
+}
+
+func TestSendRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached a loopback receiver")
+	}))
+	defer server.Close()
+
+	adapter := NewWebhookAdapter(&config.Config{})
+	_, err := adapter.Send(context.Background(), entity.WebhookRequestEntity{URL: server.URL})
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("Send() error = %v, want %v", err, ErrForbiddenAddress)
+	}
+}
+
+func TestRejectInternal(t *testing.T) {
+	tests := []struct {
+		address string
+		allowed bool
+	}{
+		{address: "93.184.216.34:443", allowed: true},
+		{address: "[2606:2800:220:1::1]:443", allowed: true},
+		{address: "127.0.0.1:80"},
+		{address: "[::1]:80"},
+		{address: "10.0.0.5:80"},
+		{address: "172.16.0.1:80"},
+		{address: "192.168.1.1:80"},
+		{address: "169.254.169.254:80"},
+		{address: "100.64.0.1:80"},
+		{address: "0.0.0.0:80"},
+		{address: "[fd00::1]:80"},
+		{address: "[fe80::1]:80"},
+		{address: "[::ffff:127.0.0.1]:80"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := rejectInternal("tcp", tt.address, nil)
+			if tt.allowed && err != nil {
+				t.Errorf("rejectInternal() error = %v, want nil", err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrForbiddenAddress) {
+				t.Errorf("rejectInternal() error = %v, want %v", err, ErrForbiddenAddress)
+			}
+		})
+	}
+}
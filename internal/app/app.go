@@ -8,16 +8,20 @@ import (
 	"time"
 
 	"news-app/config"
+	"news-app/internal/adapter/cloudflare"
 	"news-app/internal/adapter/handler"
+	"news-app/internal/adapter/mailer"
 	"news-app/internal/adapter/repository"
+	"news-app/internal/adapter/webhook"
+	"news-app/internal/core/domain/entity"
 	"news-app/internal/core/service"
 	"news-app/lib/auth"
 	"news-app/lib/middleware"
 	"news-app/lib/pagination"
 
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/etag"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/rs/zerolog/log"
@@ -32,8 +36,9 @@ func RunServer() {
 	}
 
 	// cloudflareR2
-	cdfR2 := cfg.LoadAwsConfig()
-	_ = s3.NewFromConfig(cdfR2)
+	r2Adapter := cloudflare.NewCloudflareR2Adapter(cfg.LoadR2Client(), cfg)
+	mailerAdapter := mailer.NewMailerAdapter(cfg)
+	webhookAdapter := webhook.NewWebhookAdapter(cfg)
 	_ = auth.NewJwt(cfg)
 	middlewareAuth := middleware.NewMiddleware(cfg)
 	_ = pagination.NewPagination()
@@ -41,16 +46,72 @@ func RunServer() {
 	// repository
 	authRepo := repository.NewAuthRepository(db.DB)
 	categoryRepo := repository.NewCategoryRepository(db.DB)
+	contentRepo := repository.NewContentRepository(db.DB)
+	redirectRepo := repository.NewRedirectRepository(db.DB)
+	sitemapRepo := repository.NewSitemapRepository(db.DB)
+	mediaRepo := repository.NewMediaRepository(db.DB)
+	viewRepo := repository.NewViewRepository(db.DB)
+	analyticsRepo := repository.NewAnalyticsRepository(db.DB)
+	commentRepo := repository.NewCommentRepository(db.DB)
+	readerRepo := repository.NewReaderRepository(db.DB)
+	libraryRepo := repository.NewLibraryRepository(db.DB)
+	reactionRepo := repository.NewReactionRepository(db.DB)
+	liveBlogRepo := repository.NewLiveBlogRepository(db.DB)
+	notificationRepo := repository.NewNotificationRepository(db.DB)
+	webhookRepo := repository.NewWebhookRepository(db.DB)
+	outboxRepo := repository.NewOutboxRepository(db.DB)
+	transactor := repository.NewTransactor(db.DB)
 
 	// service
 	authService := service.NewAuthService(authRepo, cfg, auth.NewJwt(cfg))
-	categoryService := service.NewCategoryService(categoryRepo)
+	sitemapService := service.NewSitemapService(sitemapRepo, cfg)
+	imageService := service.NewImageService(r2Adapter, cfg)
+	outboxService := service.NewOutboxService(outboxRepo)
+	redirectService := service.NewRedirectService(redirectRepo)
+	categoryService := service.NewCategoryService(categoryRepo, transactor, sitemapService, redirectService, outboxService)
+	notificationService := service.NewNotificationService(notificationRepo)
+	webhookService := service.NewWebhookService(webhookRepo, webhookAdapter, cfg)
+	contentService := service.NewContentService(contentRepo, mediaRepo, transactor, sitemapService, redirectService, imageService, notificationService, outboxService)
+	mediaService := service.NewMediaService(mediaRepo, r2Adapter, imageService)
+	feedService := service.NewFeedService(contentRepo, categoryRepo, cfg)
+	seoService := service.NewSeoService(contentRepo, cfg)
+	viewService := service.NewViewService(viewRepo, imageService)
+	trendingService := service.NewTrendingService(viewRepo, imageService)
+	analyticsService := service.NewAnalyticsService(analyticsRepo)
+	commentService := service.NewCommentService(commentRepo, contentRepo, notificationService, cfg)
+	readerService := service.NewReaderService(readerRepo, mailerAdapter, cfg, auth.NewJwt(cfg))
+	libraryService := service.NewLibraryService(libraryRepo, readerRepo, contentRepo, imageService)
+	reactionService := service.NewReactionService(reactionRepo, contentRepo)
+	liveBlogService := service.NewLiveBlogService(liveBlogRepo, contentRepo)
 
 	// handler
 	authHandler := handler.NewAuthHandler(authService)
 	categoryHandler := handler.NewCategoryHandler(categoryService)
+	contentHandler := handler.NewContentHandler(contentService)
+	redirectHandler := handler.NewRedirectHandler(redirectService)
+	feedHandler := handler.NewFeedHandler(feedService)
+	sitemapHandler := handler.NewSitemapHandler(sitemapService)
+	mediaHandler := handler.NewMediaHandler(mediaService)
+	seoHandler := handler.NewSeoHandler(seoService)
+	viewHandler := handler.NewViewHandler(viewService, trendingService)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService)
+	commentHandler := handler.NewCommentHandler(commentService)
+	readerHandler := handler.NewReaderHandler(readerService)
+	libraryHandler := handler.NewLibraryHandler(libraryService)
+	reactionHandler := handler.NewReactionHandler(reactionService)
+	liveBlogHandler := handler.NewLiveBlogHandler(liveBlogService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		BodyLimit: 12 * 1024 * 1024,
+		// c.IP() reads ProxyHeader only on requests from a trusted proxy, so
+		// clients cannot choose the IP that views and comments are keyed on.
+		ProxyHeader:             cfg.App.ProxyHeader,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          cfg.App.TrustedProxies,
+		EnableIPValidation:      true,
+	})
 	app.Use(cors.New())
 	app.Use(recover.New())
 	app.Use(logger.New(
@@ -58,9 +119,58 @@ func RunServer() {
 			Format: "[${time}] ${ip} ${status} - ${latency} ${method} ${path}\n",
 		},
 	))
+	app.Use(redirectHandler.Redirect)
+
+	// sitemap
+	app.Get("/sitemap.xml", sitemapHandler.GetIndex)
+	app.Get("/sitemap-news.xml", sitemapHandler.GetNewsSitemap)
+	app.Get("/sitemaps/contents-:page.xml", sitemapHandler.GetContentSitemap)
+	app.Get("/sitemaps/categories-:page.xml", sitemapHandler.GetCategorySitemap)
+	app.Get("/sitemaps/tags-:page.xml", sitemapHandler.GetTagSitemap)
 
 	api := app.Group("/api")
 	api.Post("/auth/login", authHandler.Login)
+	api.Get("/categories/:slug", categoryHandler.GetCategoryBySlug)
+	api.Get("/contents", contentHandler.GetPublishedContents)
+	api.Get("/contents/:slug", contentHandler.GetContentBySlug)
+	api.Get("/contents/:slug/seo", seoHandler.GetContentSeo)
+	api.Get("/contents/:slug/related", contentHandler.GetRelatedContents)
+	api.Post("/contents/:contentId/views", viewHandler.RecordView)
+	api.Get("/contents/:slug/comments", commentHandler.GetContentComments)
+	api.Post("/contents/:slug/comments", commentHandler.CreateComment)
+	api.Get("/contents/:contentId/reactions", middlewareAuth.OptionalReaderToken(), reactionHandler.GetReactions)
+	api.Put("/contents/:contentId/reactions", middlewareAuth.OptionalReaderToken(), reactionHandler.SetReaction)
+	api.Delete("/contents/:contentId/reactions", middlewareAuth.OptionalReaderToken(), reactionHandler.DeleteReaction)
+	api.Get("/contents/:slug/live", liveBlogHandler.GetLiveBlog)
+	api.Get("/contents/:slug/live/stream", liveBlogHandler.StreamLiveBlog)
+	api.Get("/most-read", viewHandler.GetMostRead)
+	api.Get("/trending", viewHandler.GetTrending)
+
+	// reader
+	api.Post("/readers/register", readerHandler.Register)
+	api.Post("/readers/verify", readerHandler.VerifyEmail)
+	api.Post("/readers/verify/resend", readerHandler.ResendVerification)
+	api.Post("/readers/login", readerHandler.Login)
+
+	meApp := api.Group("/me")
+	meApp.Use(middlewareAuth.CheckReaderToken())
+	meApp.Get("/", readerHandler.GetProfile)
+	meApp.Put("/", readerHandler.UpdateProfile)
+	meApp.Put("/password", readerHandler.UpdatePassword)
+	meApp.Delete("/", readerHandler.DeleteAccount)
+	meApp.Get("/bookmarks", libraryHandler.GetBookmarks)
+	meApp.Put("/bookmarks/:contentId", libraryHandler.AddBookmark)
+	meApp.Delete("/bookmarks/:contentId", libraryHandler.DeleteBookmark)
+	meApp.Get("/history", libraryHandler.GetReadingHistory)
+	meApp.Delete("/history", libraryHandler.ClearReadingHistory)
+	meApp.Put("/history/:contentId", libraryHandler.SaveReadingHistory)
+	meApp.Delete("/history/:contentId", libraryHandler.DeleteReadingHistory)
+
+	// feed
+	feedApp := api.Group("/feeds", etag.New())
+	feedApp.Get("/:format", feedHandler.GetSiteFeed)
+	feedApp.Get("/categories/:slug/:format", feedHandler.GetCategoryFeed)
+	feedApp.Get("/tags/:tag/:format", feedHandler.GetTagFeed)
 
 	adminApp := api.Group("/admin")
 	adminApp.Use(middlewareAuth.CheckToken())
@@ -72,6 +182,120 @@ func RunServer() {
 	categoryApp.Get("/:categoryId", categoryHandler.GetCategoryByID)
 	categoryApp.Put("/:categoryId", categoryHandler.UpdateCategory)
 	categoryApp.Delete("/:categoryId", categoryHandler.DeleteCategory)
+	categoryApp.Post("/:categoryId/merge", categoryHandler.MergeCategories)
+
+	// content
+	contentApp := adminApp.Group("/contents")
+	contentApp.Get("/", contentHandler.GetContents)
+	contentApp.Post("/", contentHandler.CreateContent)
+	contentApp.Get("/:contentId", contentHandler.GetContentByID)
+	contentApp.Put("/:contentId", contentHandler.UpdateContent)
+	contentApp.Delete("/:contentId", contentHandler.DeleteContent)
+	contentApp.Get("/:contentId/live-entries", liveBlogHandler.GetEntries)
+	contentApp.Post("/:contentId/live-entries", liveBlogHandler.CreateEntry)
+	contentApp.Put("/:contentId/live-entries/:entryId", liveBlogHandler.UpdateEntry)
+	contentApp.Delete("/:contentId/live-entries/:entryId", liveBlogHandler.DeleteEntry)
+
+	// media
+	mediaApp := adminApp.Group("/media")
+	mediaApp.Get("/", mediaHandler.GetMedia)
+	mediaApp.Post("/", mediaHandler.UploadMedia)
+	mediaApp.Get("/:mediaId", mediaHandler.GetMediaByID)
+	mediaApp.Put("/:mediaId", mediaHandler.UpdateMedia)
+	mediaApp.Delete("/:mediaId", mediaHandler.DeleteMedia)
+	mediaApp.Post("/uploads", mediaHandler.CreateUpload)
+	mediaApp.Post("/uploads/:uploadId/complete", mediaHandler.CompleteUpload)
+
+	// redirect
+	redirectApp := adminApp.Group("/redirects")
+	redirectApp.Get("/", redirectHandler.GetRedirects)
+	redirectApp.Post("/", redirectHandler.CreateRedirect)
+	redirectApp.Get("/:redirectId", redirectHandler.GetRedirectByID)
+	redirectApp.Put("/:redirectId", redirectHandler.UpdateRedirect)
+	redirectApp.Delete("/:redirectId", redirectHandler.DeleteRedirect)
+
+	// comment
+	commentApp := adminApp.Group("/comments")
+	commentApp.Get("/", commentHandler.GetComments)
+	commentApp.Get("/bans", commentHandler.GetCommentBans)
+	commentApp.Delete("/bans/:banId", commentHandler.DeleteCommentBan)
+	commentApp.Post("/:commentId/approve", commentHandler.ApproveComment)
+	commentApp.Post("/:commentId/reject", commentHandler.RejectComment)
+	commentApp.Post("/:commentId/spam", commentHandler.MarkCommentSpam)
+	commentApp.Post("/:commentId/ban", commentHandler.BanCommentAuthor)
+
+	// analytics
+	analyticsApp := adminApp.Group("/analytics")
+	analyticsApp.Get("/:report", analyticsHandler.GetReport)
+
+	// notification
+	notificationApp := adminApp.Group("/notifications")
+	notificationApp.Get("/", notificationHandler.GetNotifications)
+	notificationApp.Get("/unread", notificationHandler.GetUnreadNotifications)
+	notificationApp.Post("/read", notificationHandler.MarkNotificationsRead)
+	notificationApp.Get("/subscriptions", notificationHandler.GetSubscriptions)
+	notificationApp.Put("/subscriptions", notificationHandler.UpdateSubscriptions)
+	notificationApp.Get("/ws", notificationHandler.Socket)
+
+	// webhook
+	webhookApp := adminApp.Group("/webhooks")
+	webhookApp.Get("/", webhookHandler.GetWebhooks)
+	webhookApp.Post("/", webhookHandler.CreateWebhook)
+	webhookApp.Get("/deliveries/:deliveryId", webhookHandler.GetDeliveryByID)
+	webhookApp.Post("/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+	webhookApp.Get("/:webhookId", webhookHandler.GetWebhookByID)
+	webhookApp.Put("/:webhookId", webhookHandler.UpdateWebhook)
+	webhookApp.Delete("/:webhookId", webhookHandler.DeleteWebhook)
+	webhookApp.Get("/:webhookId/deliveries", webhookHandler.GetDeliveries)
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-workerCtx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := mediaService.DeleteExpiredUploads(workerCtx)
+				if err != nil {
+					log.Error().Err(err).Msg("Failed to delete expired uploads")
+				} else if deleted > 0 {
+					log.Info().Msgf("Deleted %d expired uploads", deleted)
+				}
+
+				pruned, err := viewService.DeleteOldViews(workerCtx)
+				if err != nil {
+					log.Error().Err(err).Msg("Failed to delete old hourly views")
+				} else if pruned > 0 {
+					log.Info().Msgf("Deleted %d old hourly view counters", pruned)
+				}
+
+				handled, err := outboxService.DeleteProcessedEvents(workerCtx)
+				if err != nil {
+					log.Error().Err(err).Msg("Failed to delete processed outbox events")
+				} else if handled > 0 {
+					log.Info().Msgf("Deleted %d processed outbox events", handled)
+				}
+			}
+		}
+	}()
+
+	// Handlers of domain events are registered before the relay starts.
+	outboxService.Register("webhooks", webhookService.HandleEvent, entity.WebhookEventTypes...)
+
+	go trendingService.RunWorker(workerCtx)
+	go webhookService.RunWorker(workerCtx)
+	go outboxService.RunRelay(workerCtx)
+
+	viewWriterDone := make(chan struct{})
+	go func() {
+		viewService.RunWriter(workerCtx)
+		close(viewWriterDone)
+	}()
 
 	go func() {
 		if cfg.App.AppPort == "" {
@@ -88,9 +312,15 @@ func RunServer() {
 	signal.Notify(quit, os.Interrupt)
 	signal.Notify(quit, syscall.SIGTERM)
 	<-quit
+	stopWorkers()
+	// Streams and sockets never end on their own; closing them lets
+	// shutdown finish.
+	liveBlogService.Close()
+	notificationService.Close()
 	log.Logger.Println("Server shuttdown of 5s")
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	app.ShutdownWithContext(ctx)
+	<-viewWriterDone
 }
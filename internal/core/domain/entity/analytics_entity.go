@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// AnalyticsQueryEntity filters a report. From and To are whole days, both
+// included.
+type AnalyticsQueryEntity struct {
+	From       time.Time
+	To         time.Time
+	CategoryID int64
+	ContentID  int64
+	Limit      int
+}
+
+// AnalyticsReportEntity is a report as a table, so it can be written both
+// as JSON and as CSV.
+type AnalyticsReportEntity struct {
+	Name    string
+	From    time.Time
+	To      time.Time
+	Columns []string
+	Rows    [][]interface{}
+}
@@ -11,3 +11,8 @@ type CategoryEntity struct {
 func (c *CategoryEntity) Error() string {
 	panic("unimplemented")
 }
+
+type CategoryMergeEntity struct {
+	TargetID  int16
+	SourceIDs []int16
+}
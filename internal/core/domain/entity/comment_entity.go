@@ -0,0 +1,72 @@
+package entity
+
+import "time"
+
+type CommentEntity struct {
+	ID            int64
+	ContentID     int64
+	ContentTitle  string
+	ContentSlug   string
+	ParentID      int64
+	Replies       []CommentEntity
+	AuthorName    string
+	AuthorEmail   string
+	IP            string
+	UserAgent     string
+	Body          string
+	BodyHash      string
+	Status        string
+	SpamScore     float64
+	SpamChecks    []SpamCheckEntity
+	TrainedAs     string
+	ModeratedByID int64
+	ModeratedAt   *time.Time
+	CreatedAt     time.Time
+}
+
+// SpamCheckEntity is the verdict of one spam filter on a comment.
+type SpamCheckEntity struct {
+	Filter string  `json:"filter"`
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason,omitempty"`
+}
+
+// SpamCorpusEntity holds what the spam classifier learned from moderation
+// decisions.
+type SpamCorpusEntity struct {
+	SpamTokens map[string]int
+	HamTokens  map[string]int
+	SpamDocs   int
+	HamDocs    int
+}
+
+type CommentBanEntity struct {
+	ID          int64
+	Email       string
+	IP          string
+	Reason      string
+	CreatedByID int64
+	CreatedAt   time.Time
+}
+
+const (
+	CommentStatusPending  = "PENDING"
+	CommentStatusApproved = "APPROVED"
+	CommentStatusRejected = "REJECTED"
+	CommentStatusSpam     = "SPAM"
+)
+
+// Labels a comment can be trained as after a moderation decision.
+const (
+	CommentTrainedSpam = "SPAM"
+	CommentTrainedHam  = "HAM"
+)
+
+const (
+	// CommentModerationPre holds new comments until an editor approves
+	// them.
+	CommentModerationPre = "pre"
+	// CommentModerationPost shows new comments right away and leaves them
+	// in the moderation queue for review.
+	CommentModerationPost = "post"
+)
@@ -0,0 +1,75 @@
+package entity
+
+import "time"
+
+type ContentEntity struct {
+	ID                int64
+	Title             string
+	Slug              string
+	Excerpt           string
+	Description       string
+	DescriptionFormat string
+	DescriptionHTML   string
+	Image             string
+	MediaID           int64
+	Media             *MediaEntity
+	ImageVariants     []ImageVariantEntity
+	Tags              []string
+	WordCount         int
+	ReadingTime       int
+	Status            string
+	MetaTitle         string
+	MetaDescription   string
+	CanonicalURL      string
+	NoIndex           bool
+	CommentsEnabled   bool
+	ContentType       string
+	Reactions         map[string]int64
+	OgMediaID         int64
+	OgMedia           *MediaEntity
+	CategoryID        int64
+	CreatedByID       int64
+	CreatedAt         time.Time
+	UpdatedAt         *time.Time
+	PublishedAt       *time.Time
+	User              UserEntity
+	Category          CategoryEntity
+}
+
+type SeoTagEntity struct {
+	Attribute string
+	Key       string
+	Content   string
+}
+
+type SeoEntity struct {
+	Title        string
+	Description  string
+	CanonicalURL string
+	Robots       string
+	Tags         []SeoTagEntity
+	JSONLD       []byte
+}
+
+type QueryString struct {
+	Limit      int
+	Page       int
+	OrderBy    string
+	OrderType  string
+	Search     string
+	Status     string
+	CategoryID int64
+	Tag        string
+}
+
+const (
+	ContentStatusPublish = "PUBLISH"
+	ContentStatusDraft   = "DRAFT"
+	// ContentStatusReview is a draft submitted to the editors for approval.
+	ContentStatusReview = "REVIEW"
+)
+
+const (
+	ContentTypeArticle  = "ARTICLE"
+	ContentTypeLiveBlog = "LIVE_BLOG"
+)
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// ContentViewCountEntity is the number of views a content got in one hour.
+type ContentViewCountEntity struct {
+	ContentID int64
+	Hour      time.Time
+	Views     int64
+}
+
+type ViewerEntity struct {
+	IP        string
+	UserAgent string
+}
+
+type MostReadQueryEntity struct {
+	Since      time.Time
+	Daily      bool
+	CategoryID int64
+	Limit      int
+}
+
+type MostReadEntity struct {
+	Content ContentEntity
+	Views   int64
+}
+
+type TrendingEntity struct {
+	Content ContentEntity
+	Score   float64
+	Views   int64
+}
+
+type TrendingQueryEntity struct {
+	Window  time.Duration
+	Decay   time.Duration
+	Gravity float64
+}
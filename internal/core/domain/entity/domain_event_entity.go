@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// DomainEventEntity is a change to an aggregate, recorded in the outbox
+// with the change itself. Payload is the JSON the event was emitted with.
+type DomainEventEntity struct {
+	ID            int64
+	EventID       string
+	AggregateType string
+	AggregateID   int64
+	Type          string
+	Payload       string
+	Attempts      int
+	CreatedAt     time.Time
+}
+
+const (
+	AggregateContent  = "content"
+	AggregateCategory = "category"
+)
+
+const (
+	EventContentCreated     = "content.created"
+	EventContentUpdated     = "content.updated"
+	EventContentPublished   = "content.published"
+	EventContentUnpublished = "content.unpublished"
+	EventContentDeleted     = "content.deleted"
+
+	EventCategoryCreated = "category.created"
+	EventCategoryUpdated = "category.updated"
+	EventCategoryDeleted = "category.deleted"
+	EventCategoryMerged  = "category.merged"
+)
@@ -0,0 +1,17 @@
+package entity
+
+type ImageEntity struct {
+	URL      string
+	MimeType string
+	Size     int64
+	Width    int
+	Height   int
+	Variants []ImageVariantEntity
+}
+
+type ImageVariantEntity struct {
+	Name   string
+	URL    string
+	Width  int
+	Height int
+}
@@ -2,7 +2,16 @@ package entity
 
 import "github.com/golang-jwt/jwt/v5"
 
+// Kinds of token. Staff and readers sign in separately and a token only
+// opens the routes of its own kind.
+const (
+	JwtKindStaff  = "staff"
+	JwtKindReader = "reader"
+)
+
 type JwtData struct {
-	UserID float64 `json:"user_id"`
+	UserID   float64 `json:"user_id"`
+	ReaderID float64 `json:"reader_id,omitempty"`
+	Kind     string  `json:"kind"`
 	jwt.RegisteredClaims
 }
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+type BookmarkEntity struct {
+	ReaderID  int64
+	ContentID int64
+	Content   ContentEntity
+	CreatedAt time.Time
+}
+
+// ReadingHistoryEntity is the last time a reader opened a story. Position
+// is how far they got, in percent of the story.
+type ReadingHistoryEntity struct {
+	ReaderID  int64
+	ContentID int64
+	Content   ContentEntity
+	Position  int
+	ReadAt    time.Time
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+type LiveBlogEntryEntity struct {
+	ID          int64
+	ContentID   int64
+	Title       string
+	Body        string
+	BodyFormat  string
+	BodyHTML    string
+	Pinned      bool
+	CreatedByID int64
+	Author      string
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+}
+
+// LiveBlogEventEntity is one change to a live blog. Its id is the SSE event
+// id readers resume from.
+type LiveBlogEventEntity struct {
+	ID        int64
+	ContentID int64
+	EntryID   int64
+	Type      string
+	CreatedAt time.Time
+}
+
+const (
+	LiveBlogEventCreated = "entry.created"
+	LiveBlogEventUpdated = "entry.updated"
+	LiveBlogEventDeleted = "entry.deleted"
+)
+
+// LiveBlogEntity is a page of a live blog and the id of its last event, from
+// which a reader who loaded the page can start streaming.
+type LiveBlogEntity struct {
+	ContentID   int64
+	LastEventID int64
+	Entries     []LiveBlogEntryEntity
+}
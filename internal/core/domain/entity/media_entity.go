@@ -0,0 +1,59 @@
+package entity
+
+import "time"
+
+type MediaEntity struct {
+	ID           int64
+	URL          string
+	FileName     string
+	MimeType     string
+	Size         int64
+	Width        int
+	Height       int
+	AltText      string
+	Caption      string
+	Credit       string
+	Copyright    string
+	License      string
+	Tags         []string
+	UploadedByID int64
+	UsageCount   int64
+	Variants     []ImageVariantEntity
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+}
+
+type MediaUploadEntity struct {
+	ID            int64
+	ObjectKey     string
+	FileName      string
+	ContentType   string
+	Size          int64
+	UploadedByID  int64
+	UploadURL     string
+	UploadMethod  string
+	UploadHeaders map[string]string
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+}
+
+type MediaGCObjectEntity struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	Deleted      bool
+	Error        string
+}
+
+type MediaGCReportEntity struct {
+	Prefix         string
+	MinAge         time.Duration
+	Applied        bool
+	ScannedObjects int
+	TooRecent      int
+	Orphans        []MediaGCObjectEntity
+	OrphanBytes    int64
+	Deleted        int
+	StartedAt      time.Time
+	FinishedAt     time.Time
+}
@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+type NotificationEntity struct {
+	ID           int64
+	UserID       int64
+	Type         string
+	ActorID      int64
+	Actor        string
+	ContentID    int64
+	ContentTitle string
+	CommentID    int64
+	Message      string
+	ReadAt       *time.Time
+	CreatedAt    time.Time
+}
+
+// NotificationEventEntity is something that happened in the newsroom. It
+// becomes one notification for each staff member subscribed to its type,
+// or only for RecipientID when set. The actor is never notified.
+type NotificationEventEntity struct {
+	Type        string
+	ActorID     int64
+	RecipientID int64
+	ContentID   int64
+	CommentID   int64
+	Message     string
+}
+
+const (
+	NotificationContentSubmitted = "content.submitted"
+	NotificationContentApproved  = "content.approved"
+	NotificationContentPublished = "content.published"
+	NotificationCommentFlagged   = "comment.flagged"
+)
+
+// NotificationTypes lists the types staff can subscribe to; all are on
+// until turned off.
+var NotificationTypes = []string{
+	NotificationContentSubmitted,
+	NotificationContentApproved,
+	NotificationContentPublished,
+	NotificationCommentFlagged,
+}
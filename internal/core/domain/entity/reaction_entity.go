@@ -0,0 +1,23 @@
+package entity
+
+const (
+	ReactionLike       = "LIKE"
+	ReactionInsightful = "INSIGHTFUL"
+	ReactionSad        = "SAD"
+	ReactionAngry      = "ANGRY"
+)
+
+// ReactionKinds lists the reactions in the order they are shown.
+var ReactionKinds = []string{ReactionLike, ReactionInsightful, ReactionSad, ReactionAngry}
+
+// ReactorEntity is who reacts: a signed-in reader, or else an anonymous
+// visitor known by the fingerprint cookie.
+type ReactorEntity struct {
+	ReaderID    int64
+	Fingerprint string
+}
+
+type ReactionSummaryEntity struct {
+	Counts map[string]int64
+	Mine   string
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type ReaderEntity struct {
+	ID                 int64
+	Name               string
+	Email              string
+	Password           string
+	EmailVerifiedAt    *time.Time
+	HistoryEnabled     bool
+	VerificationSentAt *time.Time
+	CreatedAt          time.Time
+}
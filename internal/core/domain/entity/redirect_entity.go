@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type RedirectEntity struct {
+	ID          int64
+	SourcePath  string
+	TargetPath  string
+	StatusCode  int
+	HitCount    int64
+	CreatedByID int64
+	CreatedAt   time.Time
+}
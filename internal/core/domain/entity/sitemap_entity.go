@@ -0,0 +1,9 @@
+package entity
+
+import "time"
+
+type SitemapEntryEntity struct {
+	ID      int64
+	Slug    string
+	LastMod time.Time
+}
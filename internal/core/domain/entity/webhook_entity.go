@@ -0,0 +1,71 @@
+package entity
+
+import "time"
+
+type WebhookEntity struct {
+	ID          int64
+	URL         string
+	Secret      string
+	EventTypes  []string
+	Active      bool
+	CreatedByID int64
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+}
+
+type WebhookDeliveryEntity struct {
+	ID            int64
+	WebhookID     int64
+	Webhook       *WebhookEntity
+	EventID       string
+	EventType     string
+	Payload       string
+	Status        string
+	Attempts      int
+	ResponseCode  int
+	NextAttemptAt *time.Time
+	LastAttemptAt *time.Time
+	AttemptLog    []WebhookAttemptEntity
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
+}
+
+// WebhookAttemptEntity is one try at a delivery. A request that got no
+// response has no ResponseCode but an Error.
+type WebhookAttemptEntity struct {
+	ID           int64
+	DeliveryID   int64
+	ResponseCode int
+	ResponseBody string
+	Error        string
+	Duration     time.Duration
+	CreatedAt    time.Time
+}
+
+type WebhookRequestEntity struct {
+	URL        string
+	Secret     string
+	DeliveryID int64
+	EventType  string
+	Payload    []byte
+}
+
+type WebhookResponseEntity struct {
+	StatusCode int
+	Body       string
+}
+
+const (
+	WebhookDeliveryPending = "PENDING"
+	WebhookDeliverySuccess = "SUCCESS"
+	WebhookDeliveryFailed  = "FAILED"
+)
+
+// WebhookEventTypes are the domain events webhooks can subscribe to.
+var WebhookEventTypes = []string{
+	EventContentCreated,
+	EventContentUpdated,
+	EventContentPublished,
+	EventContentUnpublished,
+	EventContentDeleted,
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+type Comment struct {
+	ID            int64      `gorm:"id"`
+	ContentID     int64      `gorm:"content_id"`
+	Content       Content    `gorm:"foreignKey:ContentID"`
+	ParentID      *int64     `gorm:"parent_id"`
+	Replies       []Comment  `gorm:"foreignKey:ParentID"`
+	AuthorName    string     `gorm:"author_name"`
+	AuthorEmail   string     `gorm:"author_email"`
+	IP            string     `gorm:"ip"`
+	UserAgent     string     `gorm:"user_agent"`
+	Body          string     `gorm:"body"`
+	BodyHash      string     `gorm:"body_hash"`
+	Status        string     `gorm:"status"`
+	SpamScore     float64    `gorm:"spam_score"`
+	SpamChecks    string     `gorm:"spam_checks"`
+	TrainedAs     string     `gorm:"trained_as"`
+	ModeratedByID *int64     `gorm:"moderated_by_id"`
+	ModeratedAt   *time.Time `gorm:"moderated_at"`
+	CreatedAt     time.Time  `gorm:"created_at"`
+	UpdatedAt     *time.Time `gorm:"updated_at"`
+}
+
+type CommentBan struct {
+	ID          int64     `gorm:"id"`
+	Email       string    `gorm:"email"`
+	IP          string    `gorm:"ip"`
+	Reason      string    `gorm:"reason"`
+	CreatedByID *int64    `gorm:"created_by_id"`
+	CreatedAt   time.Time `gorm:"created_at"`
+}
+
+type CommentSpamToken struct {
+	Token     string `gorm:"token"`
+	SpamCount int    `gorm:"spam_count"`
+	HamCount  int    `gorm:"ham_count"`
+}
@@ -3,17 +3,33 @@ package model
 import "time"
 
 type Content struct {
-	ID          int64      `gorm:"id"`
-	Title       string     `gorm:"title"`
-	Exerpt      string     `gorm:"exerpt"`
-	Description string     `gorm:"description"`
-	Image       string     `gorm:"image"`
-	Tags        string     `gorm:"tags"`
-	Status      string     `gorm:"status"`
-	CategoryID  int64      `gorm:"category_id"`
-	CreatedByID int64      `gorm:"created_by_id"`
-	User        User       `gorm:"foreignKey:CreatedByID"`
-	Category    Category   `gorm:"foreignKey:CategoryID"`
-	CreatedAt   time.Time  `gorm:"created_at"`
-	UpdatedAt   *time.Time `gorm:"updated_at"`
+	ID                int64                  `gorm:"id"`
+	Title             string                 `gorm:"title"`
+	Slug              string                 `gorm:"slug"`
+	Exerpt            string                 `gorm:"exerpt"`
+	Description       string                 `gorm:"description"`
+	DescriptionFormat string                 `gorm:"description_format"`
+	DescriptionHTML   string                 `gorm:"description_html"`
+	MediaID           *int64                 `gorm:"media_id"`
+	Tags              string                 `gorm:"tags"`
+	WordCount         int                    `gorm:"word_count"`
+	ReadingTime       int                    `gorm:"reading_time"`
+	Status            string                 `gorm:"status"`
+	ContentType       string                 `gorm:"content_type"`
+	MetaTitle         string                 `gorm:"meta_title"`
+	MetaDescription   string                 `gorm:"meta_description"`
+	CanonicalURL      string                 `gorm:"canonical_url"`
+	Noindex           bool                   `gorm:"noindex"`
+	CommentsEnabled   bool                   `gorm:"comments_enabled"`
+	OgMediaID         *int64                 `gorm:"og_media_id"`
+	CategoryID        int64                  `gorm:"category_id"`
+	CreatedByID       int64                  `gorm:"created_by_id"`
+	User              User                   `gorm:"foreignKey:CreatedByID"`
+	Category          Category               `gorm:"foreignKey:CategoryID"`
+	Media             *Media                 `gorm:"foreignKey:MediaID"`
+	OgMedia           *Media                 `gorm:"foreignKey:OgMediaID"`
+	ReactionCounts    []ContentReactionCount `gorm:"foreignKey:ContentID"`
+	CreatedAt         time.Time              `gorm:"created_at"`
+	UpdatedAt         *time.Time             `gorm:"updated_at"`
+	PublishedAt       *time.Time             `gorm:"published_at"`
 }
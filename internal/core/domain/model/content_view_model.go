@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+type ContentViewHourly struct {
+	ContentID int64     `gorm:"content_id"`
+	Hour      time.Time `gorm:"hour"`
+	Views     int64     `gorm:"views"`
+}
+
+func (ContentViewHourly) TableName() string {
+	return "content_views_hourly"
+}
+
+type ContentViewDaily struct {
+	ContentID int64     `gorm:"content_id"`
+	Day       time.Time `gorm:"day"`
+	Views     int64     `gorm:"views"`
+}
+
+func (ContentViewDaily) TableName() string {
+	return "content_views_daily"
+}
+
+type TrendingContent struct {
+	ContentID  int64     `gorm:"content_id"`
+	CategoryID int64     `gorm:"category_id"`
+	Score      float64   `gorm:"score"`
+	Views      int64     `gorm:"views"`
+	ComputedAt time.Time `gorm:"computed_at"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type ReaderBookmark struct {
+	ReaderID  int64     `gorm:"reader_id"`
+	ContentID int64     `gorm:"content_id"`
+	Content   Content   `gorm:"foreignKey:ContentID"`
+	CreatedAt time.Time `gorm:"created_at"`
+}
+
+type ReaderHistory struct {
+	ReaderID  int64     `gorm:"reader_id"`
+	ContentID int64     `gorm:"content_id"`
+	Content   Content   `gorm:"foreignKey:ContentID"`
+	Position  int       `gorm:"position"`
+	ReadAt    time.Time `gorm:"read_at"`
+}
+
+func (ReaderHistory) TableName() string {
+	return "reader_history"
+}
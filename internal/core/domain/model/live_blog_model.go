@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+type LiveBlogEntry struct {
+	ID          int64      `gorm:"id"`
+	ContentID   int64      `gorm:"content_id"`
+	Title       string     `gorm:"title"`
+	Body        string     `gorm:"body"`
+	BodyFormat  string     `gorm:"body_format"`
+	BodyHTML    string     `gorm:"body_html"`
+	Pinned      bool       `gorm:"pinned"`
+	CreatedByID *int64     `gorm:"created_by_id"`
+	User        *User      `gorm:"foreignKey:CreatedByID"`
+	CreatedAt   time.Time  `gorm:"created_at"`
+	UpdatedAt   *time.Time `gorm:"updated_at"`
+}
+
+type LiveBlogEvent struct {
+	ID        int64     `gorm:"id"`
+	ContentID int64     `gorm:"content_id"`
+	EntryID   int64     `gorm:"entry_id"`
+	Type      string    `gorm:"type"`
+	CreatedAt time.Time `gorm:"created_at"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+type Media struct {
+	ID           int64      `gorm:"id"`
+	URL          string     `gorm:"url"`
+	FileName     string     `gorm:"file_name"`
+	MimeType     string     `gorm:"mime_type"`
+	Size         int64      `gorm:"size"`
+	Width        int        `gorm:"width"`
+	Height       int        `gorm:"height"`
+	AltText      string     `gorm:"alt_text"`
+	Caption      string     `gorm:"caption"`
+	Credit       string     `gorm:"credit"`
+	Copyright    string     `gorm:"copyright"`
+	License      string     `gorm:"license"`
+	Tags         string     `gorm:"tags"`
+	Variants     string     `gorm:"variants"`
+	UploadedByID *int64     `gorm:"uploaded_by_id"`
+	UsageCount   int64      `gorm:"->;-:migration"`
+	CreatedAt    time.Time  `gorm:"created_at"`
+	UpdatedAt    *time.Time `gorm:"updated_at"`
+}
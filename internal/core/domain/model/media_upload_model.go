@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type MediaUpload struct {
+	ID           int64     `gorm:"id"`
+	ObjectKey    string    `gorm:"object_key"`
+	FileName     string    `gorm:"file_name"`
+	ContentType  string    `gorm:"content_type"`
+	Size         int64     `gorm:"size"`
+	UploadedByID *int64    `gorm:"uploaded_by_id"`
+	ExpiresAt    time.Time `gorm:"expires_at"`
+	CreatedAt    time.Time `gorm:"created_at"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type Notification struct {
+	ID        int64      `gorm:"id"`
+	UserID    int64      `gorm:"user_id"`
+	Type      string     `gorm:"type"`
+	ActorID   *int64     `gorm:"actor_id"`
+	Actor     *User      `gorm:"foreignKey:ActorID"`
+	ContentID *int64     `gorm:"content_id"`
+	Content   *Content   `gorm:"foreignKey:ContentID"`
+	CommentID *int64     `gorm:"comment_id"`
+	Message   string     `gorm:"message"`
+	ReadAt    *time.Time `gorm:"read_at"`
+	CreatedAt time.Time  `gorm:"created_at"`
+}
+
+type NotificationSubscription struct {
+	UserID  int64  `gorm:"user_id"`
+	Type    string `gorm:"type"`
+	Enabled bool   `gorm:"enabled"`
+}
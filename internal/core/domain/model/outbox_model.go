@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type OutboxEvent struct {
+	ID            int64      `gorm:"id"`
+	EventID       string     `gorm:"event_id"`
+	AggregateType string     `gorm:"aggregate_type"`
+	AggregateID   int64      `gorm:"aggregate_id"`
+	EventType     string     `gorm:"event_type"`
+	Payload       string     `gorm:"payload"`
+	Attempts      int        `gorm:"attempts"`
+	LastError     string     `gorm:"last_error"`
+	NextAttemptAt time.Time  `gorm:"next_attempt_at"`
+	ProcessedAt   *time.Time `gorm:"processed_at"`
+	CreatedAt     time.Time  `gorm:"created_at"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox"
+}
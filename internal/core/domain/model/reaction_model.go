@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+type ContentReaction struct {
+	ID          int64      `gorm:"id"`
+	ContentID   int64      `gorm:"content_id"`
+	ReaderID    *int64     `gorm:"reader_id"`
+	Fingerprint *string    `gorm:"fingerprint"`
+	Reaction    string     `gorm:"reaction"`
+	CreatedAt   time.Time  `gorm:"created_at"`
+	UpdatedAt   *time.Time `gorm:"updated_at"`
+}
+
+type ContentReactionCount struct {
+	ContentID int64  `gorm:"content_id"`
+	Reaction  string `gorm:"reaction"`
+	Count     int64  `gorm:"count"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type Reader struct {
+	ID                 int64      `gorm:"id"`
+	Name               string     `gorm:"name"`
+	Email              string     `gorm:"email"`
+	Password           string     `gorm:"password"`
+	EmailVerifiedAt    *time.Time `gorm:"email_verified_at"`
+	HistoryEnabled     bool       `gorm:"history_enabled"`
+	VerificationToken  *string    `gorm:"verification_token"`
+	VerificationSentAt *time.Time `gorm:"verification_sent_at"`
+	CreatedAt          time.Time  `gorm:"created_at"`
+	UpdatedAt          *time.Time `gorm:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type Redirect struct {
+	ID          int64      `gorm:"id"`
+	SourcePath  string     `gorm:"source_path"`
+	TargetPath  string     `gorm:"target_path"`
+	StatusCode  int        `gorm:"status_code"`
+	HitCount    int64      `gorm:"hit_count"`
+	CreatedByID *int64     `gorm:"created_by_id"`
+	CreatedAt   time.Time  `gorm:"created_at"`
+	UpdatedAt   *time.Time `gorm:"updated_at"`
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+type SlugHistory struct {
+	ID         int64     `gorm:"id"`
+	EntityType string    `gorm:"entity_type"`
+	EntityID   int64     `gorm:"entity_id"`
+	Slug       string    `gorm:"slug"`
+	CreatedAt  time.Time `gorm:"created_at"`
+}
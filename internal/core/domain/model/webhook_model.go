@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+type Webhook struct {
+	ID          int64      `gorm:"id"`
+	URL         string     `gorm:"url"`
+	Secret      string     `gorm:"secret"`
+	EventTypes  string     `gorm:"event_types"`
+	Active      bool       `gorm:"active"`
+	CreatedByID *int64     `gorm:"created_by_id"`
+	CreatedAt   time.Time  `gorm:"created_at"`
+	UpdatedAt   *time.Time `gorm:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID            int64                    `gorm:"id"`
+	WebhookID     int64                    `gorm:"webhook_id"`
+	Webhook       Webhook                  `gorm:"foreignKey:WebhookID"`
+	EventID       string                   `gorm:"event_id"`
+	EventType     string                   `gorm:"event_type"`
+	Payload       string                   `gorm:"payload"`
+	Status        string                   `gorm:"status"`
+	Attempts      int                      `gorm:"attempts"`
+	ResponseCode  *int                     `gorm:"response_code"`
+	NextAttemptAt *time.Time               `gorm:"next_attempt_at"`
+	LastAttemptAt *time.Time               `gorm:"last_attempt_at"`
+	AttemptLog    []WebhookDeliveryAttempt `gorm:"foreignKey:DeliveryID"`
+	CreatedAt     time.Time                `gorm:"created_at"`
+	UpdatedAt     *time.Time               `gorm:"updated_at"`
+}
+
+type WebhookDeliveryAttempt struct {
+	ID           int64     `gorm:"id"`
+	DeliveryID   int64     `gorm:"delivery_id"`
+	ResponseCode *int      `gorm:"response_code"`
+	ResponseBody string    `gorm:"response_body"`
+	Error        string    `gorm:"error"`
+	DurationMs   int64     `gorm:"duration_ms"`
+	CreatedAt    time.Time `gorm:"created_at"`
+}
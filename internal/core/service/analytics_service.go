@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+const (
+	analyticsDefaultDays = 30
+	analyticsMaxDays     = 366
+	analyticsMaxLimit    = 1000
+)
+
+var (
+	ErrUnknownReport         = errors.New("unknown analytics report")
+	ErrInvalidAnalyticsRange = errors.New("from must not be after to, and the range may span at most 366 days")
+)
+
+type AnalyticsService interface {
+	GetReport(ctx context.Context, name string, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error)
+}
+
+type analyticsReport func(ctx context.Context, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error)
+
+type analyticsService struct {
+	reports map[string]analyticsReport
+}
+
+// GetReport implements AnalyticsService. A missing range means the last 30
+// days, and a missing limit 100 rows for the reports that have one.
+func (a *analyticsService) GetReport(ctx context.Context, name string, query entity.AnalyticsQueryEntity) (*entity.AnalyticsReportEntity, error) {
+	report, ok := a.reports[name]
+	if !ok {
+		return nil, ErrUnknownReport
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if query.To.IsZero() {
+		query.To = today
+	}
+	if query.From.IsZero() {
+		query.From = query.To.AddDate(0, 0, -(analyticsDefaultDays - 1))
+	}
+	if query.From.After(query.To) || query.To.Sub(query.From) >= analyticsMaxDays*24*time.Hour {
+		return nil, ErrInvalidAnalyticsRange
+	}
+	if query.Limit <= 0 || query.Limit > analyticsMaxLimit {
+		query.Limit = 100
+	}
+
+	result, err := report(ctx, query)
+	if err != nil {
+		code = "[SERVICE] GetReport - 1"
+		log.Errorw(code, err, "report", name)
+		return nil, err
+	}
+
+	result.Name = name
+	result.From = query.From
+	result.To = query.To
+	return result, nil
+}
+
+func NewAnalyticsService(analyticsRepo repository.AnalyticsRepository) AnalyticsService {
+	return &analyticsService{reports: map[string]analyticsReport{
+		"published-per-day":      analyticsRepo.GetPublishedPerDay,
+		"published-per-author":   analyticsRepo.GetPublishedPerAuthor,
+		"published-per-category": analyticsRepo.GetPublishedPerCategory,
+		"views-per-day":          analyticsRepo.GetViewsPerDay,
+		"views-per-content":      analyticsRepo.GetViewsPerContent,
+		"reading-time":           analyticsRepo.GetReadingTime,
+		"top-tags":               analyticsRepo.GetTopTags,
+		"pending-drafts":         analyticsRepo.GetPendingDrafts,
+	}}
+}
@@ -49,6 +49,7 @@ func (a *authService) GetUserByEmail(ctx context.Context, req entity.LoginReques
 
 	jwtData := &entity.JwtData{
 		UserID: float64(result.ID),
+		Kind:   entity.JwtKindStaff,
 		RegisteredClaims: jwt.RegisteredClaims{
 			NotBefore: jwt.NewNumericDate(time.Now().Add(time.Hour * 2)),
 			ID:        fmt.Sprint(result.ID),
@@ -2,12 +2,19 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"news-app/internal/adapter/repository"
 	"news-app/internal/core/domain/entity"
-	"news-app/lib/conv"
+	"news-app/lib/slug"
 
 	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCategoryMergeIntoItself = errors.New("cannot merge a category into itself")
+	ErrCategoryMergeNoSource   = errors.New("at least one source category is required")
 )
 
 type CategoryService interface {
@@ -15,37 +22,76 @@ type CategoryService interface {
 	GetCategoryByID(ctx context.Context, id int16) (*entity.CategoryEntity, error)
 	CreateCategory(ctx context.Context, req entity.CategoryEntity) (*entity.CategoryEntity, error)
 	UpdateCategory(ctx context.Context, req entity.CategoryEntity) (*entity.CategoryEntity, error)
+	GetCategoryBySlug(ctx context.Context, categorySlug string) (*entity.CategoryEntity, error)
 	DeleteCategory(ctx context.Context, id int16) error
+	MergeCategories(ctx context.Context, req entity.CategoryMergeEntity) error
+}
+
+// categoryEvent is the payload of the category domain events. A merge is
+// recorded on the target, with the categories merged into it.
+type categoryEvent struct {
+	ID        int64   `json:"id"`
+	Title     string  `json:"title,omitempty"`
+	Slug      string  `json:"slug,omitempty"`
+	MergedIDs []int64 `json:"merged_ids,omitempty"`
 }
 
 type categoryService struct {
 	categoryRepository repository.CategoryRepository
+	transactor         repository.Transactor
+	sitemapService     SitemapService
+	redirectService    RedirectService
+	outboxService      OutboxService
 }
 
 // CreateCategory implements CategoryService.
 func (c *categoryService) CreateCategory(ctx context.Context, req entity.CategoryEntity) (*entity.CategoryEntity, error) {
-	slug := conv.GeneratesSlug(req.Title)
-	req.Slug = slug
-
-	_, err := c.categoryRepository.CreateCategory(ctx, req)
+	req.Slug = slug.Generate(req.Title)
+
+	var result *entity.CategoryEntity
+	err := c.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		created, err := c.categoryRepository.CreateCategory(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		result = created
+		return c.emitCategoryEvent(ctx, entity.EventCategoryCreated, *result)
+	})
 	if err != nil {
 		code := "[SERVICE] CreateCategory - 1"
 		log.Errorw(code, err)
 		return nil, err
 	}
 
-	return &req, nil
+	c.sitemapService.CategoryChanged(int64(result.ID))
+
+	return result, nil
 }
 
 // DeleteCategory implements CategoryService.
 func (c *categoryService) DeleteCategory(ctx context.Context, id int16) error {
-	err = c.categoryRepository.DeleteCategory(ctx, id)
+	err = c.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		categoryData, err := c.categoryRepository.GetCategoryByID(ctx, id)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		err = c.categoryRepository.DeleteCategory(ctx, id)
+		if err != nil || categoryData == nil {
+			return err
+		}
+
+		return c.emitCategoryEvent(ctx, entity.EventCategoryDeleted, *categoryData)
+	})
 	if err != nil {
 		code := "[SERVICE] DeleteCategory - 1"
 		log.Errorw(code, err)
 		return err
 	}
 
+	c.sitemapService.CategoryChanged(int64(id))
+
 	return nil
 }
 
@@ -73,6 +119,18 @@ func (c *categoryService) GetCategoryByID(ctx context.Context, id int16) (*entit
 	return results, nil
 }
 
+// GetCategoryBySlug implements CategoryService.
+func (c *categoryService) GetCategoryBySlug(ctx context.Context, categorySlug string) (*entity.CategoryEntity, error) {
+	result, err := c.categoryRepository.GetCategoryBySlug(ctx, categorySlug)
+	if err != nil {
+		code = "[SERVICE] GetCategoryBySlug - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // UpdateCategory implements CategoryService.
 func (c *categoryService) UpdateCategory(ctx context.Context, req entity.CategoryEntity) (*entity.CategoryEntity, error) {
 	categoryData, err := c.categoryRepository.GetCategoryByID(ctx, req.ID)
@@ -81,24 +139,105 @@ func (c *categoryService) UpdateCategory(ctx context.Context, req entity.Categor
 		log.Errorw(code, err)
 		return nil, err
 	}
-	slug := conv.GeneratesSlug(req.Title)
 
-	if categoryData.Title == req.Title {
-		req.Slug = categoryData.Slug
+	req.Slug = categoryData.Slug
+	if categoryData.Title != req.Title {
+		req.Slug = slug.Generate(req.Title)
 	}
 
-	req.Slug = slug
+	var result *entity.CategoryEntity
+	err = c.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		updated, err := c.categoryRepository.UpdateCategory(ctx, req)
+		if err != nil {
+			return err
+		}
 
-	_, err = c.categoryRepository.UpdateCategory(ctx, req)
+		result = updated
+		return c.emitCategoryEvent(ctx, entity.EventCategoryUpdated, *result)
+	})
 	if err != nil {
 		code := "[SERVICE] UpdateCategory - 2"
 		log.Errorw(code, err)
 		return nil, err
 	}
 
-	return nil, err
+	c.sitemapService.CategoryChanged(int64(result.ID))
+	if result.Slug != categoryData.Slug {
+		c.redirectService.Invalidate()
+	}
+
+	return result, nil
 }
 
-func NewCategoryService(categoryRepo repository.CategoryRepository) CategoryService {
-	return &categoryService{categoryRepository: categoryRepo}
+// MergeCategories implements CategoryService.
+func (c *categoryService) MergeCategories(ctx context.Context, req entity.CategoryMergeEntity) error {
+	sourceIDs := []int16{}
+	seen := map[int16]bool{}
+	for _, id := range req.SourceIDs {
+		if id == req.TargetID {
+			code = "[SERVICE] MergeCategories - 1"
+			log.Errorw(code, ErrCategoryMergeIntoItself)
+			return ErrCategoryMergeIntoItself
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			sourceIDs = append(sourceIDs, id)
+		}
+	}
+
+	if len(sourceIDs) == 0 {
+		code = "[SERVICE] MergeCategories - 2"
+		log.Errorw(code, ErrCategoryMergeNoSource)
+		return ErrCategoryMergeNoSource
+	}
+
+	req.SourceIDs = sourceIDs
+	err = c.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := c.categoryRepository.MergeCategories(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		data := categoryEvent{ID: int64(req.TargetID)}
+		for _, id := range req.SourceIDs {
+			data.MergedIDs = append(data.MergedIDs, int64(id))
+		}
+
+		return c.outboxService.Emit(ctx, entity.AggregateCategory, data.ID, entity.EventCategoryMerged, data)
+	})
+	if err != nil {
+		code = "[SERVICE] MergeCategories - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	for _, id := range append(req.SourceIDs, req.TargetID) {
+		c.sitemapService.CategoryChanged(int64(id))
+	}
+	c.redirectService.Invalidate()
+
+	return nil
+}
+
+// emitCategoryEvent records eventType for category in the transaction of
+// ctx.
+func (c *categoryService) emitCategoryEvent(ctx context.Context, eventType string, category entity.CategoryEntity) error {
+	data := categoryEvent{
+		ID:    int64(category.ID),
+		Title: category.Title,
+		Slug:  category.Slug,
+	}
+
+	return c.outboxService.Emit(ctx, entity.AggregateCategory, data.ID, eventType, data)
+}
+
+func NewCategoryService(categoryRepo repository.CategoryRepository, transactor repository.Transactor, sitemapService SitemapService, redirectService RedirectService, outboxService OutboxService) CategoryService {
+	return &categoryService{
+		categoryRepository: categoryRepo,
+		transactor:         transactor,
+		sitemapService:     sitemapService,
+		redirectService:    redirectService,
+		outboxService:      outboxService,
+	}
 }
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"news-app/config"
+	"news-app/internal/adapter/repository"
+	"news-app/lib/spamfilter"
+)
+
+const (
+	// commentSpamThreshold is the combined score from which a comment is
+	// filed as spam without being shown.
+	commentSpamThreshold = 0.95
+	// commentReviewThreshold is the combined score from which a comment
+	// waits for an editor even when comments are post-moderated.
+	commentReviewThreshold = 0.5
+
+	commentDefaultMaxLinks  = 2
+	commentDuplicateWindow  = 24 * time.Hour
+	spamClassifierMinDocs   = 10
+	spamClassifierReloadTTL = 10 * time.Minute
+)
+
+// newCommentFilters builds the filter chain every new comment goes through.
+func newCommentFilters(commentRepo repository.CommentRepository, classifier *spamClassifier, cfg *config.Config) spamfilter.Chain {
+	maxLinks := cfg.Comment.MaxLinks
+	if maxLinks <= 0 {
+		maxLinks = commentDefaultMaxLinks
+	}
+
+	return spamfilter.Chain{
+		spamfilter.LinkFilter{Max: maxLinks},
+		spamfilter.NewBlocklistFilter(splitList(cfg.Comment.Blocklist)),
+		spamfilter.NewWordListFilter(append(append([]string{}, spamfilter.DefaultWords...), splitList(cfg.Comment.Words)...)),
+		duplicateFilter{commentRepository: commentRepo},
+		bayesFilter{classifier: classifier},
+	}
+}
+
+// duplicateFilter catches the same text sent again and again by one email
+// address or IP.
+type duplicateFilter struct {
+	commentRepository repository.CommentRepository
+}
+
+func (f duplicateFilter) Name() string {
+	return "duplicate"
+}
+
+func (f duplicateFilter) Check(ctx context.Context, in spamfilter.Input) (spamfilter.Verdict, error) {
+	count, err := f.commentRepository.CountRecentDuplicates(ctx, commentBodyHash(in.Body), in.Email, in.IP, time.Now().Add(-commentDuplicateWindow))
+	if err != nil || count == 0 {
+		return spamfilter.Verdict{}, err
+	}
+
+	return spamfilter.Verdict{
+		Score:  0.4 + 0.2*float64(count),
+		Reason: fmt.Sprintf("same text sent %d times in the last 24 hours", count),
+	}, nil
+}
+
+// bayesFilter scores comments with the classifier trained from moderation
+// decisions. Until it has seen enough of both kinds it stays neutral.
+type bayesFilter struct {
+	classifier *spamClassifier
+}
+
+func (f bayesFilter) Name() string {
+	return "bayes"
+}
+
+func (f bayesFilter) Check(ctx context.Context, in spamfilter.Input) (spamfilter.Verdict, error) {
+	classifier, err := f.classifier.get(ctx)
+	if err != nil {
+		return spamfilter.Verdict{}, err
+	}
+
+	spamDocs, hamDocs := classifier.Documents()
+	if spamDocs < spamClassifierMinDocs || hamDocs < spamClassifierMinDocs {
+		return spamfilter.Verdict{Reason: "not enough moderated comments to learn from"}, nil
+	}
+
+	tokens := spamfilter.Tokens(in.Body)
+	probability := classifier.SpamProbability(tokens)
+
+	// Only the part above even odds counts, so a neutral text adds
+	// nothing to the combined score.
+	verdict := spamfilter.Verdict{Score: max(probability-0.5, 0) * 2}
+	if top := classifier.TopTokens(tokens, 5); len(top) > 0 {
+		verdict.Reason = fmt.Sprintf("spam probability %.2f, top tokens: %s", probability, strings.Join(top, ", "))
+	}
+
+	return verdict, nil
+}
+
+// spamClassifier keeps the classifier in memory, reloading it now and then
+// so instances pick up what the others learned.
+type spamClassifier struct {
+	commentRepository repository.CommentRepository
+
+	mu         sync.Mutex
+	classifier *spamfilter.Classifier
+	loadedAt   time.Time
+}
+
+func (s *spamClassifier) get(ctx context.Context) (*spamfilter.Classifier, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.classifier != nil && time.Since(s.loadedAt) < spamClassifierReloadTTL {
+		return s.classifier, nil
+	}
+
+	corpus, err := s.commentRepository.GetSpamCorpus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.classifier = spamfilter.NewClassifier(corpus.SpamTokens, corpus.HamTokens, corpus.SpamDocs, corpus.HamDocs)
+	s.loadedAt = time.Now()
+	return s.classifier, nil
+}
+
+// train stores label for the comment with the given body and updates the
+// classifier in memory the same way.
+func (s *spamClassifier) train(ctx context.Context, id int64, body, label string) error {
+	tokens := spamfilter.Tokens(body)
+
+	previous, err := s.commentRepository.TrainComment(ctx, id, label, tokens)
+	if err != nil || previous == label {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.classifier != nil {
+		if previous != "" {
+			s.classifier.Train(previous, tokens, -1)
+		}
+		if label != "" {
+			s.classifier.Train(label, tokens, 1)
+		}
+	}
+
+	return nil
+}
+
+// commentBodyHash identifies a comment text regardless of case and spacing.
+func commentBodyHash(body string) string {
+	sum := sha256.Sum256([]byte(spamfilter.Normalize(body)))
+	return hex.EncodeToString(sum[:])
+}
+
+func splitList(list string) []string {
+	res := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"news-app/config"
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+	"news-app/lib/spamfilter"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCommentsDisabled      = errors.New("comments are disabled for this content")
+	ErrCommentAuthorBanned   = errors.New("you are not allowed to comment")
+	ErrCommentParentNotFound = errors.New("the comment replied to does not exist")
+)
+
+type CommentService interface {
+	GetContentComments(ctx context.Context, contentSlug string, query entity.QueryString) ([]entity.CommentEntity, int64, error)
+	CreateComment(ctx context.Context, contentSlug string, req entity.CommentEntity) (*entity.CommentEntity, error)
+	GetComments(ctx context.Context, query entity.QueryString) ([]entity.CommentEntity, int64, error)
+	ModerateComment(ctx context.Context, id int64, status string, moderatorID int64) error
+	BanCommentAuthor(ctx context.Context, id int64, reason string, moderatorID int64) (*entity.CommentBanEntity, error)
+	GetCommentBans(ctx context.Context, query entity.QueryString) ([]entity.CommentBanEntity, int64, error)
+	DeleteCommentBan(ctx context.Context, id int64) error
+}
+
+type commentService struct {
+	commentRepository   repository.CommentRepository
+	contentRepository   repository.ContentRepository
+	notificationService NotificationService
+	cfg                 *config.Config
+	filters             spamfilter.Chain
+	classifier          *spamClassifier
+}
+
+// GetContentComments implements CommentService.
+func (c *commentService) GetContentComments(ctx context.Context, contentSlug string, query entity.QueryString) ([]entity.CommentEntity, int64, error) {
+	content, err := c.contentRepository.GetContentBySlug(ctx, contentSlug, true)
+	if err != nil {
+		code = "[SERVICE] GetContentComments - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	results, totalData, err := c.commentRepository.GetApprovedComments(ctx, content.ID, query)
+	if err != nil {
+		code = "[SERVICE] GetContentComments - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	return results, totalData, nil
+}
+
+// CreateComment implements CommentService. Replies to a reply are attached
+// to the top level comment, so threads stay one level deep. Every comment
+// runs through the spam filters: likely spam is filed away at once and
+// doubtful ones wait for an editor whatever the moderation mode.
+func (c *commentService) CreateComment(ctx context.Context, contentSlug string, req entity.CommentEntity) (*entity.CommentEntity, error) {
+	content, err := c.contentRepository.GetContentBySlug(ctx, contentSlug, true)
+	if err != nil {
+		code = "[SERVICE] CreateComment - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if !content.CommentsEnabled {
+		return nil, ErrCommentsDisabled
+	}
+
+	req.AuthorName = strings.TrimSpace(req.AuthorName)
+	req.AuthorEmail = strings.ToLower(strings.TrimSpace(req.AuthorEmail))
+	req.Body = strings.TrimSpace(req.Body)
+
+	banned, err := c.commentRepository.IsCommentAuthorBanned(ctx, req.AuthorEmail, req.IP)
+	if err != nil {
+		code = "[SERVICE] CreateComment - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+	if banned {
+		return nil, ErrCommentAuthorBanned
+	}
+
+	if req.ParentID > 0 {
+		parent, err := c.commentRepository.GetCommentByID(ctx, req.ParentID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			code = "[SERVICE] CreateComment - 3"
+			log.Errorw(code, err)
+			return nil, err
+		}
+		if err != nil || parent.ContentID != content.ID || parent.Status != entity.CommentStatusApproved {
+			return nil, ErrCommentParentNotFound
+		}
+
+		if parent.ParentID > 0 {
+			req.ParentID = parent.ParentID
+		}
+	}
+
+	req.ContentID = content.ID
+	req.BodyHash = commentBodyHash(req.Body)
+
+	check := c.filters.Run(ctx, spamfilter.Input{Body: req.Body, Author: req.AuthorName, Email: req.AuthorEmail, IP: req.IP})
+	req.SpamScore = check.Score
+	req.SpamChecks = []entity.SpamCheckEntity{}
+	for _, verdict := range check.Verdicts {
+		req.SpamChecks = append(req.SpamChecks, entity.SpamCheckEntity{Filter: verdict.Filter, Score: verdict.Score, Reason: verdict.Reason})
+	}
+
+	switch {
+	case check.Score >= commentSpamThreshold:
+		req.Status = entity.CommentStatusSpam
+	case check.Score < commentReviewThreshold && c.cfg.Comment.Moderation == entity.CommentModerationPost:
+		req.Status = entity.CommentStatusApproved
+	default:
+		req.Status = entity.CommentStatusPending
+	}
+
+	result, err := c.commentRepository.CreateComment(ctx, req)
+	if err != nil {
+		code = "[SERVICE] CreateComment - 4"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	// Comments held only because of pre-moderation are routine; those the
+	// filters found suspicious are worth a look.
+	if result.Status == entity.CommentStatusPending && check.Score >= commentReviewThreshold {
+		c.notificationService.Notify(ctx, entity.NotificationEventEntity{
+			Type:      entity.NotificationCommentFlagged,
+			ContentID: content.ID,
+			CommentID: result.ID,
+			Message:   fmt.Sprintf("A comment by %s on %q was flagged for review", result.AuthorName, content.Title),
+		})
+	}
+
+	return result, nil
+}
+
+// GetComments implements CommentService.
+func (c *commentService) GetComments(ctx context.Context, query entity.QueryString) ([]entity.CommentEntity, int64, error) {
+	results, totalData, err := c.commentRepository.GetComments(ctx, query)
+	if err != nil {
+		code = "[SERVICE] GetComments - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	return results, totalData, nil
+}
+
+// ModerateComment implements CommentService. The decision trains the spam
+// classifier: approved comments count as ham, spam as spam, and a rejected
+// comment, which may be off topic rather than spam, is taken out of it.
+func (c *commentService) ModerateComment(ctx context.Context, id int64, status string, moderatorID int64) error {
+	err = c.commentRepository.ModerateComment(ctx, id, status, moderatorID)
+	if err != nil {
+		code = "[SERVICE] ModerateComment - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	comment, err := c.commentRepository.GetCommentByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] ModerateComment - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	label := ""
+	switch status {
+	case entity.CommentStatusApproved:
+		label = entity.CommentTrainedHam
+	case entity.CommentStatusSpam:
+		label = entity.CommentTrainedSpam
+	}
+
+	err = c.classifier.train(ctx, id, comment.Body, label)
+	if err != nil {
+		code = "[SERVICE] ModerateComment - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// BanCommentAuthor implements CommentService.
+func (c *commentService) BanCommentAuthor(ctx context.Context, id int64, reason string, moderatorID int64) (*entity.CommentBanEntity, error) {
+	result, err := c.commentRepository.BanCommentAuthor(ctx, id, strings.TrimSpace(reason), moderatorID)
+	if err != nil {
+		code = "[SERVICE] BanCommentAuthor - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetCommentBans implements CommentService.
+func (c *commentService) GetCommentBans(ctx context.Context, query entity.QueryString) ([]entity.CommentBanEntity, int64, error) {
+	results, totalData, err := c.commentRepository.GetCommentBans(ctx, query)
+	if err != nil {
+		code = "[SERVICE] GetCommentBans - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	return results, totalData, nil
+}
+
+// DeleteCommentBan implements CommentService.
+func (c *commentService) DeleteCommentBan(ctx context.Context, id int64) error {
+	err = c.commentRepository.DeleteCommentBan(ctx, id)
+	if err != nil {
+		code = "[SERVICE] DeleteCommentBan - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+func NewCommentService(commentRepo repository.CommentRepository, contentRepo repository.ContentRepository, notificationService NotificationService, cfg *config.Config) CommentService {
+	classifier := &spamClassifier{commentRepository: commentRepo}
+
+	return &commentService{
+		commentRepository:   commentRepo,
+		contentRepository:   contentRepo,
+		notificationService: notificationService,
+		cfg:                 cfg,
+		filters:             newCommentFilters(commentRepo, classifier, cfg),
+		classifier:          classifier,
+	}
+}
@@ -0,0 +1,479 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+	"news-app/lib/richtext"
+	"news-app/lib/slug"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+const (
+	// excerptMaxLength matches the size of the contents.exerpt column.
+	excerptMaxLength = 250
+
+	// MaxRelatedContents is the most related stories returned for one story;
+	// that many are always cached so every smaller limit is served from it.
+	MaxRelatedContents = 12
+	// relatedCacheTTL bounds how long newly published stories can be missing
+	// from the related lists of older ones.
+	relatedCacheTTL = 15 * time.Minute
+)
+
+var ErrMediaNotFound = errors.New("media not found")
+
+type ContentService interface {
+	GetContents(ctx context.Context, query entity.QueryString) ([]entity.ContentEntity, int64, error)
+	GetContentByID(ctx context.Context, id int64) (*entity.ContentEntity, error)
+	GetContentBySlug(ctx context.Context, contentSlug string) (*entity.ContentEntity, error)
+	CreateContent(ctx context.Context, req entity.ContentEntity) (*entity.ContentEntity, error)
+	UpdateContent(ctx context.Context, req entity.ContentEntity, editorID int64) (*entity.ContentEntity, error)
+	DeleteContent(ctx context.Context, id int64) error
+	GetRelatedContents(ctx context.Context, contentSlug string, limit int) ([]entity.ContentEntity, error)
+}
+
+type relatedCacheEntry struct {
+	tags     []string
+	contents []entity.ContentEntity
+	loadedAt time.Time
+}
+
+// contentEvent is the payload of the content domain events.
+type contentEvent struct {
+	ID          int64    `json:"id"`
+	Title       string   `json:"title"`
+	Slug        string   `json:"slug"`
+	Status      string   `json:"status"`
+	ContentType string   `json:"content_type"`
+	CategoryID  int64    `json:"category_id"`
+	Tags        []string `json:"tags"`
+	PublishedAt string   `json:"published_at,omitempty"`
+	UpdatedAt   string   `json:"updated_at,omitempty"`
+}
+
+type contentService struct {
+	contentRepository   repository.ContentRepository
+	mediaRepository     repository.MediaRepository
+	transactor          repository.Transactor
+	sitemapService      SitemapService
+	redirectService     RedirectService
+	imageService        ImageService
+	notificationService NotificationService
+	outboxService       OutboxService
+
+	relatedMu sync.RWMutex
+	related   map[int64]relatedCacheEntry
+}
+
+// GetContents implements ContentService.
+func (c *contentService) GetContents(ctx context.Context, query entity.QueryString) ([]entity.ContentEntity, int64, error) {
+	results, totalData, err := c.contentRepository.GetContents(ctx, query)
+	if err != nil {
+		code = "[SERVICE] GetContents - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	for i := range results {
+		c.setImageVariants(&results[i])
+	}
+
+	return results, totalData, nil
+}
+
+// GetContentByID implements ContentService.
+func (c *contentService) GetContentByID(ctx context.Context, id int64) (*entity.ContentEntity, error) {
+	result, err := c.contentRepository.GetContentByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] GetContentByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	c.setImageVariants(result)
+
+	return result, nil
+}
+
+// GetContentBySlug implements ContentService.
+func (c *contentService) GetContentBySlug(ctx context.Context, contentSlug string) (*entity.ContentEntity, error) {
+	result, err := c.contentRepository.GetContentBySlug(ctx, contentSlug, true)
+	if err != nil {
+		code = "[SERVICE] GetContentBySlug - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	c.setImageVariants(result)
+
+	return result, nil
+}
+
+// CreateContent implements ContentService.
+func (c *contentService) CreateContent(ctx context.Context, req entity.ContentEntity) (*entity.ContentEntity, error) {
+	req.Slug = slug.Generate(req.Title)
+	req.Tags = cleanTags(req.Tags)
+
+	err := c.renderDescription(&req)
+	if err != nil {
+		code = "[SERVICE] CreateContent - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	err = c.checkMedia(ctx, req.MediaID, req.OgMediaID)
+	if err != nil {
+		code = "[SERVICE] CreateContent - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	var result *entity.ContentEntity
+	err = c.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		created, err := c.contentRepository.CreateContent(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		result = created
+		return c.emitContentEvents(ctx, entity.EventContentCreated, "", *result)
+	})
+	if err != nil {
+		code = "[SERVICE] CreateContent - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	c.sitemapService.ContentChanged(result.ID, nil, result)
+	c.invalidateRelated(result.ID, result.Tags)
+	c.notifyStatusChange(ctx, "", *result, req.CreatedByID)
+
+	return c.GetContentByID(ctx, result.ID)
+}
+
+// UpdateContent implements ContentService.
+func (c *contentService) UpdateContent(ctx context.Context, req entity.ContentEntity, editorID int64) (*entity.ContentEntity, error) {
+	contentData, err := c.contentRepository.GetContentByID(ctx, req.ID)
+	if err != nil {
+		code = "[SERVICE] UpdateContent - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	req.Slug = contentData.Slug
+	if contentData.Title != req.Title {
+		req.Slug = slug.Generate(req.Title)
+	}
+	req.Tags = cleanTags(req.Tags)
+
+	err = c.renderDescription(&req)
+	if err != nil {
+		code = "[SERVICE] UpdateContent - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	err = c.checkMedia(ctx, req.MediaID, req.OgMediaID)
+	if err != nil {
+		code = "[SERVICE] UpdateContent - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	var result *entity.ContentEntity
+	err = c.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		updated, err := c.contentRepository.UpdateContent(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		result = updated
+		return c.emitContentEvents(ctx, entity.EventContentUpdated, contentData.Status, *result)
+	})
+	if err != nil {
+		code = "[SERVICE] UpdateContent - 4"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	c.sitemapService.ContentChanged(result.ID, contentData, result)
+	c.invalidateRelated(result.ID, append(contentData.Tags, result.Tags...))
+	if result.Slug != contentData.Slug {
+		c.redirectService.Invalidate()
+	}
+	result.CreatedByID = contentData.CreatedByID
+	c.notifyStatusChange(ctx, contentData.Status, *result, editorID)
+
+	return c.GetContentByID(ctx, result.ID)
+}
+
+// DeleteContent implements ContentService.
+func (c *contentService) DeleteContent(ctx context.Context, id int64) error {
+	var contentData *entity.ContentEntity
+	err = c.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		contentData, err = c.contentRepository.GetContentByID(ctx, id)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		err = c.contentRepository.DeleteContent(ctx, id)
+		if err != nil || contentData == nil {
+			return err
+		}
+
+		return c.emitContentEvents(ctx, entity.EventContentDeleted, contentData.Status, *contentData)
+	})
+	if err != nil {
+		code = "[SERVICE] DeleteContent - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	c.sitemapService.ContentChanged(id, contentData, nil)
+	c.invalidateRelated(id, nil)
+
+	return nil
+}
+
+// GetRelatedContents implements ContentService.
+func (c *contentService) GetRelatedContents(ctx context.Context, contentSlug string, limit int) ([]entity.ContentEntity, error) {
+	content, err := c.contentRepository.GetContentBySlug(ctx, contentSlug, true)
+	if err != nil {
+		code = "[SERVICE] GetRelatedContents - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if limit <= 0 || limit > MaxRelatedContents {
+		limit = MaxRelatedContents
+	}
+
+	c.relatedMu.RLock()
+	cached, ok := c.related[content.ID]
+	c.relatedMu.RUnlock()
+	if ok && time.Since(cached.loadedAt) < relatedCacheTTL {
+		return cached.contents[:min(limit, len(cached.contents))], nil
+	}
+
+	results, err := c.contentRepository.GetRelatedContents(ctx, *content, MaxRelatedContents)
+	if err != nil {
+		code = "[SERVICE] GetRelatedContents - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	for i := range results {
+		c.setImageVariants(&results[i])
+	}
+
+	c.relatedMu.Lock()
+	c.related[content.ID] = relatedCacheEntry{tags: content.Tags, contents: results, loadedAt: time.Now()}
+	c.relatedMu.Unlock()
+
+	return results[:min(limit, len(results))], nil
+}
+
+// notifyStatusChange tells the newsroom a story was submitted for review or
+// published, and its author that the review approved it.
+func (c *contentService) notifyStatusChange(ctx context.Context, previous string, content entity.ContentEntity, actorID int64) {
+	if content.Status == previous {
+		return
+	}
+
+	switch content.Status {
+	case entity.ContentStatusReview:
+		c.notificationService.Notify(ctx, entity.NotificationEventEntity{
+			Type:      entity.NotificationContentSubmitted,
+			ActorID:   actorID,
+			ContentID: content.ID,
+			Message:   fmt.Sprintf("%q was submitted for review", content.Title),
+		})
+	case entity.ContentStatusPublish:
+		if previous == entity.ContentStatusReview && content.CreatedByID > 0 {
+			c.notificationService.Notify(ctx, entity.NotificationEventEntity{
+				Type:        entity.NotificationContentApproved,
+				ActorID:     actorID,
+				RecipientID: content.CreatedByID,
+				ContentID:   content.ID,
+				Message:     fmt.Sprintf("%q was approved", content.Title),
+			})
+		}
+
+		c.notificationService.Notify(ctx, entity.NotificationEventEntity{
+			Type:      entity.NotificationContentPublished,
+			ActorID:   actorID,
+			ContentID: content.ID,
+			Message:   fmt.Sprintf("%q was published", content.Title),
+		})
+	}
+}
+
+// emitContentEvents records eventType for content, followed by the
+// publication or unpublication it brought, in the transaction of ctx.
+func (c *contentService) emitContentEvents(ctx context.Context, eventType, previous string, content entity.ContentEntity) error {
+	eventTypes := []string{eventType}
+	switch {
+	case content.Status == entity.ContentStatusPublish && previous != entity.ContentStatusPublish:
+		eventTypes = append(eventTypes, entity.EventContentPublished)
+	case content.Status != entity.ContentStatusPublish && previous == entity.ContentStatusPublish:
+		eventTypes = append(eventTypes, entity.EventContentUnpublished)
+	}
+
+	data := contentEvent{
+		ID:          content.ID,
+		Title:       content.Title,
+		Slug:        content.Slug,
+		Status:      content.Status,
+		ContentType: content.ContentType,
+		CategoryID:  content.CategoryID,
+		Tags:        content.Tags,
+	}
+	if data.Tags == nil {
+		data.Tags = []string{}
+	}
+	if content.PublishedAt != nil {
+		data.PublishedAt = content.PublishedAt.Format(time.RFC3339)
+	}
+	if content.UpdatedAt != nil {
+		data.UpdatedAt = content.UpdatedAt.Format(time.RFC3339)
+	}
+
+	for _, eventType := range eventTypes {
+		err := c.outboxService.Emit(ctx, entity.AggregateContent, content.ID, eventType, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *contentService) setImageVariants(content *entity.ContentEntity) {
+	content.ImageVariants = c.imageService.ImageVariants(content.Media)
+	if content.Media != nil {
+		content.Media.Variants = content.ImageVariants
+	}
+}
+
+// renderDescription stores the sanitized HTML of the description next to
+// its source, so readers never get markup that was not cleaned, and derives
+// the reading stats and a missing excerpt from it.
+func (c *contentService) renderDescription(content *entity.ContentEntity) error {
+	if content.DescriptionFormat == "" {
+		content.DescriptionFormat = richtext.FormatHTML
+	}
+
+	rendered, err := richtext.Render(content.DescriptionFormat, content.Description)
+	if err != nil {
+		return err
+	}
+
+	content.DescriptionHTML = rendered
+	content.WordCount = richtext.WordCount(rendered)
+	content.ReadingTime = richtext.ReadingTime(content.WordCount)
+
+	content.Excerpt = strings.TrimSpace(content.Excerpt)
+	if content.Excerpt == "" {
+		content.Excerpt = richtext.Excerpt(rendered, excerptMaxLength)
+	}
+
+	return nil
+}
+
+// checkMedia makes sure media exist before they are linked, so a bad id is
+// reported as such rather than as a foreign key violation.
+func (c *contentService) checkMedia(ctx context.Context, mediaIDs ...int64) error {
+	for _, mediaID := range mediaIDs {
+		if mediaID == 0 {
+			continue
+		}
+
+		_, err := c.mediaRepository.GetMediaByID(ctx, mediaID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrMediaNotFound
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// invalidateRelated drops the cached related stories of the content with id,
+// every list it appears in, and the lists of stories sharing one of tags,
+// since it may now belong in or drop out of them.
+func (c *contentService) invalidateRelated(id int64, tags []string) {
+	changed := map[string]bool{}
+	for _, tag := range tags {
+		changed[strings.ToLower(tag)] = true
+	}
+
+	c.relatedMu.Lock()
+	defer c.relatedMu.Unlock()
+
+	delete(c.related, id)
+	for key, entry := range c.related {
+		if relatedEntryAffected(entry, id, changed) {
+			delete(c.related, key)
+		}
+	}
+}
+
+func relatedEntryAffected(entry relatedCacheEntry, id int64, tags map[string]bool) bool {
+	for _, content := range entry.contents {
+		if content.ID == id {
+			return true
+		}
+	}
+
+	for _, tag := range entry.tags {
+		if tags[strings.ToLower(tag)] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cleanTags trims tags and drops empty and duplicate ones, since they are
+// stored as a comma separated list.
+func cleanTags(tags []string) []string {
+	res := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(strings.ReplaceAll(tag, ",", " "))
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+
+		seen[strings.ToLower(tag)] = true
+		res = append(res, tag)
+	}
+
+	return res
+}
+
+func NewContentService(contentRepo repository.ContentRepository, mediaRepo repository.MediaRepository, transactor repository.Transactor, sitemapService SitemapService, redirectService RedirectService, imageService ImageService, notificationService NotificationService, outboxService OutboxService) ContentService {
+	return &contentService{
+		contentRepository:   contentRepo,
+		mediaRepository:     mediaRepo,
+		transactor:          transactor,
+		sitemapService:      sitemapService,
+		redirectService:     redirectService,
+		imageService:        imageService,
+		notificationService: notificationService,
+		outboxService:       outboxService,
+		related:             map[int64]relatedCacheEntry{},
+	}
+}
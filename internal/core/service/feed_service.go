@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+
+	"news-app/config"
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+	"news-app/lib/feed"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+const feedItemLimit = 50
+
+type FeedService interface {
+	GetSiteFeed(ctx context.Context) (*feed.Feed, error)
+	GetCategoryFeed(ctx context.Context, categorySlug string) (*feed.Feed, error)
+	GetTagFeed(ctx context.Context, tag string) (*feed.Feed, error)
+}
+
+type feedService struct {
+	contentRepository  repository.ContentRepository
+	categoryRepository repository.CategoryRepository
+	cfg                *config.Config
+}
+
+// GetSiteFeed implements FeedService.
+func (f *feedService) GetSiteFeed(ctx context.Context) (*feed.Feed, error) {
+	res, err := f.buildFeed(ctx, entity.QueryString{})
+	if err != nil {
+		code = "[SERVICE] GetSiteFeed - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res.Title = f.cfg.App.SiteName
+	res.Description = "Latest stories from " + f.cfg.App.SiteName
+	res.Link = f.cfg.SiteURL()
+	return res, nil
+}
+
+// GetCategoryFeed implements FeedService.
+func (f *feedService) GetCategoryFeed(ctx context.Context, categorySlug string) (*feed.Feed, error) {
+	category, err := f.categoryRepository.GetCategoryBySlug(ctx, categorySlug)
+	if err != nil {
+		code = "[SERVICE] GetCategoryFeed - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res, err := f.buildFeed(ctx, entity.QueryString{CategoryID: int64(category.ID)})
+	if err != nil {
+		code = "[SERVICE] GetCategoryFeed - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res.Title = category.Title + " - " + f.cfg.App.SiteName
+	res.Description = "Latest " + category.Title + " stories from " + f.cfg.App.SiteName
+	res.Link = f.cfg.CategoryURL(category.Slug)
+	return res, nil
+}
+
+// GetTagFeed implements FeedService.
+func (f *feedService) GetTagFeed(ctx context.Context, tag string) (*feed.Feed, error) {
+	res, err := f.buildFeed(ctx, entity.QueryString{Tag: tag})
+	if err != nil {
+		code = "[SERVICE] GetTagFeed - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res.Title = "#" + tag + " - " + f.cfg.App.SiteName
+	res.Description = "Latest stories tagged " + tag + " from " + f.cfg.App.SiteName
+	res.Link = f.cfg.TagURL(tag)
+	return res, nil
+}
+
+func (f *feedService) buildFeed(ctx context.Context, query entity.QueryString) (*feed.Feed, error) {
+	query.Status = entity.ContentStatusPublish
+	query.Limit = feedItemLimit
+	query.OrderBy = "published_at"
+	query.Page = 1
+
+	contents, _, err := f.contentRepository.GetContents(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &feed.Feed{Language: "id"}
+	for _, content := range contents {
+		item := feed.Item{
+			ID:          f.cfg.ContentURL(content.Slug),
+			Title:       content.Title,
+			Link:        f.cfg.ContentURL(content.Slug),
+			Summary:     content.Excerpt,
+			ContentHTML: content.DescriptionHTML,
+			Author:      content.User.Name,
+			Categories:  append([]string{content.Category.Title}, content.Tags...),
+			Image:       content.Image,
+			Published:   content.CreatedAt,
+		}
+		if content.Media != nil {
+			item.ImageSize = content.Media.Size
+			item.ImageType = content.Media.MimeType
+		}
+		if content.PublishedAt != nil {
+			item.Published = *content.PublishedAt
+		}
+		if content.UpdatedAt != nil {
+			item.Updated = *content.UpdatedAt
+		}
+
+		if item.Published.After(res.Updated) {
+			res.Updated = item.Published
+		}
+		if item.Updated.After(res.Updated) {
+			res.Updated = item.Updated
+		}
+
+		res.Items = append(res.Items, item)
+	}
+
+	return res, nil
+}
+
+func NewFeedService(contentRepo repository.ContentRepository, categoryRepo repository.CategoryRepository, cfg *config.Config) FeedService {
+	return &feedService{contentRepository: contentRepo, categoryRepository: categoryRepo, cfg: cfg}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"news-app/config"
+	"news-app/internal/adapter/cloudflare"
+	"news-app/internal/core/domain/entity"
+	"news-app/lib/imaging"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+type ImageService interface {
+	UploadImage(ctx context.Context, data []byte) (*entity.ImageEntity, error)
+	ImageVariants(media *entity.MediaEntity) []entity.ImageVariantEntity
+	VariantURLs(imageURL string) []string
+}
+
+type imageService struct {
+	r2       cloudflare.CloudflareR2Adapter
+	cfg      *config.Config
+	variants []imaging.Variant
+}
+
+// UploadImage implements ImageService. The original is re-encoded so no
+// EXIF or GPS metadata is stored, and every configured variant is written
+// next to it as <key>-<variant><ext>.
+func (i *imageService) UploadImage(ctx context.Context, data []byte) (*entity.ImageEntity, error) {
+	img, err := imaging.Decode(data, i.cfg.Image.MaxPixels)
+	if err != nil {
+		code = "[SERVICE] UploadImage - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	original, err := imaging.Encode(img)
+	if err != nil {
+		code = "[SERVICE] UploadImage - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	now := time.Now()
+	baseKey := fmt.Sprintf("images/%d/%02d/%s", now.Year(), now.Month(), uuid.NewString())
+
+	originalURL, err := i.r2.PutObject(ctx, baseKey+original.Extension, original.Body, original.ContentType)
+	if err != nil {
+		code = "[SERVICE] UploadImage - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := &entity.ImageEntity{
+		URL:      originalURL,
+		MimeType: original.ContentType,
+		Size:     int64(len(original.Body)),
+		Width:    original.Width,
+		Height:   original.Height,
+	}
+
+	for _, variant := range i.variants {
+		encoded, err := imaging.EncodeLike(imaging.Fill(img, variant), original)
+		if err != nil {
+			code = "[SERVICE] UploadImage - 4"
+			log.Errorw(code, err)
+			return nil, err
+		}
+
+		// Variants share the original's extension so their URLs can be
+		// derived from the original's alone.
+		variantURL, err := i.r2.PutObject(ctx, baseKey+"-"+variant.Name+encoded.Extension, encoded.Body, encoded.ContentType)
+		if err != nil {
+			code = "[SERVICE] UploadImage - 5"
+			log.Errorw(code, err)
+			return nil, err
+		}
+
+		res.Variants = append(res.Variants, entity.ImageVariantEntity{
+			Name:   variant.Name,
+			URL:    variantURL,
+			Width:  encoded.Width,
+			Height: encoded.Height,
+		})
+	}
+
+	return res, nil
+}
+
+// ImageVariants implements ImageService. It returns the variants stored
+// for media with their URLs and encoded sizes, or nil when media was not
+// uploaded through UploadImage.
+func (i *imageService) ImageVariants(media *entity.MediaEntity) []entity.ImageVariantEntity {
+	if media == nil || !i.uploaded(media.URL) {
+		return nil
+	}
+
+	variants := media.Variants
+	// Rows from before the encoded sizes were stored got every configured
+	// variant, at the size Fill gave it.
+	if len(variants) == 0 && media.Width > 0 && media.Height > 0 {
+		for _, variant := range i.variants {
+			width, height := imaging.FillSize(media.Width, media.Height, variant)
+			variants = append(variants, entity.ImageVariantEntity{Name: variant.Name, Width: width, Height: height})
+		}
+	}
+
+	res := []entity.ImageVariantEntity{}
+	for _, variant := range variants {
+		variant.URL = variantURL(media.URL, variant.Name)
+		res = append(res, variant)
+	}
+
+	return res
+}
+
+// VariantURLs implements ImageService. It returns the URLs of the
+// configured variants of an image uploaded through UploadImage, or nil for
+// any other URL.
+func (i *imageService) VariantURLs(imageURL string) []string {
+	if !i.uploaded(imageURL) {
+		return nil
+	}
+
+	res := []string{}
+	for _, variant := range i.variants {
+		res = append(res, variantURL(imageURL, variant.Name))
+	}
+
+	return res
+}
+
+func (i *imageService) uploaded(imageURL string) bool {
+	return imageURL != "" && strings.HasPrefix(imageURL, i.r2.PublicURL("images/"))
+}
+
+func variantURL(imageURL, name string) string {
+	ext := path.Ext(imageURL)
+	return strings.TrimSuffix(imageURL, ext) + "-" + name + ext
+}
+
+func NewImageService(r2 cloudflare.CloudflareR2Adapter, cfg *config.Config) ImageService {
+	variants, err := imaging.ParseVariants(cfg.Image.Variants)
+	if err != nil {
+		log.Errorw("[SERVICE] NewImageService - 1", err)
+		variants, _ = imaging.ParseVariants(imaging.DefaultVariants)
+	}
+
+	return &imageService{r2: r2, cfg: cfg, variants: variants}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+var ErrReadingHistoryDisabled = errors.New("reading history is turned off for this account")
+
+type LibraryService interface {
+	GetBookmarks(ctx context.Context, readerID int64, query entity.QueryString) ([]entity.BookmarkEntity, int64, error)
+	AddBookmark(ctx context.Context, readerID, contentID int64) error
+	DeleteBookmark(ctx context.Context, readerID, contentID int64) error
+	GetReadingHistory(ctx context.Context, readerID int64, query entity.QueryString) ([]entity.ReadingHistoryEntity, int64, error)
+	SaveReadingHistory(ctx context.Context, req entity.ReadingHistoryEntity) error
+	DeleteReadingHistory(ctx context.Context, readerID, contentID int64) error
+	ClearReadingHistory(ctx context.Context, readerID int64) (int64, error)
+}
+
+type libraryService struct {
+	libraryRepository repository.LibraryRepository
+	readerRepository  repository.ReaderRepository
+	contentRepository repository.ContentRepository
+	imageService      ImageService
+}
+
+// GetBookmarks implements LibraryService.
+func (l *libraryService) GetBookmarks(ctx context.Context, readerID int64, query entity.QueryString) ([]entity.BookmarkEntity, int64, error) {
+	results, totalData, err := l.libraryRepository.GetBookmarks(ctx, readerID, query)
+	if err != nil {
+		code = "[SERVICE] GetBookmarks - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	for i := range results {
+		results[i].Content.ImageVariants = l.imageService.ImageVariants(results[i].Content.Media)
+	}
+
+	return results, totalData, nil
+}
+
+// AddBookmark implements LibraryService.
+func (l *libraryService) AddBookmark(ctx context.Context, readerID, contentID int64) error {
+	err = l.checkPublished(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] AddBookmark - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = l.libraryRepository.AddBookmark(ctx, readerID, contentID)
+	if err != nil {
+		code = "[SERVICE] AddBookmark - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteBookmark implements LibraryService.
+func (l *libraryService) DeleteBookmark(ctx context.Context, readerID, contentID int64) error {
+	err = l.libraryRepository.DeleteBookmark(ctx, readerID, contentID)
+	if err != nil {
+		code = "[SERVICE] DeleteBookmark - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetReadingHistory implements LibraryService.
+func (l *libraryService) GetReadingHistory(ctx context.Context, readerID int64, query entity.QueryString) ([]entity.ReadingHistoryEntity, int64, error) {
+	results, totalData, err := l.libraryRepository.GetReadingHistory(ctx, readerID, query)
+	if err != nil {
+		code = "[SERVICE] GetReadingHistory - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	for i := range results {
+		results[i].Content.ImageVariants = l.imageService.ImageVariants(results[i].Content.Media)
+	}
+
+	return results, totalData, nil
+}
+
+// SaveReadingHistory implements LibraryService. Nothing is recorded for
+// readers who have not turned the history on.
+func (l *libraryService) SaveReadingHistory(ctx context.Context, req entity.ReadingHistoryEntity) error {
+	reader, err := l.readerRepository.GetReaderByID(ctx, req.ReaderID)
+	if err != nil {
+		code = "[SERVICE] SaveReadingHistory - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	if !reader.HistoryEnabled {
+		return ErrReadingHistoryDisabled
+	}
+
+	err = l.checkPublished(ctx, req.ContentID)
+	if err != nil {
+		code = "[SERVICE] SaveReadingHistory - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	req.Position = min(max(req.Position, 0), 100)
+
+	err = l.libraryRepository.SaveReadingHistory(ctx, req)
+	if err != nil {
+		code = "[SERVICE] SaveReadingHistory - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteReadingHistory implements LibraryService.
+func (l *libraryService) DeleteReadingHistory(ctx context.Context, readerID, contentID int64) error {
+	err = l.libraryRepository.DeleteReadingHistory(ctx, readerID, contentID)
+	if err != nil {
+		code = "[SERVICE] DeleteReadingHistory - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// ClearReadingHistory implements LibraryService.
+func (l *libraryService) ClearReadingHistory(ctx context.Context, readerID int64) (int64, error) {
+	deleted, err := l.libraryRepository.ClearReadingHistory(ctx, readerID)
+	if err != nil {
+		code = "[SERVICE] ClearReadingHistory - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// checkPublished reports drafts as missing, so readers cannot learn of
+// stories before they are out.
+func (l *libraryService) checkPublished(ctx context.Context, contentID int64) error {
+	content, err := l.contentRepository.GetContentByID(ctx, contentID)
+	if err != nil {
+		return err
+	}
+
+	if content.Status != entity.ContentStatusPublish {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func NewLibraryService(libraryRepo repository.LibraryRepository, readerRepo repository.ReaderRepository, contentRepo repository.ContentRepository, imageService ImageService) LibraryService {
+	return &libraryService{
+		libraryRepository: libraryRepo,
+		readerRepository:  readerRepo,
+		contentRepository: contentRepo,
+		imageService:      imageService,
+	}
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+	"news-app/lib/richtext"
+	"news-app/lib/sse"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+const (
+	// liveBlogReplayLimit is the most events replayed to a reader coming
+	// back; one who missed more is told to reload instead.
+	liveBlogReplayLimit = 500
+
+	// LiveBlogEventReset asks readers to reload the whole live blog.
+	LiveBlogEventReset = "reset"
+)
+
+var ErrNotLiveBlog = errors.New("content is not a live blog")
+
+type LiveBlogService interface {
+	GetLiveBlog(ctx context.Context, contentSlug string, query entity.QueryString) (*entity.LiveBlogEntity, int64, error)
+	GetEntries(ctx context.Context, contentID int64, query entity.QueryString) ([]entity.LiveBlogEntryEntity, int64, error)
+	CreateEntry(ctx context.Context, req entity.LiveBlogEntryEntity) (*entity.LiveBlogEntryEntity, error)
+	UpdateEntry(ctx context.Context, req entity.LiveBlogEntryEntity) (*entity.LiveBlogEntryEntity, error)
+	DeleteEntry(ctx context.Context, contentID, id int64) error
+	OpenStream(ctx context.Context, contentSlug string, lastEventID int64) (*LiveBlogStream, error)
+	Close()
+}
+
+// LiveBlogStream is one reader following a live blog: the events they
+// missed, then the live ones. Live events up to LastEventID were already
+// part of Replay and must be skipped.
+type LiveBlogStream struct {
+	Replay      []sse.Event
+	Events      <-chan sse.Event
+	LastEventID int64
+
+	subscription *sse.Subscription
+}
+
+// Close stops following the live blog.
+func (s *LiveBlogStream) Close() {
+	s.subscription.Close()
+}
+
+type liveBlogEntryPayload struct {
+	ID        int64  `json:"id"`
+	Title     string `json:"title,omitempty"`
+	BodyHTML  string `json:"body_html,omitempty"`
+	Pinned    bool   `json:"pinned,omitempty"`
+	Author    string `json:"author,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type liveBlogService struct {
+	liveBlogRepository repository.LiveBlogRepository
+	contentRepository  repository.ContentRepository
+	hub                *sse.Hub
+}
+
+// GetLiveBlog implements LiveBlogService.
+func (l *liveBlogService) GetLiveBlog(ctx context.Context, contentSlug string, query entity.QueryString) (*entity.LiveBlogEntity, int64, error) {
+	content, err := l.publishedLiveBlog(ctx, contentSlug)
+	if err != nil {
+		code = "[SERVICE] GetLiveBlog - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	// The last event id is read first, so a reader streaming from it gets
+	// every change made while the page was loading, at worst twice.
+	lastEventID, err := l.liveBlogRepository.GetLastEventID(ctx, content.ID)
+	if err != nil {
+		code = "[SERVICE] GetLiveBlog - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	entries, totalData, err := l.liveBlogRepository.GetEntries(ctx, content.ID, query)
+	if err != nil {
+		code = "[SERVICE] GetLiveBlog - 3"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	return &entity.LiveBlogEntity{ContentID: content.ID, LastEventID: lastEventID, Entries: entries}, totalData, nil
+}
+
+// GetEntries implements LiveBlogService.
+func (l *liveBlogService) GetEntries(ctx context.Context, contentID int64, query entity.QueryString) ([]entity.LiveBlogEntryEntity, int64, error) {
+	results, totalData, err := l.liveBlogRepository.GetEntries(ctx, contentID, query)
+	if err != nil {
+		code = "[SERVICE] GetEntries - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	return results, totalData, nil
+}
+
+// CreateEntry implements LiveBlogService.
+func (l *liveBlogService) CreateEntry(ctx context.Context, req entity.LiveBlogEntryEntity) (*entity.LiveBlogEntryEntity, error) {
+	err = l.prepareEntry(ctx, &req)
+	if err != nil {
+		code = "[SERVICE] CreateEntry - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	result, event, err := l.liveBlogRepository.CreateEntry(ctx, req)
+	if err != nil {
+		code = "[SERVICE] CreateEntry - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	l.publish(*event, result)
+	return result, nil
+}
+
+// UpdateEntry implements LiveBlogService.
+func (l *liveBlogService) UpdateEntry(ctx context.Context, req entity.LiveBlogEntryEntity) (*entity.LiveBlogEntryEntity, error) {
+	err = l.prepareEntry(ctx, &req)
+	if err != nil {
+		code = "[SERVICE] UpdateEntry - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	result, event, err := l.liveBlogRepository.UpdateEntry(ctx, req)
+	if err != nil {
+		code = "[SERVICE] UpdateEntry - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	l.publish(*event, result)
+	return result, nil
+}
+
+// DeleteEntry implements LiveBlogService.
+func (l *liveBlogService) DeleteEntry(ctx context.Context, contentID, id int64) error {
+	event, err := l.liveBlogRepository.DeleteEntry(ctx, contentID, id)
+	if err != nil {
+		code = "[SERVICE] DeleteEntry - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	l.publish(*event, nil)
+	return nil
+}
+
+// OpenStream implements LiveBlogService. The reader is subscribed before
+// the missed events are read, so nothing falls between the two.
+func (l *liveBlogService) OpenStream(ctx context.Context, contentSlug string, lastEventID int64) (*LiveBlogStream, error) {
+	content, err := l.publishedLiveBlog(ctx, contentSlug)
+	if err != nil {
+		code = "[SERVICE] OpenStream - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	subscription := l.hub.Subscribe(liveBlogTopic(content.ID))
+	stream := &LiveBlogStream{
+		Replay:       []sse.Event{},
+		Events:       subscription.C,
+		LastEventID:  lastEventID,
+		subscription: subscription,
+	}
+	if lastEventID <= 0 {
+		return stream, nil
+	}
+
+	events, err := l.liveBlogRepository.GetEventsSince(ctx, content.ID, lastEventID, liveBlogReplayLimit)
+	if err != nil {
+		subscription.Close()
+		code = "[SERVICE] OpenStream - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+	if len(events) == 0 {
+		return stream, nil
+	}
+
+	stream.LastEventID = events[len(events)-1].ID
+	if len(events) == liveBlogReplayLimit {
+		stream.LastEventID, err = l.liveBlogRepository.GetLastEventID(ctx, content.ID)
+		if err != nil {
+			subscription.Close()
+			code = "[SERVICE] OpenStream - 3"
+			log.Errorw(code, err)
+			return nil, err
+		}
+
+		stream.Replay = append(stream.Replay, sse.Event{ID: stream.LastEventID, Type: LiveBlogEventReset, Data: "{}"})
+		return stream, nil
+	}
+
+	stream.Replay, err = l.replay(ctx, content.ID, events)
+	if err != nil {
+		subscription.Close()
+		code = "[SERVICE] OpenStream - 4"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+// Close implements LiveBlogService. Open streams end, so the server can
+// shut down.
+func (l *liveBlogService) Close() {
+	l.hub.Close()
+}
+
+// replay turns missed events into what to send: entries are sent as they
+// are now, once each, at the position of their last change.
+func (l *liveBlogService) replay(ctx context.Context, contentID int64, events []entity.LiveBlogEventEntity) ([]sse.Event, error) {
+	last := map[int64]entity.LiveBlogEventEntity{}
+	for _, event := range events {
+		last[event.EntryID] = event
+	}
+
+	ids := make([]int64, 0, len(last))
+	changes := make([]entity.LiveBlogEventEntity, 0, len(last))
+	for id, event := range last {
+		ids = append(ids, id)
+		changes = append(changes, event)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ID < changes[j].ID })
+
+	entries, err := l.liveBlogRepository.GetEntriesByID(ctx, contentID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	current := map[int64]*entity.LiveBlogEntryEntity{}
+	for i := range entries {
+		current[entries[i].ID] = &entries[i]
+	}
+
+	res := []sse.Event{}
+	for _, event := range changes {
+		entry := current[event.EntryID]
+		if entry == nil {
+			event.Type = entity.LiveBlogEventDeleted
+		}
+
+		res = append(res, liveBlogEvent(event, entry))
+	}
+
+	return res, nil
+}
+
+func (l *liveBlogService) publish(event entity.LiveBlogEventEntity, entry *entity.LiveBlogEntryEntity) {
+	l.hub.Publish(liveBlogTopic(event.ContentID), liveBlogEvent(event, entry))
+}
+
+// prepareEntry checks the entry belongs to a live blog and renders its
+// body.
+func (l *liveBlogService) prepareEntry(ctx context.Context, req *entity.LiveBlogEntryEntity) error {
+	content, err := l.contentRepository.GetContentByID(ctx, req.ContentID)
+	if err != nil {
+		return err
+	}
+
+	if content.ContentType != entity.ContentTypeLiveBlog {
+		return ErrNotLiveBlog
+	}
+
+	if req.BodyFormat == "" {
+		req.BodyFormat = richtext.FormatHTML
+	}
+	req.Title = strings.TrimSpace(req.Title)
+	req.BodyHTML, err = richtext.Render(req.BodyFormat, req.Body)
+	return err
+}
+
+// publishedLiveBlog reports drafts and other contents as missing.
+func (l *liveBlogService) publishedLiveBlog(ctx context.Context, contentSlug string) (*entity.ContentEntity, error) {
+	content, err := l.contentRepository.GetContentBySlug(ctx, contentSlug, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if content.ContentType != entity.ContentTypeLiveBlog {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return content, nil
+}
+
+func liveBlogEvent(event entity.LiveBlogEventEntity, entry *entity.LiveBlogEntryEntity) sse.Event {
+	payload := liveBlogEntryPayload{ID: event.EntryID}
+	if entry != nil && event.Type != entity.LiveBlogEventDeleted {
+		payload.Title = entry.Title
+		payload.BodyHTML = entry.BodyHTML
+		payload.Pinned = entry.Pinned
+		payload.Author = entry.Author
+		payload.CreatedAt = entry.CreatedAt.Format(time.RFC3339)
+		if entry.UpdatedAt != nil {
+			payload.UpdatedAt = entry.UpdatedAt.Format(time.RFC3339)
+		}
+	}
+
+	data, _ := json.Marshal(payload)
+	return sse.Event{ID: event.ID, Type: event.Type, Data: string(data)}
+}
+
+func liveBlogTopic(contentID int64) string {
+	return fmt.Sprintf("live-blog:%d", contentID)
+}
+
+func NewLiveBlogService(liveBlogRepo repository.LiveBlogRepository, contentRepo repository.ContentRepository) LiveBlogService {
+	return &liveBlogService{
+		liveBlogRepository: liveBlogRepo,
+		contentRepository:  contentRepo,
+		hub:                sse.NewHub(),
+	}
+}
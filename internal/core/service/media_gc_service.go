@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"news-app/internal/adapter/cloudflare"
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type MediaGCService interface {
+	CollectOrphans(ctx context.Context, prefix string, minAge time.Duration, apply bool) (*entity.MediaGCReportEntity, error)
+}
+
+type mediaGCService struct {
+	mediaRepository repository.MediaRepository
+	r2              cloudflare.CloudflareR2Adapter
+	imageService    ImageService
+}
+
+// CollectOrphans implements MediaGCService. An object is an orphan when no
+// media row, pending upload or content body refers to it, either directly
+// or as a variant of a referenced image. Objects younger than minAge are
+// never reported, which also covers uploads racing with the scan. Orphans
+// are only deleted when apply is set.
+func (m *mediaGCService) CollectOrphans(ctx context.Context, prefix string, minAge time.Duration, apply bool) (*entity.MediaGCReportEntity, error) {
+	res := &entity.MediaGCReportEntity{
+		Prefix:    prefix,
+		MinAge:    minAge,
+		Applied:   apply,
+		Orphans:   []entity.MediaGCObjectEntity{},
+		StartedAt: time.Now(),
+	}
+
+	// The bucket is listed before the references are loaded, so anything
+	// registered during the scan is already known when objects are checked.
+	var candidates []cloudflare.ObjectInfo
+	cutoff := res.StartedAt.Add(-minAge)
+	err := m.r2.ListObjects(ctx, prefix, func(object cloudflare.ObjectInfo) error {
+		res.ScannedObjects++
+		if object.LastModified.After(cutoff) {
+			res.TooRecent++
+			return nil
+		}
+
+		candidates = append(candidates, object)
+		return nil
+	})
+	if err != nil {
+		code = "[SERVICE] CollectOrphans - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	referenced, err := m.referencedURLs(ctx)
+	if err != nil {
+		code = "[SERVICE] CollectOrphans - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	for _, object := range candidates {
+		if referenced[m.r2.PublicURL(object.Key)] {
+			continue
+		}
+
+		orphan := entity.MediaGCObjectEntity{
+			Key:          object.Key,
+			Size:         object.Size,
+			LastModified: object.LastModified,
+		}
+		if apply {
+			err = m.r2.DeleteObject(ctx, object.Key)
+			if err != nil {
+				orphan.Error = err.Error()
+			} else {
+				orphan.Deleted = true
+				res.Deleted++
+			}
+		}
+
+		res.Orphans = append(res.Orphans, orphan)
+		res.OrphanBytes += object.Size
+	}
+
+	res.FinishedAt = time.Now()
+	return res, nil
+}
+
+func (m *mediaGCService) referencedURLs(ctx context.Context) (map[string]bool, error) {
+	res := map[string]bool{}
+
+	files, err := m.mediaRepository.GetMediaFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range files {
+		// The variants stored with a row are the ones it links to, whatever
+		// the configuration is now.
+		if len(files[i].Variants) == 0 {
+			m.addReference(res, files[i].URL)
+			continue
+		}
+
+		res[files[i].URL] = true
+		for _, variant := range m.imageService.ImageVariants(&files[i]) {
+			res[variant.URL] = true
+		}
+	}
+
+	keys, err := m.mediaRepository.GetUploadKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		res[m.r2.PublicURL(key)] = true
+	}
+
+	// Editors also paste bucket URLs straight into article bodies and live
+	// blog entries.
+	publicURL := m.r2.PublicURL("")
+	descriptions, err := m.mediaRepository.GetDescriptionsContaining(ctx, publicURL)
+	if err != nil {
+		return nil, err
+	}
+
+	bodies, err := m.mediaRepository.GetLiveBlogBodiesContaining(ctx, publicURL)
+	if err != nil {
+		return nil, err
+	}
+	descriptions = append(descriptions, bodies...)
+
+	pattern := regexp.MustCompile(regexp.QuoteMeta(publicURL) + `[^\s"'<>()?#]+`)
+	for _, description := range descriptions {
+		for _, url := range pattern.FindAllString(description, -1) {
+			m.addReference(res, url)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mediaGCService) addReference(refs map[string]bool, url string) {
+	refs[url] = true
+	for _, variantURL := range m.imageService.VariantURLs(url) {
+		refs[variantURL] = true
+	}
+}
+
+func NewMediaGCService(mediaRepo repository.MediaRepository, r2 cloudflare.CloudflareR2Adapter, imageService ImageService) MediaGCService {
+	return &mediaGCService{mediaRepository: mediaRepo, r2: r2, imageService: imageService}
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"news-app/internal/adapter/cloudflare"
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+	"news-app/lib/imaging"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	uploadURLExpiry = 15 * time.Minute
+
+	// uploadCleanupGrace is how long a pending upload is kept after its URL
+	// expires, so a slow upload started just before expiry has finished
+	// before its object is deleted.
+	uploadCleanupGrace = 24 * time.Hour
+	uploadCleanupBatch = 100
+
+	// uploadHeadLength is enough to sniff the MIME type.
+	uploadHeadLength = 64 * 1024
+)
+
+type uploadType struct {
+	extension string
+	maxSize   int64
+}
+
+var uploadTypes = map[string]uploadType{
+	"image/jpeg": {extension: ".jpg", maxSize: 20 * 1024 * 1024},
+	"image/png":  {extension: ".png", maxSize: 20 * 1024 * 1024},
+	"image/webp": {extension: ".webp", maxSize: 20 * 1024 * 1024},
+	"video/mp4":  {extension: ".mp4", maxSize: 500 * 1024 * 1024},
+	"video/webm": {extension: ".webm", maxSize: 500 * 1024 * 1024},
+}
+
+var (
+	ErrUnsupportedUploadType = errors.New("content type is not allowed for uploads")
+	ErrUploadTooLarge        = errors.New("file is larger than allowed for its content type")
+	ErrUploadNotReceived     = errors.New("file has not been uploaded yet")
+	ErrUploadMismatch        = errors.New("uploaded file does not match the declared content type or size")
+	ErrUploadNotOwned        = errors.New("upload was started by another user")
+	ErrUploadExpired         = errors.New("upload has expired")
+)
+
+type MediaService interface {
+	GetMedia(ctx context.Context, query entity.QueryString) ([]entity.MediaEntity, int64, error)
+	GetMediaByID(ctx context.Context, id int64) (*entity.MediaEntity, error)
+	UploadMedia(ctx context.Context, req entity.MediaEntity, data []byte) (*entity.MediaEntity, error)
+	UpdateMedia(ctx context.Context, req entity.MediaEntity) (*entity.MediaEntity, error)
+	DeleteMedia(ctx context.Context, id int64) error
+
+	CreateUpload(ctx context.Context, req entity.MediaUploadEntity) (*entity.MediaUploadEntity, error)
+	CompleteUpload(ctx context.Context, uploadID int64, req entity.MediaEntity) (*entity.MediaEntity, error)
+	DeleteExpiredUploads(ctx context.Context) (int, error)
+}
+
+type mediaService struct {
+	mediaRepository repository.MediaRepository
+	r2              cloudflare.CloudflareR2Adapter
+	imageService    ImageService
+}
+
+// GetMedia implements MediaService.
+func (m *mediaService) GetMedia(ctx context.Context, query entity.QueryString) ([]entity.MediaEntity, int64, error) {
+	results, totalData, err := m.mediaRepository.GetMedia(ctx, query)
+	if err != nil {
+		code = "[SERVICE] GetMedia - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	for i := range results {
+		results[i].Variants = m.imageService.ImageVariants(&results[i])
+	}
+
+	return results, totalData, nil
+}
+
+// GetMediaByID implements MediaService.
+func (m *mediaService) GetMediaByID(ctx context.Context, id int64) (*entity.MediaEntity, error) {
+	result, err := m.mediaRepository.GetMediaByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] GetMediaByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	result.Variants = m.imageService.ImageVariants(result)
+
+	return result, nil
+}
+
+// UploadMedia implements MediaService. The file goes through the image
+// pipeline first, so the library only ever holds cleaned originals with
+// their variants.
+func (m *mediaService) UploadMedia(ctx context.Context, req entity.MediaEntity, data []byte) (*entity.MediaEntity, error) {
+	image, err := m.imageService.UploadImage(ctx, data)
+	if err != nil {
+		code = "[SERVICE] UploadMedia - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	req.URL = image.URL
+	req.MimeType = image.MimeType
+	req.Size = image.Size
+	req.Width = image.Width
+	req.Height = image.Height
+	req.Variants = image.Variants
+	req.Tags = cleanTags(req.Tags)
+
+	result, err := m.mediaRepository.CreateMedia(ctx, req)
+	if err != nil {
+		code = "[SERVICE] UploadMedia - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return m.GetMediaByID(ctx, result.ID)
+}
+
+// UpdateMedia implements MediaService.
+func (m *mediaService) UpdateMedia(ctx context.Context, req entity.MediaEntity) (*entity.MediaEntity, error) {
+	req.Tags = cleanTags(req.Tags)
+
+	result, err := m.mediaRepository.UpdateMedia(ctx, req)
+	if err != nil {
+		code = "[SERVICE] UpdateMedia - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return m.GetMediaByID(ctx, result.ID)
+}
+
+// DeleteMedia implements MediaService.
+func (m *mediaService) DeleteMedia(ctx context.Context, id int64) error {
+	err = m.mediaRepository.DeleteMedia(ctx, id)
+	if err != nil {
+		code = "[SERVICE] DeleteMedia - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// CreateUpload implements MediaService. It records a pending upload and
+// returns a presigned URL the client PUTs the file to directly.
+func (m *mediaService) CreateUpload(ctx context.Context, req entity.MediaUploadEntity) (*entity.MediaUploadEntity, error) {
+	req.ContentType = strings.ToLower(strings.TrimSpace(req.ContentType))
+	allowed, ok := uploadTypes[req.ContentType]
+	if !ok {
+		code = "[SERVICE] CreateUpload - 1"
+		log.Errorw(code, ErrUnsupportedUploadType)
+		return nil, ErrUnsupportedUploadType
+	}
+	if req.Size > allowed.maxSize {
+		code = "[SERVICE] CreateUpload - 2"
+		log.Errorw(code, ErrUploadTooLarge)
+		return nil, ErrUploadTooLarge
+	}
+
+	now := time.Now()
+	req.ObjectKey = fmt.Sprintf("uploads/%d/%02d/%s%s", now.Year(), now.Month(), uuid.NewString(), allowed.extension)
+	req.FileName = path.Base(req.FileName)
+	req.ExpiresAt = now.Add(uploadURLExpiry)
+
+	presigned, err := m.r2.PresignPutObject(ctx, req.ObjectKey, req.ContentType, req.Size, uploadURLExpiry)
+	if err != nil {
+		code = "[SERVICE] CreateUpload - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	result, err := m.mediaRepository.CreateUpload(ctx, req)
+	if err != nil {
+		code = "[SERVICE] CreateUpload - 4"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	result.UploadURL = presigned.URL
+	result.UploadMethod = presigned.Method
+	result.UploadHeaders = presigned.Headers
+	return result, nil
+}
+
+// CompleteUpload implements MediaService. Only the user who started the
+// upload, given as req.UploadedByID, can complete it, and only before it
+// expires. The stored object is checked against what was declared, using
+// its real bytes rather than the client's word, before it is added to the
+// library. Images go through the same pipeline as UploadMedia and the raw
+// object is dropped afterwards.
+func (m *mediaService) CompleteUpload(ctx context.Context, uploadID int64, req entity.MediaEntity) (*entity.MediaEntity, error) {
+	upload, err := m.mediaRepository.GetUploadByID(ctx, uploadID)
+	if err != nil {
+		code = "[SERVICE] CompleteUpload - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if upload.UploadedByID == 0 || upload.UploadedByID != req.UploadedByID {
+		code = "[SERVICE] CompleteUpload - 6"
+		log.Errorw(code, ErrUploadNotOwned)
+		return nil, ErrUploadNotOwned
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		code = "[SERVICE] CompleteUpload - 7"
+		log.Errorw(code, ErrUploadExpired)
+		return nil, ErrUploadExpired
+	}
+
+	size, err := m.r2.HeadObject(ctx, upload.ObjectKey)
+	if errors.Is(err, cloudflare.ErrObjectNotFound) {
+		return nil, ErrUploadNotReceived
+	}
+	if err != nil {
+		code = "[SERVICE] CompleteUpload - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	head, err := m.r2.GetObjectRange(ctx, upload.ObjectKey, uploadHeadLength)
+	if err != nil {
+		code = "[SERVICE] CompleteUpload - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	mimeType, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	if size != upload.Size || mimeType != upload.ContentType {
+		code = "[SERVICE] CompleteUpload - 4"
+		log.Errorw(code, ErrUploadMismatch)
+
+		// The object cannot be trusted, so it is dropped right away instead
+		// of waiting for the cleanup.
+		m.deleteUpload(ctx, *upload)
+		return nil, ErrUploadMismatch
+	}
+
+	req.URL = m.r2.PublicURL(upload.ObjectKey)
+	req.FileName = upload.FileName
+	req.MimeType = mimeType
+	req.Size = size
+	req.Tags = cleanTags(req.Tags)
+	if strings.HasPrefix(mimeType, "image/") {
+		data, err := m.r2.GetObjectRange(ctx, upload.ObjectKey, size)
+		if err != nil {
+			code = "[SERVICE] CompleteUpload - 8"
+			log.Errorw(code, err)
+			return nil, err
+		}
+
+		image, err := m.imageService.UploadImage(ctx, data)
+		if errors.Is(err, imaging.ErrUnsupportedFormat) || errors.Is(err, imaging.ErrTooManyPixels) {
+			m.deleteUpload(ctx, *upload)
+		}
+		if err != nil {
+			code = "[SERVICE] CompleteUpload - 9"
+			log.Errorw(code, err)
+			return nil, err
+		}
+
+		req.URL = image.URL
+		req.MimeType = image.MimeType
+		req.Size = image.Size
+		req.Width = image.Width
+		req.Height = image.Height
+		req.Variants = image.Variants
+	}
+
+	result, err := m.mediaRepository.CompleteUpload(ctx, upload.ID, req)
+	if err != nil {
+		code = "[SERVICE] CompleteUpload - 5"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	// The library now holds the re-encoded copy, so the raw upload with its
+	// metadata is not kept. Should this fail, the media GC removes it.
+	if req.URL != m.r2.PublicURL(upload.ObjectKey) {
+		err = m.r2.DeleteObject(ctx, upload.ObjectKey)
+		if err != nil {
+			code = "[SERVICE] CompleteUpload - 10"
+			log.Errorw(code, err)
+		}
+	}
+
+	return m.GetMediaByID(ctx, result.ID)
+}
+
+// DeleteExpiredUploads implements MediaService. It removes pending uploads
+// that were never completed, together with any file sent for them, and
+// returns how many were removed.
+func (m *mediaService) DeleteExpiredUploads(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		uploads, err := m.mediaRepository.GetExpiredUploads(ctx, time.Now().Add(-uploadCleanupGrace), uploadCleanupBatch)
+		if err != nil {
+			code = "[SERVICE] DeleteExpiredUploads - 1"
+			log.Errorw(code, err)
+			return total, err
+		}
+
+		for _, upload := range uploads {
+			err = m.deleteUpload(ctx, upload)
+			if err != nil {
+				code = "[SERVICE] DeleteExpiredUploads - 2"
+				log.Errorw(code, err)
+				return total, err
+			}
+			total++
+		}
+
+		if len(uploads) < uploadCleanupBatch {
+			return total, nil
+		}
+	}
+}
+
+// deleteUpload removes the object before the row, so a failure leaves the
+// row behind for the next cleanup run rather than an untracked file.
+func (m *mediaService) deleteUpload(ctx context.Context, upload entity.MediaUploadEntity) error {
+	err := m.r2.DeleteObject(ctx, upload.ObjectKey)
+	if err != nil {
+		return err
+	}
+
+	return m.mediaRepository.DeleteUpload(ctx, upload.ID)
+}
+
+func NewMediaService(mediaRepo repository.MediaRepository, r2 cloudflare.CloudflareR2Adapter, imageService ImageService) MediaService {
+	return &mediaService{mediaRepository: mediaRepo, r2: r2, imageService: imageService}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+	"news-app/lib/sse"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+var ErrUnknownNotificationType = errors.New("unknown notification type")
+
+type NotificationService interface {
+	Notify(ctx context.Context, event entity.NotificationEventEntity)
+	GetNotifications(ctx context.Context, userID int64, unreadOnly bool, query entity.QueryString) ([]entity.NotificationEntity, int64, error)
+	CountUnread(ctx context.Context, userID int64) (int64, error)
+	MarkNotificationsRead(ctx context.Context, userID int64, ids []int64) (int64, error)
+	GetSubscriptions(ctx context.Context, userID int64) (map[string]bool, error)
+	UpdateSubscriptions(ctx context.Context, userID int64, subscriptions map[string]bool) (map[string]bool, error)
+	Subscribe(userID int64) *sse.Subscription
+	Close()
+}
+
+type notificationPayload struct {
+	ID           int64  `json:"id"`
+	Type         string `json:"type"`
+	Message      string `json:"message"`
+	Actor        string `json:"actor,omitempty"`
+	ContentID    int64  `json:"content_id,omitempty"`
+	ContentTitle string `json:"content_title,omitempty"`
+	CommentID    int64  `json:"comment_id,omitempty"`
+	CreatedAt    string `json:"created_at"`
+}
+
+type notificationService struct {
+	notificationRepository repository.NotificationRepository
+	// hub carries each new notification, as JSON, to the open connections
+	// of its recipient.
+	hub *sse.Hub
+}
+
+// Notify implements NotificationService. Notifications are a side effect of
+// what the actor did, so failing to send them is logged rather than
+// returned.
+func (n *notificationService) Notify(ctx context.Context, event entity.NotificationEventEntity) {
+	var userIDs []int64
+	if event.RecipientID > 0 {
+		userIDs = []int64{event.RecipientID}
+	}
+
+	recipients, err := n.notificationRepository.GetRecipients(ctx, event.Type, userIDs)
+	if err != nil {
+		code = "[SERVICE] Notify - 1"
+		log.Errorw(code, err)
+		return
+	}
+
+	reqs := []entity.NotificationEntity{}
+	for _, userID := range recipients {
+		if userID == event.ActorID {
+			continue
+		}
+
+		reqs = append(reqs, entity.NotificationEntity{
+			UserID:    userID,
+			Type:      event.Type,
+			ActorID:   event.ActorID,
+			ContentID: event.ContentID,
+			CommentID: event.CommentID,
+			Message:   event.Message,
+		})
+	}
+
+	results, err := n.notificationRepository.CreateNotifications(ctx, reqs)
+	if err != nil {
+		code = "[SERVICE] Notify - 2"
+		log.Errorw(code, err)
+		return
+	}
+
+	for _, result := range results {
+		data, _ := json.Marshal(notificationPayload{
+			ID:           result.ID,
+			Type:         result.Type,
+			Message:      result.Message,
+			Actor:        result.Actor,
+			ContentID:    result.ContentID,
+			ContentTitle: result.ContentTitle,
+			CommentID:    result.CommentID,
+			CreatedAt:    result.CreatedAt.Format(time.RFC3339),
+		})
+
+		n.hub.Publish(notificationTopic(result.UserID), sse.Event{ID: result.ID, Type: result.Type, Data: string(data)})
+	}
+}
+
+// GetNotifications implements NotificationService.
+func (n *notificationService) GetNotifications(ctx context.Context, userID int64, unreadOnly bool, query entity.QueryString) ([]entity.NotificationEntity, int64, error) {
+	results, totalData, err := n.notificationRepository.GetNotifications(ctx, userID, unreadOnly, query)
+	if err != nil {
+		code = "[SERVICE] GetNotifications - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	return results, totalData, nil
+}
+
+// CountUnread implements NotificationService.
+func (n *notificationService) CountUnread(ctx context.Context, userID int64) (int64, error) {
+	count, err := n.notificationRepository.CountUnread(ctx, userID)
+	if err != nil {
+		code = "[SERVICE] CountUnread - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkNotificationsRead implements NotificationService.
+func (n *notificationService) MarkNotificationsRead(ctx context.Context, userID int64, ids []int64) (int64, error) {
+	count, err := n.notificationRepository.MarkNotificationsRead(ctx, userID, ids)
+	if err != nil {
+		code = "[SERVICE] MarkNotificationsRead - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetSubscriptions implements NotificationService. Every type is listed,
+// those the user never changed as subscribed.
+func (n *notificationService) GetSubscriptions(ctx context.Context, userID int64) (map[string]bool, error) {
+	saved, err := n.notificationRepository.GetSubscriptions(ctx, userID)
+	if err != nil {
+		code = "[SERVICE] GetSubscriptions - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := map[string]bool{}
+	for _, notificationType := range entity.NotificationTypes {
+		enabled, ok := saved[notificationType]
+		res[notificationType] = !ok || enabled
+	}
+
+	return res, nil
+}
+
+// UpdateSubscriptions implements NotificationService.
+func (n *notificationService) UpdateSubscriptions(ctx context.Context, userID int64, subscriptions map[string]bool) (map[string]bool, error) {
+	for notificationType := range subscriptions {
+		if !slices.Contains(entity.NotificationTypes, notificationType) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownNotificationType, notificationType)
+		}
+	}
+
+	err = n.notificationRepository.SaveSubscriptions(ctx, userID, subscriptions)
+	if err != nil {
+		code = "[SERVICE] UpdateSubscriptions - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return n.GetSubscriptions(ctx, userID)
+}
+
+// Subscribe implements NotificationService. The subscription receives the
+// notifications of the user created from now on.
+func (n *notificationService) Subscribe(userID int64) *sse.Subscription {
+	return n.hub.Subscribe(notificationTopic(userID))
+}
+
+// Close implements NotificationService. Open connections are ended, so the
+// server can shut down.
+func (n *notificationService) Close() {
+	n.hub.Close()
+}
+
+func notificationTopic(userID int64) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+func NewNotificationService(notificationRepo repository.NotificationRepository) NotificationService {
+	return &notificationService{
+		notificationRepository: notificationRepo,
+		hub:                    sse.NewHub(),
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	outboxPollInterval = time.Second
+	outboxBatchSize    = 50
+	// outboxLease is how long claimed events are left to their relay. It
+	// has to outlast handling a batch.
+	outboxLease = 5 * time.Minute
+
+	outboxRetryBase = 5 * time.Second
+	outboxRetryMax  = 30 * time.Minute
+	// outboxRetention is how long handled events are kept around for
+	// inspection.
+	outboxRetention = 7 * 24 * time.Hour
+)
+
+// EventHandler reacts to a domain event. Events are handed over at least
+// once: after a failure or a crash an event goes to all of its handlers
+// again, so handlers have to be idempotent, for instance by keying on
+// EventID.
+type EventHandler func(ctx context.Context, event entity.DomainEventEntity) error
+
+type OutboxService interface {
+	Emit(ctx context.Context, aggregateType string, aggregateID int64, eventType string, data interface{}) error
+	Register(name string, handler EventHandler, eventTypes ...string)
+	RunRelay(ctx context.Context)
+	DeleteProcessedEvents(ctx context.Context) (int64, error)
+}
+
+type outboxHandler struct {
+	name       string
+	handler    EventHandler
+	eventTypes []string
+}
+
+type outboxService struct {
+	outboxRepository repository.OutboxRepository
+
+	mu       sync.RWMutex
+	handlers []outboxHandler
+}
+
+// Emit implements OutboxService. Given the context of a Transactor, the
+// event is stored with the change it describes or not at all.
+func (o *outboxService) Emit(ctx context.Context, aggregateType string, aggregateID int64, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		code = "[SERVICE] Emit - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = o.outboxRepository.AddEvents(ctx, []entity.DomainEventEntity{{
+		EventID:       uuid.NewString(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Type:          eventType,
+		Payload:       string(payload),
+	}})
+	if err != nil {
+		code = "[SERVICE] Emit - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// Register implements OutboxService. The handler gets the events of
+// eventTypes, or every event when none are given.
+func (o *outboxService) Register(name string, handler EventHandler, eventTypes ...string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.handlers = append(o.handlers, outboxHandler{name: name, handler: handler, eventTypes: eventTypes})
+}
+
+// RunRelay implements OutboxService. It hands the due events to their
+// handlers every outboxPollInterval until ctx is done, and keeps going
+// while there is a backlog.
+func (o *outboxService) RunRelay(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for o.relayDue(ctx) == outboxBatchSize && ctx.Err() == nil {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeleteProcessedEvents implements OutboxService.
+func (o *outboxService) DeleteProcessedEvents(ctx context.Context) (int64, error) {
+	deleted, err := o.outboxRepository.DeleteProcessedEvents(ctx, time.Now().Add(-outboxRetention))
+	if err != nil {
+		code = "[SERVICE] DeleteProcessedEvents - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// relayDue hands one batch of due events over in the order they were
+// emitted and returns its size. A failed event is retried later without
+// holding up the ones after it.
+func (o *outboxService) relayDue(ctx context.Context) int {
+	now := time.Now()
+	events, err := o.outboxRepository.ClaimDueEvents(ctx, now, now.Add(outboxLease), outboxBatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			code = "[SERVICE] relayDue - 1"
+			log.Errorw(code, err)
+		}
+		return 0
+	}
+
+	for _, event := range events {
+		// Events left when shutting down come back once their lease
+		// runs out.
+		if ctx.Err() != nil {
+			break
+		}
+		o.relay(ctx, event)
+	}
+
+	return len(events)
+}
+
+// relay hands event to its handlers. It is done once all of them
+// succeeded; otherwise it goes to all of them again after a backoff.
+func (o *outboxService) relay(ctx context.Context, event entity.DomainEventEntity) {
+	o.mu.RLock()
+	handlers := o.handlers
+	o.mu.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if len(h.eventTypes) > 0 && !slices.Contains(h.eventTypes, event.Type) {
+			continue
+		}
+
+		err := h.handler(ctx, event)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	if len(errs) == 0 {
+		err := o.outboxRepository.MarkEventProcessed(ctx, event.ID, time.Now())
+		if err != nil {
+			code = "[SERVICE] relay - 1"
+			log.Errorw(code, err)
+		}
+		return
+	}
+
+	failure := errors.Join(errs...)
+	code = "[SERVICE] relay - 2"
+	log.Errorw(code, fmt.Errorf("%s %s: %w", event.Type, event.EventID, failure))
+
+	err := o.outboxRepository.MarkEventFailed(ctx, event.ID, failure.Error(), time.Now().Add(outboxBackoff(event.Attempts+1)))
+	if err != nil {
+		code = "[SERVICE] relay - 3"
+		log.Errorw(code, err)
+	}
+}
+
+// outboxBackoff is the wait after the given failed attempt. Events are
+// never given up on; a handler that keeps failing is retried every
+// outboxRetryMax until it is fixed.
+func outboxBackoff(attempt int) time.Duration {
+	if attempt >= 20 {
+		return outboxRetryMax
+	}
+
+	return min(outboxRetryBase<<(attempt-1), outboxRetryMax)
+}
+
+func NewOutboxService(outboxRepo repository.OutboxRepository) OutboxService {
+	return &outboxService{outboxRepository: outboxRepo}
+}
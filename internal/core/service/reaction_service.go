@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+var ErrUnknownReaction = errors.New("unknown reaction")
+
+type ReactionService interface {
+	GetReactions(ctx context.Context, contentID int64, reactor entity.ReactorEntity) (*entity.ReactionSummaryEntity, error)
+	SetReaction(ctx context.Context, contentID int64, reactor entity.ReactorEntity, reaction string) (*entity.ReactionSummaryEntity, error)
+	DeleteReaction(ctx context.Context, contentID int64, reactor entity.ReactorEntity) (*entity.ReactionSummaryEntity, error)
+}
+
+type reactionService struct {
+	reactionRepository repository.ReactionRepository
+	contentRepository  repository.ContentRepository
+}
+
+// GetReactions implements ReactionService. Without a reactor only the
+// counts are returned.
+func (r *reactionService) GetReactions(ctx context.Context, contentID int64, reactor entity.ReactorEntity) (*entity.ReactionSummaryEntity, error) {
+	err = r.checkPublished(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] GetReactions - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return r.summary(ctx, contentID, reactor)
+}
+
+// SetReaction implements ReactionService.
+func (r *reactionService) SetReaction(ctx context.Context, contentID int64, reactor entity.ReactorEntity, reaction string) (*entity.ReactionSummaryEntity, error) {
+	if !slices.Contains(entity.ReactionKinds, reaction) {
+		return nil, ErrUnknownReaction
+	}
+
+	err = r.checkPublished(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] SetReaction - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	err = r.reactionRepository.SetReaction(ctx, contentID, reactor, reaction)
+	if err != nil {
+		code = "[SERVICE] SetReaction - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return r.summary(ctx, contentID, reactor)
+}
+
+// DeleteReaction implements ReactionService. Taking back a reaction that
+// was never set is not an error.
+func (r *reactionService) DeleteReaction(ctx context.Context, contentID int64, reactor entity.ReactorEntity) (*entity.ReactionSummaryEntity, error) {
+	err = r.reactionRepository.DeleteReaction(ctx, contentID, reactor)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		code = "[SERVICE] DeleteReaction - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return r.summary(ctx, contentID, reactor)
+}
+
+func (r *reactionService) summary(ctx context.Context, contentID int64, reactor entity.ReactorEntity) (*entity.ReactionSummaryEntity, error) {
+	counts, err := r.reactionRepository.GetReactionCounts(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &entity.ReactionSummaryEntity{Counts: counts}
+	if reactor.ReaderID == 0 && reactor.Fingerprint == "" {
+		return res, nil
+	}
+
+	res.Mine, err = r.reactionRepository.GetReaction(ctx, contentID, reactor)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// checkPublished reports drafts as missing.
+func (r *reactionService) checkPublished(ctx context.Context, contentID int64) error {
+	content, err := r.contentRepository.GetContentByID(ctx, contentID)
+	if err != nil {
+		return err
+	}
+
+	if content.Status != entity.ContentStatusPublish {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func NewReactionService(reactionRepo repository.ReactionRepository, contentRepo repository.ContentRepository) ReactionService {
+	return &reactionService{reactionRepository: reactionRepo, contentRepository: contentRepo}
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"news-app/config"
+	"news-app/internal/adapter/mailer"
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+	"news-app/lib/auth"
+	"news-app/lib/conv"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	// readerVerificationTTL is how long the link in a verification email
+	// works.
+	readerVerificationTTL = 48 * time.Hour
+	// readerVerificationResendAfter keeps the resend endpoint from being used
+	// to flood an inbox.
+	readerVerificationResendAfter = time.Minute
+)
+
+var (
+	ErrReaderEmailTaken         = errors.New("email is already registered")
+	ErrReaderInvalidCredentials = errors.New("email or password is incorrect")
+	ErrReaderNotVerified        = errors.New("email address is not verified yet")
+	ErrReaderInvalidToken       = errors.New("verification link is invalid or has expired")
+)
+
+type ReaderService interface {
+	Register(ctx context.Context, req entity.ReaderEntity) (*entity.ReaderEntity, error)
+	VerifyEmail(ctx context.Context, token string) (*entity.ReaderEntity, error)
+	ResendVerification(ctx context.Context, email string) error
+	Login(ctx context.Context, req entity.LoginRequest) (*entity.AccessToken, error)
+	GetReaderByID(ctx context.Context, id int64) (*entity.ReaderEntity, error)
+	UpdateReader(ctx context.Context, req entity.ReaderEntity, historyEnabled *bool) (*entity.ReaderEntity, error)
+	UpdateReaderPassword(ctx context.Context, id int64, currentPassword, newPassword string) error
+	DeleteReader(ctx context.Context, id int64, password string) error
+}
+
+type readerService struct {
+	readerRepository repository.ReaderRepository
+	mailer           mailer.MailerAdapter
+	cfg              *config.Config
+	jwtToken         auth.Jwt
+}
+
+// Register implements ReaderService. The account cannot sign in until the
+// link emailed to it has been followed.
+func (r *readerService) Register(ctx context.Context, req entity.ReaderEntity) (*entity.ReaderEntity, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+
+	_, err := r.readerRepository.GetReaderByEmail(ctx, req.Email)
+	if err == nil {
+		return nil, ErrReaderEmailTaken
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		code = "[SERVICE] Register - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	req.Password, err = conv.HashPassword(req.Password)
+	if err != nil {
+		code = "[SERVICE] Register - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	token, tokenHash, err := newVerificationToken()
+	if err != nil {
+		code = "[SERVICE] Register - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	result, err := r.readerRepository.CreateReader(ctx, req, tokenHash)
+	if err != nil {
+		code = "[SERVICE] Register - 4"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	// The account exists either way; a lost email can be sent again.
+	if err := r.sendVerification(ctx, *result, token); err != nil {
+		code = "[SERVICE] Register - 5"
+		log.Errorw(code, err)
+	}
+
+	return result, nil
+}
+
+// VerifyEmail implements ReaderService.
+func (r *readerService) VerifyEmail(ctx context.Context, token string) (*entity.ReaderEntity, error) {
+	result, err := r.readerRepository.VerifyReaderEmail(ctx, hashVerificationToken(token), time.Now().Add(-readerVerificationTTL))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrReaderInvalidToken
+	}
+	if err != nil {
+		code = "[SERVICE] VerifyEmail - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ResendVerification implements ReaderService. Unknown and already
+// verified addresses are ignored without telling, so the endpoint does not
+// reveal who has an account.
+func (r *readerService) ResendVerification(ctx context.Context, email string) error {
+	reader, err := r.readerRepository.GetReaderByEmail(ctx, strings.TrimSpace(email))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		code = "[SERVICE] ResendVerification - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	if reader.EmailVerifiedAt != nil {
+		return nil
+	}
+	if reader.VerificationSentAt != nil && time.Since(*reader.VerificationSentAt) < readerVerificationResendAfter {
+		return nil
+	}
+
+	token, tokenHash, err := newVerificationToken()
+	if err != nil {
+		code = "[SERVICE] ResendVerification - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = r.readerRepository.SetReaderVerificationToken(ctx, reader.ID, tokenHash)
+	if err != nil {
+		code = "[SERVICE] ResendVerification - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = r.sendVerification(ctx, *reader, token)
+	if err != nil {
+		code = "[SERVICE] ResendVerification - 4"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// Login implements ReaderService. The token is a reader token: it carries
+// no staff user id and the admin routes refuse it.
+func (r *readerService) Login(ctx context.Context, req entity.LoginRequest) (*entity.AccessToken, error) {
+	reader, err := r.readerRepository.GetReaderByEmail(ctx, strings.TrimSpace(req.Email))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrReaderInvalidCredentials
+	}
+	if err != nil {
+		code = "[SERVICE] Login - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if !conv.CheckPasswordHash(req.Password, reader.Password) {
+		return nil, ErrReaderInvalidCredentials
+	}
+
+	if reader.EmailVerifiedAt == nil {
+		return nil, ErrReaderNotVerified
+	}
+
+	jwtData := &entity.JwtData{
+		ReaderID: float64(reader.ID),
+		Kind:     entity.JwtKindReader,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: fmt.Sprintf("reader:%d", reader.ID),
+		},
+	}
+
+	accessToken, expireAt, err := r.jwtToken.GenerateToken(jwtData)
+	if err != nil {
+		code = "[SERVICE] Login - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return &entity.AccessToken{Token: accessToken, ExpireAt: expireAt}, nil
+}
+
+// GetReaderByID implements ReaderService.
+func (r *readerService) GetReaderByID(ctx context.Context, id int64) (*entity.ReaderEntity, error) {
+	result, err := r.readerRepository.GetReaderByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] GetReaderByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateReader implements ReaderService. The reading history setting is
+// left as it is when historyEnabled is nil.
+func (r *readerService) UpdateReader(ctx context.Context, req entity.ReaderEntity, historyEnabled *bool) (*entity.ReaderEntity, error) {
+	current, err := r.readerRepository.GetReaderByID(ctx, req.ID)
+	if err != nil {
+		code = "[SERVICE] UpdateReader - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	req.HistoryEnabled = current.HistoryEnabled
+	if historyEnabled != nil {
+		req.HistoryEnabled = *historyEnabled
+	}
+
+	result, err := r.readerRepository.UpdateReader(ctx, req)
+	if err != nil {
+		code = "[SERVICE] UpdateReader - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateReaderPassword implements ReaderService.
+func (r *readerService) UpdateReaderPassword(ctx context.Context, id int64, currentPassword, newPassword string) error {
+	reader, err := r.readerRepository.GetReaderByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] UpdateReaderPassword - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	if !conv.CheckPasswordHash(currentPassword, reader.Password) {
+		return ErrReaderInvalidCredentials
+	}
+
+	password, err := conv.HashPassword(newPassword)
+	if err != nil {
+		code = "[SERVICE] UpdateReaderPassword - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = r.readerRepository.UpdateReaderPassword(ctx, id, password)
+	if err != nil {
+		code = "[SERVICE] UpdateReaderPassword - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteReader implements ReaderService. The password is asked again so a
+// stolen token alone cannot delete the account.
+func (r *readerService) DeleteReader(ctx context.Context, id int64, password string) error {
+	reader, err := r.readerRepository.GetReaderByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] DeleteReader - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	if !conv.CheckPasswordHash(password, reader.Password) {
+		return ErrReaderInvalidCredentials
+	}
+
+	err = r.readerRepository.DeleteReader(ctx, id)
+	if err != nil {
+		code = "[SERVICE] DeleteReader - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *readerService) sendVerification(ctx context.Context, reader entity.ReaderEntity, token string) error {
+	link := strings.TrimRight(r.cfg.App.PublicUrl, "/") + "/verify-email?token=" + token
+	body := fmt.Sprintf("Hi %s,\n\nPlease confirm your email address for %s by opening this link:\n\n%s\n\nThe link works for %d hours. If you did not sign up, you can ignore this email.\n",
+		reader.Name, r.cfg.App.SiteName, link, int(readerVerificationTTL.Hours()))
+
+	return r.mailer.Send(ctx, reader.Email, "Confirm your email address", body)
+}
+
+// newVerificationToken returns a random token for the email and the hash
+// stored in its place, so a leaked database does not verify anyone.
+func newVerificationToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashVerificationToken(token), nil
+}
+
+func hashVerificationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func NewReaderService(readerRepo repository.ReaderRepository, mailerAdapter mailer.MailerAdapter, cfg *config.Config, jwtToken auth.Jwt) ReaderService {
+	return &readerService{readerRepository: readerRepo, mailer: mailerAdapter, cfg: cfg, jwtToken: jwtToken}
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+const (
+	redirectCacheTTL = 30 * time.Second
+	redirectMaxHops  = 10
+)
+
+var ErrRedirectLoop = errors.New("redirect loop detected")
+
+type RedirectService interface {
+	GetRedirects(ctx context.Context, query entity.QueryString) ([]entity.RedirectEntity, int64, error)
+	GetRedirectByID(ctx context.Context, id int64) (*entity.RedirectEntity, error)
+	CreateRedirect(ctx context.Context, req entity.RedirectEntity) (*entity.RedirectEntity, error)
+	UpdateRedirect(ctx context.Context, req entity.RedirectEntity) (*entity.RedirectEntity, error)
+	DeleteRedirect(ctx context.Context, id int64) error
+	ResolveRedirect(ctx context.Context, path string) (*entity.RedirectEntity, error)
+	Invalidate()
+}
+
+type redirectService struct {
+	redirectRepository repository.RedirectRepository
+
+	mu       sync.RWMutex
+	cache    map[string]entity.RedirectEntity
+	loadedAt time.Time
+}
+
+// GetRedirects implements RedirectService.
+func (r *redirectService) GetRedirects(ctx context.Context, query entity.QueryString) ([]entity.RedirectEntity, int64, error) {
+	results, totalData, err := r.redirectRepository.GetRedirects(ctx, query)
+	if err != nil {
+		code = "[SERVICE] GetRedirects - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	return results, totalData, nil
+}
+
+// GetRedirectByID implements RedirectService.
+func (r *redirectService) GetRedirectByID(ctx context.Context, id int64) (*entity.RedirectEntity, error) {
+	result, err := r.redirectRepository.GetRedirectByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] GetRedirectByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateRedirect implements RedirectService.
+func (r *redirectService) CreateRedirect(ctx context.Context, req entity.RedirectEntity) (*entity.RedirectEntity, error) {
+	req.SourcePath = normalizePath(req.SourcePath)
+	req.TargetPath = normalizePath(req.TargetPath)
+
+	err = r.checkLoop(ctx, req)
+	if err != nil {
+		code = "[SERVICE] CreateRedirect - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	result, err := r.redirectRepository.CreateRedirect(ctx, req)
+	if err != nil {
+		code = "[SERVICE] CreateRedirect - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	r.Invalidate()
+	return result, nil
+}
+
+// UpdateRedirect implements RedirectService.
+func (r *redirectService) UpdateRedirect(ctx context.Context, req entity.RedirectEntity) (*entity.RedirectEntity, error) {
+	req.SourcePath = normalizePath(req.SourcePath)
+	req.TargetPath = normalizePath(req.TargetPath)
+
+	err = r.checkLoop(ctx, req)
+	if err != nil {
+		code = "[SERVICE] UpdateRedirect - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	result, err := r.redirectRepository.UpdateRedirect(ctx, req)
+	if err != nil {
+		code = "[SERVICE] UpdateRedirect - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	r.Invalidate()
+	return result, nil
+}
+
+// DeleteRedirect implements RedirectService.
+func (r *redirectService) DeleteRedirect(ctx context.Context, id int64) error {
+	err = r.redirectRepository.DeleteRedirect(ctx, id)
+	if err != nil {
+		code = "[SERVICE] DeleteRedirect - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	r.Invalidate()
+	return nil
+}
+
+// ResolveRedirect implements RedirectService. It follows the chain starting
+// at path and returns the first hop with its target replaced by the final
+// destination, or nil when path is not redirected or the chain loops.
+func (r *redirectService) ResolveRedirect(ctx context.Context, path string) (*entity.RedirectEntity, error) {
+	redirects, err := r.redirects(ctx)
+	if err != nil {
+		code = "[SERVICE] ResolveRedirect - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	first, ok := redirects[normalizePath(path)]
+	if !ok {
+		return nil, nil
+	}
+
+	target, err := followRedirects(redirects, first.SourcePath)
+	if err != nil {
+		code = "[SERVICE] ResolveRedirect - 2"
+		log.Errorw(code, err, "path", path)
+		return nil, nil
+	}
+
+	go func(id int64) {
+		if err := r.redirectRepository.IncrementHitCount(context.Background(), id); err != nil {
+			log.Errorw("[SERVICE] ResolveRedirect - 3", err)
+		}
+	}(first.ID)
+
+	first.TargetPath = target
+	return &first, nil
+}
+
+func (r *redirectService) checkLoop(ctx context.Context, req entity.RedirectEntity) error {
+	if req.SourcePath == req.TargetPath {
+		return ErrRedirectLoop
+	}
+
+	all, err := r.redirectRepository.GetAllRedirects(ctx)
+	if err != nil {
+		return err
+	}
+
+	redirects := map[string]entity.RedirectEntity{}
+	for _, val := range all {
+		if val.ID != req.ID {
+			redirects[val.SourcePath] = val
+		}
+	}
+	redirects[req.SourcePath] = req
+
+	_, err = followRedirects(redirects, req.SourcePath)
+	return err
+}
+
+func (r *redirectService) redirects(ctx context.Context) (map[string]entity.RedirectEntity, error) {
+	r.mu.RLock()
+	if r.cache != nil && time.Since(r.loadedAt) < redirectCacheTTL {
+		defer r.mu.RUnlock()
+		return r.cache, nil
+	}
+	r.mu.RUnlock()
+
+	all, err := r.redirectRepository.GetAllRedirects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := make(map[string]entity.RedirectEntity, len(all))
+	for _, val := range all {
+		cache[val.SourcePath] = val
+	}
+
+	r.mu.Lock()
+	r.cache = cache
+	r.loadedAt = time.Now()
+	r.mu.Unlock()
+
+	return cache, nil
+}
+
+// Invalidate implements RedirectService. Redirects written elsewhere, such
+// as those recorded when a slug changes, are served once it is called.
+func (r *redirectService) Invalidate() {
+	r.mu.Lock()
+	r.cache = nil
+	r.mu.Unlock()
+}
+
+// followRedirects walks the chain starting at source and returns the final
+// target, failing if the chain comes back on itself or is too long.
+func followRedirects(redirects map[string]entity.RedirectEntity, source string) (string, error) {
+	seen := map[string]bool{source: true}
+	current := source
+
+	for i := 0; i < redirectMaxHops; i++ {
+		next, ok := redirects[current]
+		if !ok {
+			return current, nil
+		}
+
+		if seen[next.TargetPath] {
+			return "", ErrRedirectLoop
+		}
+
+		seen[next.TargetPath] = true
+		current = next.TargetPath
+	}
+
+	return "", ErrRedirectLoop
+}
+
+func normalizePath(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	if len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+
+	return path
+}
+
+func NewRedirectService(redirectRepo repository.RedirectRepository) RedirectService {
+	return &redirectService{redirectRepository: redirectRepo}
+}
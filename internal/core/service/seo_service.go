@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"news-app/config"
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+	"news-app/lib/richtext"
+	"news-app/lib/seo"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// metaDescriptionLength is about what search results show before cutting.
+const metaDescriptionLength = 160
+
+type SeoService interface {
+	GetContentSeo(ctx context.Context, contentSlug string) (*entity.SeoEntity, error)
+}
+
+type seoService struct {
+	contentRepository repository.ContentRepository
+	cfg               *config.Config
+}
+
+// GetContentSeo implements SeoService. Fields the editor left empty fall
+// back to the article's own title, excerpt, cover image and URL.
+func (s *seoService) GetContentSeo(ctx context.Context, contentSlug string) (*entity.SeoEntity, error) {
+	content, err := s.contentRepository.GetContentBySlug(ctx, contentSlug, true)
+	if err != nil {
+		code = "[SERVICE] GetContentSeo - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	res := &entity.SeoEntity{
+		Title:        content.MetaTitle,
+		Description:  content.MetaDescription,
+		CanonicalURL: content.CanonicalURL,
+		Robots:       "index, follow, max-image-preview:large",
+	}
+	if res.Title == "" {
+		res.Title = content.Title
+	}
+	if res.Description == "" {
+		res.Description = richtext.TruncateWords(content.Excerpt, metaDescriptionLength)
+	}
+	if res.CanonicalURL == "" {
+		res.CanonicalURL = s.cfg.ContentURL(content.Slug)
+	}
+	if content.NoIndex {
+		res.Robots = "noindex, follow"
+	}
+
+	image := content.OgMedia
+	if image == nil {
+		image = content.Media
+	}
+
+	modified := content.CreatedAt
+	if content.UpdatedAt != nil {
+		modified = *content.UpdatedAt
+	}
+	published := content.CreatedAt
+	if content.PublishedAt != nil {
+		published = *content.PublishedAt
+	}
+
+	tags := []seo.Tag{
+		seo.Name("description", res.Description),
+		seo.Name("robots", res.Robots),
+		seo.Property("og:type", "article"),
+		seo.Property("og:site_name", s.cfg.App.SiteName),
+		seo.Property("og:locale", "id_ID"),
+		seo.Property("og:title", res.Title),
+		seo.Property("og:description", res.Description),
+		seo.Property("og:url", res.CanonicalURL),
+	}
+	if image != nil {
+		tags = append(tags, seo.Property("og:image", image.URL))
+		if image.Width > 0 && image.Height > 0 {
+			tags = append(tags,
+				seo.Property("og:image:width", strconv.Itoa(image.Width)),
+				seo.Property("og:image:height", strconv.Itoa(image.Height)),
+			)
+		}
+		if image.AltText != "" {
+			tags = append(tags, seo.Property("og:image:alt", image.AltText))
+		}
+	}
+	tags = append(tags,
+		seo.Property("article:published_time", seo.Time(published)),
+		seo.Property("article:modified_time", seo.Time(modified)),
+		seo.Property("article:section", content.Category.Title),
+	)
+	for _, tag := range content.Tags {
+		tags = append(tags, seo.Property("article:tag", tag))
+	}
+
+	twitterCard := "summary"
+	if image != nil {
+		twitterCard = "summary_large_image"
+	}
+	tags = append(tags,
+		seo.Name("twitter:card", twitterCard),
+		seo.Name("twitter:title", res.Title),
+		seo.Name("twitter:description", res.Description),
+	)
+	if image != nil {
+		tags = append(tags, seo.Name("twitter:image", image.URL))
+		if image.AltText != "" {
+			tags = append(tags, seo.Name("twitter:image:alt", image.AltText))
+		}
+	}
+
+	for _, tag := range tags {
+		if tag.Content != "" {
+			res.Tags = append(res.Tags, entity.SeoTagEntity{Attribute: tag.Attribute, Key: tag.Key, Content: tag.Content})
+		}
+	}
+
+	article := seo.NewNewsArticle(richtext.TruncateWords(content.Title, seo.MaxHeadlineLength), res.CanonicalURL)
+	article.Description = res.Description
+	article.DatePublished = seo.Time(published)
+	article.DateModified = seo.Time(modified)
+	article.ArticleSection = content.Category.Title
+	article.WordCount = content.WordCount
+	article.InLanguage = "id"
+	article.Publisher = seo.Organization{Type: "Organization", Name: s.cfg.App.SiteName, URL: s.cfg.SiteURL()}
+	if content.User.Name != "" {
+		article.Author = []seo.Thing{{Type: "Person", Name: content.User.Name}}
+	}
+	if image != nil {
+		article.Image = []seo.ImageObject{{Type: "ImageObject", URL: image.URL, Width: image.Width, Height: image.Height}}
+	}
+	if len(content.Tags) > 0 {
+		article.Keywords = strings.Join(content.Tags, ", ")
+	}
+
+	res.JSONLD, err = article.Marshal()
+	if err != nil {
+		code = "[SERVICE] GetContentSeo - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func NewSeoService(contentRepo repository.ContentRepository, cfg *config.Config) SeoService {
+	return &seoService{contentRepository: contentRepo, cfg: cfg}
+}
@@ -0,0 +1,475 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"news-app/config"
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+	"news-app/lib/sitemap"
+
+	"github.com/gofiber/fiber/v2/log"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// sitemapPageSize is the id range covered by one child sitemap. Pages are
+	// bucketed by id rather than by offset so a change to one row only ever
+	// invalidates the page that row lives on.
+	sitemapPageSize = 10000
+
+	newsSitemapWindow = 48 * time.Hour
+	newsSitemapLimit  = 1000
+	newsSitemapTTL    = 5 * time.Minute
+
+	// tagSitemapRefresh is the shortest time between two reloads of the
+	// tags, which aggregate every published story.
+	tagSitemapRefresh = time.Minute
+)
+
+// ErrSitemapPageNotFound is returned for child sitemaps past the last page
+// listed in the index. They are never built, so requests for made-up pages
+// cannot fill the cache.
+var ErrSitemapPageNotFound = errors.New("sitemap page not found")
+
+type SitemapService interface {
+	GetIndex(ctx context.Context, baseURL string) ([]byte, error)
+	GetContentSitemap(ctx context.Context, page int64) ([]byte, error)
+	GetCategorySitemap(ctx context.Context, page int64) ([]byte, error)
+	GetTagSitemap(ctx context.Context, page int64) ([]byte, error)
+	GetNewsSitemap(ctx context.Context) ([]byte, error)
+
+	ContentChanged(contentID int64, before, after *entity.ContentEntity)
+	CategoryChanged(categoryID int64)
+}
+
+type sitemapService struct {
+	sitemapRepository repository.SitemapRepository
+	cfg               *config.Config
+
+	// mu guards the fields below and is never held while querying; builds
+	// of the same document share one query through group.
+	mu            sync.Mutex
+	group         singleflight.Group
+	gen           uint64
+	loaded        bool
+	contentPages  map[int64]time.Time
+	categoryPages map[int64]time.Time
+	tags          []entity.SitemapEntryEntity
+	tagsAt        time.Time
+	tagsStale     bool
+	docs          map[string][]byte
+	news          []byte
+	newsAt        time.Time
+}
+
+// GetIndex implements SitemapService. It is built from the page lastmods
+// kept in memory, so serving it never touches the contents table.
+func (s *sitemapService) GetIndex(ctx context.Context, baseURL string) ([]byte, error) {
+	err := s.load(ctx)
+	if err != nil {
+		code = "[SERVICE] GetIndex - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	tags, err := s.loadTags(ctx)
+	if err != nil {
+		code = "[SERVICE] GetIndex - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	s.mu.Lock()
+	sitemaps := []sitemap.Sitemap{{Loc: baseURL + "/sitemap-news.xml", LastMod: time.Now()}}
+	sitemaps = append(sitemaps, pagesToSitemaps(baseURL+"/sitemaps/contents-%d.xml", s.contentPages)...)
+	sitemaps = append(sitemaps, pagesToSitemaps(baseURL+"/sitemaps/categories-%d.xml", s.categoryPages)...)
+	s.mu.Unlock()
+
+	for page := int64(1); (page-1)*sitemapPageSize < int64(len(tags)); page++ {
+		var lastMod time.Time
+		for _, tag := range tagPage(tags, page) {
+			if tag.LastMod.After(lastMod) {
+				lastMod = tag.LastMod
+			}
+		}
+
+		sitemaps = append(sitemaps, sitemap.Sitemap{Loc: fmt.Sprintf(baseURL+"/sitemaps/tags-%d.xml", page), LastMod: lastMod})
+	}
+
+	return sitemap.Index(sitemaps)
+}
+
+// GetContentSitemap implements SitemapService.
+func (s *sitemapService) GetContentSitemap(ctx context.Context, page int64) ([]byte, error) {
+	err := s.load(ctx)
+	if err != nil {
+		code = "[SERVICE] GetContentSitemap - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	s.mu.Lock()
+	_, ok := s.contentPages[page]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrSitemapPageNotFound
+	}
+
+	doc, err := s.cached(fmt.Sprintf("contents-%d", page), func() ([]sitemap.URL, error) {
+		entries, err := s.sitemapRepository.GetContentEntries(ctx, (page-1)*sitemapPageSize+1, page*sitemapPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		urls := []sitemap.URL{}
+		for _, entry := range entries {
+			urls = append(urls, sitemap.URL{Loc: s.cfg.ContentURL(entry.Slug), LastMod: entry.LastMod})
+		}
+
+		return urls, nil
+	})
+	if err != nil {
+		code = "[SERVICE] GetContentSitemap - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// GetCategorySitemap implements SitemapService.
+func (s *sitemapService) GetCategorySitemap(ctx context.Context, page int64) ([]byte, error) {
+	err := s.load(ctx)
+	if err != nil {
+		code = "[SERVICE] GetCategorySitemap - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	s.mu.Lock()
+	_, ok := s.categoryPages[page]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrSitemapPageNotFound
+	}
+
+	doc, err := s.cached(fmt.Sprintf("categories-%d", page), func() ([]sitemap.URL, error) {
+		entries, err := s.sitemapRepository.GetCategoryEntries(ctx, (page-1)*sitemapPageSize+1, page*sitemapPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		urls := []sitemap.URL{}
+		for _, entry := range entries {
+			urls = append(urls, sitemap.URL{Loc: s.cfg.CategoryURL(entry.Slug), LastMod: entry.LastMod})
+		}
+
+		return urls, nil
+	})
+	if err != nil {
+		code = "[SERVICE] GetCategorySitemap - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// GetTagSitemap implements SitemapService.
+func (s *sitemapService) GetTagSitemap(ctx context.Context, page int64) ([]byte, error) {
+	tags, err := s.loadTags(ctx)
+	if err != nil {
+		code = "[SERVICE] GetTagSitemap - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+	if len(tagPage(tags, page)) == 0 {
+		return nil, ErrSitemapPageNotFound
+	}
+
+	doc, err := s.cached(fmt.Sprintf("tags-%d", page), func() ([]sitemap.URL, error) {
+		urls := []sitemap.URL{}
+		for _, tag := range tagPage(tags, page) {
+			urls = append(urls, sitemap.URL{Loc: s.cfg.TagURL(tag.Slug), LastMod: tag.LastMod})
+		}
+
+		return urls, nil
+	})
+	if err != nil {
+		code = "[SERVICE] GetTagSitemap - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// GetNewsSitemap implements SitemapService.
+func (s *sitemapService) GetNewsSitemap(ctx context.Context) ([]byte, error) {
+	// Stories age out of the 48 hour window on their own, so the cached
+	// copy also expires after a few minutes.
+	s.mu.Lock()
+	if s.news != nil && time.Since(s.newsAt) < newsSitemapTTL {
+		defer s.mu.Unlock()
+		return s.news, nil
+	}
+	gen := s.gen
+	s.mu.Unlock()
+
+	doc, err, _ := s.group.Do("news", func() (interface{}, error) {
+		contents, err := s.sitemapRepository.GetNewsContents(ctx, time.Now().Add(-newsSitemapWindow), newsSitemapLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		urls := []sitemap.URL{}
+		for _, content := range contents {
+			urls = append(urls, sitemap.URL{
+				Loc: s.cfg.ContentURL(content.Slug),
+				News: &sitemap.News{
+					PublicationName: s.cfg.App.SiteName,
+					Language:        "id",
+					Title:           content.Title,
+					Keywords:        content.Tags,
+					PublishedAt:     *content.PublishedAt,
+				},
+			})
+		}
+
+		doc, err := sitemap.URLSet(urls)
+		if err != nil {
+			return nil, err
+		}
+
+		s.mu.Lock()
+		if s.gen == gen {
+			s.news = doc
+			s.newsAt = time.Now()
+		}
+		s.mu.Unlock()
+
+		return doc, nil
+	})
+	if err != nil {
+		code = "[SERVICE] GetNewsSitemap - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return doc.([]byte), nil
+}
+
+// ContentChanged implements SitemapService. before and after are the
+// content around the change, nil when it did not exist. Changes to contents
+// that are not in the sitemaps on either side, such as drafts, leave them
+// alone. Otherwise only the child sitemap holding the content is dropped.
+// The tags are marked stale and reloaded at most every tagSitemapRefresh,
+// so a burst of edits does not rescan them each time; the news sitemap is
+// rebuilt lazily.
+func (s *sitemapService) ContentChanged(contentID int64, before, after *entity.ContentEntity) {
+	listed := sitemapListed(before) || sitemapListed(after)
+	published := sitemapPublished(before) || sitemapPublished(after)
+	if !listed && !published {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.gen++
+	if listed {
+		page := (contentID-1)/sitemapPageSize + 1
+		if s.loaded {
+			s.contentPages[page] = time.Now()
+		}
+		delete(s.docs, fmt.Sprintf("contents-%d", page))
+	}
+
+	if published {
+		s.tagsStale = true
+		s.news = nil
+	}
+}
+
+// CategoryChanged implements SitemapService.
+func (s *sitemapService) CategoryChanged(categoryID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.gen++
+	page := (categoryID-1)/sitemapPageSize + 1
+	if s.loaded {
+		s.categoryPages[page] = time.Now()
+	}
+	delete(s.docs, fmt.Sprintf("categories-%d", page))
+}
+
+func (s *sitemapService) load(ctx context.Context) error {
+	s.mu.Lock()
+	loaded := s.loaded
+	gen := s.gen
+	s.mu.Unlock()
+	if loaded {
+		return nil
+	}
+
+	_, err, _ := s.group.Do("pages", func() (interface{}, error) {
+		contentPages, err := s.sitemapRepository.GetContentPageLastMods(ctx, sitemapPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		categoryPages, err := s.sitemapRepository.GetCategoryPageLastMods(ctx, sitemapPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.contentPages = contentPages
+		s.categoryPages = categoryPages
+		// A change made while loading may be missing; load again next
+		// time rather than keep it.
+		s.loaded = s.gen == gen
+		return nil, nil
+	})
+
+	return err
+}
+
+// loadTags returns the tags, reloading them when they are missing or stale
+// and were last loaded over tagSitemapRefresh ago. Reloading drops the
+// cached tag sitemaps.
+func (s *sitemapService) loadTags(ctx context.Context) ([]entity.SitemapEntryEntity, error) {
+	s.mu.Lock()
+	if s.tags != nil && (!s.tagsStale || time.Since(s.tagsAt) < tagSitemapRefresh) {
+		defer s.mu.Unlock()
+		return s.tags, nil
+	}
+	s.mu.Unlock()
+
+	tags, err, _ := s.group.Do("tags", func() (interface{}, error) {
+		loadedAt := time.Now()
+		tags, err := s.sitemapRepository.GetTagEntries(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if tags == nil {
+			tags = []entity.SitemapEntryEntity{}
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.tags = tags
+		s.tagsAt = loadedAt
+		s.tagsStale = false
+		s.gen++
+		for key := range s.docs {
+			if strings.HasPrefix(key, "tags-") {
+				delete(s.docs, key)
+			}
+		}
+
+		return tags, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags.([]entity.SitemapEntryEntity), nil
+}
+
+// cached returns the document stored under key, or builds and stores it.
+// A change made while building means the result may be outdated, so it is
+// returned but not kept.
+func (s *sitemapService) cached(key string, build func() ([]sitemap.URL, error)) ([]byte, error) {
+	s.mu.Lock()
+	doc, ok := s.docs[key]
+	gen := s.gen
+	s.mu.Unlock()
+	if ok {
+		return doc, nil
+	}
+
+	res, err, _ := s.group.Do(key, func() (interface{}, error) {
+		urls, err := build()
+		if err != nil {
+			return nil, err
+		}
+
+		doc, err := sitemap.URLSet(urls)
+		if err != nil {
+			return nil, err
+		}
+
+		s.mu.Lock()
+		if s.gen == gen {
+			s.docs[key] = doc
+		}
+		s.mu.Unlock()
+
+		return doc, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]byte), nil
+}
+
+// sitemapPublished reports whether content counts towards the tag and news
+// sitemaps.
+func sitemapPublished(content *entity.ContentEntity) bool {
+	return content != nil && content.Status == entity.ContentStatusPublish
+}
+
+// sitemapListed reports whether content has a URL in the content sitemaps.
+func sitemapListed(content *entity.ContentEntity) bool {
+	return sitemapPublished(content) && !content.NoIndex
+}
+
+func pagesToSitemaps(format string, pages map[int64]time.Time) []sitemap.Sitemap {
+	keys := make([]int64, 0, len(pages))
+	for page := range pages {
+		keys = append(keys, page)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	res := []sitemap.Sitemap{}
+	for _, page := range keys {
+		res = append(res, sitemap.Sitemap{Loc: fmt.Sprintf(format, page), LastMod: pages[page]})
+	}
+
+	return res
+}
+
+func tagPage(tags []entity.SitemapEntryEntity, page int64) []entity.SitemapEntryEntity {
+	from := (page - 1) * sitemapPageSize
+	if page < 1 || from >= int64(len(tags)) {
+		return nil
+	}
+
+	to := from + sitemapPageSize
+	if to > int64(len(tags)) {
+		to = int64(len(tags))
+	}
+
+	return tags[from:to]
+}
+
+func NewSitemapService(sitemapRepo repository.SitemapRepository, cfg *config.Config) SitemapService {
+	return &sitemapService{
+		sitemapRepository: sitemapRepo,
+		cfg:               cfg,
+		docs:              map[string][]byte{},
+	}
+}
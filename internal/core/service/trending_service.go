@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+const (
+	trendingInterval = 5 * time.Minute
+	// trendingWindow is how far back views count. It has to stay within
+	// hourlyViewRetention.
+	trendingWindow = 48 * time.Hour
+	// trendingDecay is the time after which a view weighs 1/e of a fresh
+	// one, so the score follows the current view rate.
+	trendingDecay = 6 * time.Hour
+	// trendingGravity is how strongly the age of a story pulls it down, as
+	// in the Hacker News ranking.
+	trendingGravity = 1.5
+
+	MaxTrending = 50
+)
+
+type TrendingService interface {
+	GetTrending(ctx context.Context, categoryID int64, limit int) ([]entity.TrendingEntity, error)
+	RunWorker(ctx context.Context)
+}
+
+type trendingService struct {
+	viewRepository repository.ViewRepository
+	imageService   ImageService
+}
+
+// GetTrending implements TrendingService. It reads the ranking stored by the
+// last run of RunWorker.
+func (t *trendingService) GetTrending(ctx context.Context, categoryID int64, limit int) ([]entity.TrendingEntity, error) {
+	if limit <= 0 || limit > MaxTrending {
+		limit = MaxTrending
+	}
+
+	results, err := t.viewRepository.GetTrending(ctx, categoryID, limit)
+	if err != nil {
+		code = "[SERVICE] GetTrending - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].Content.ImageVariants = t.imageService.ImageVariants(results[i].Content.Media)
+	}
+
+	return results, nil
+}
+
+// RunWorker implements TrendingService. It recomputes the ranking right away
+// and then every trendingInterval until ctx is done.
+func (t *trendingService) RunWorker(ctx context.Context) {
+	ticker := time.NewTicker(trendingInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := t.viewRepository.RecomputeTrending(ctx, entity.TrendingQueryEntity{
+			Window:  trendingWindow,
+			Decay:   trendingDecay,
+			Gravity: trendingGravity,
+		})
+		if err != nil && ctx.Err() == nil {
+			code = "[SERVICE] RunWorker - 1"
+			log.Errorw(code, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func NewTrendingService(viewRepo repository.ViewRepository, imageService ImageService) TrendingService {
+	return &trendingService{viewRepository: viewRepo, imageService: imageService}
+}
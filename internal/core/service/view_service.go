@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"news-app/internal/adapter/repository"
+	"news-app/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+const (
+	// viewDedupWindow is how long repeated views of a story by the same
+	// visitor count once.
+	viewDedupWindow = 30 * time.Minute
+	// viewSeenLimit bounds the memory used for deduplication; past it the
+	// older half of the window is forgotten early, and everything if that
+	// is not enough.
+	viewSeenLimit = 200000
+
+	viewFlushInterval = 10 * time.Second
+	// viewBufferLimit is the number of buffered counters that triggers a
+	// flush before the next tick.
+	viewBufferLimit = 5000
+	// hourlyViewRetention keeps hourly counters long enough for every
+	// window served from them; older ones only live on in the daily table.
+	hourlyViewRetention = 7 * 24 * time.Hour
+
+	mostReadCacheTTL = time.Minute
+	MaxMostRead      = 50
+)
+
+var ErrUnknownWindow = errors.New("window must be one of 1h, 24h, 7d or 30d")
+
+type mostReadWindow struct {
+	duration time.Duration
+	daily    bool
+}
+
+// mostReadWindows are the supported windows; the long ones are read from the
+// daily counters.
+var mostReadWindows = map[string]mostReadWindow{
+	"1h":  {duration: time.Hour},
+	"24h": {duration: 24 * time.Hour},
+	"7d":  {duration: 7 * 24 * time.Hour, daily: true},
+	"30d": {duration: 30 * 24 * time.Hour, daily: true},
+}
+
+type ViewService interface {
+	RecordView(contentID int64, viewer entity.ViewerEntity) bool
+	GetMostRead(ctx context.Context, window string, categoryID int64, limit int) ([]entity.MostReadEntity, error)
+	RunWriter(ctx context.Context)
+	DeleteOldViews(ctx context.Context) (int64, error)
+}
+
+type viewerKey struct {
+	contentID int64
+	visitor   [16]byte
+}
+
+type viewCountKey struct {
+	contentID int64
+	hour      time.Time
+}
+
+type mostReadCacheEntry struct {
+	results  []entity.MostReadEntity
+	loadedAt time.Time
+}
+
+type viewService struct {
+	viewRepository repository.ViewRepository
+	imageService   ImageService
+
+	mu     sync.Mutex
+	seen   map[viewerKey]time.Time
+	counts map[viewCountKey]int64
+	full   chan struct{}
+
+	cacheMu sync.Mutex
+	cache   map[string]mostReadCacheEntry
+}
+
+// RecordView implements ViewService. The view is only buffered; RunWriter
+// stores it. It returns false when the view was not counted because it came
+// from the same visitor within viewDedupWindow. Deduplication is per
+// instance, so a visitor balanced across several instances may count more
+// than once.
+func (v *viewService) RecordView(contentID int64, viewer entity.ViewerEntity) bool {
+	key := viewerKey{contentID: contentID}
+	sum := sha256.Sum256([]byte(viewer.IP + "\x00" + viewer.UserAgent))
+	copy(key.visitor[:], sum[:])
+
+	now := time.Now()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if seenAt, ok := v.seen[key]; ok && now.Sub(seenAt) < viewDedupWindow {
+		return false
+	}
+	if len(v.seen) >= viewSeenLimit {
+		v.pruneSeen(now.Add(-viewDedupWindow / 2))
+		if len(v.seen) >= viewSeenLimit {
+			v.seen = map[viewerKey]time.Time{}
+		}
+	}
+	v.seen[key] = now
+
+	v.counts[viewCountKey{contentID: contentID, hour: now.UTC().Truncate(time.Hour)}]++
+	if len(v.counts) >= viewBufferLimit {
+		select {
+		case v.full <- struct{}{}:
+		default:
+		}
+	}
+
+	return true
+}
+
+// GetMostRead implements ViewService.
+func (v *viewService) GetMostRead(ctx context.Context, window string, categoryID int64, limit int) ([]entity.MostReadEntity, error) {
+	w, ok := mostReadWindows[window]
+	if !ok {
+		return nil, ErrUnknownWindow
+	}
+	if limit <= 0 || limit > MaxMostRead {
+		limit = MaxMostRead
+	}
+
+	key := fmt.Sprintf("%s-%d-%d", window, categoryID, limit)
+	v.cacheMu.Lock()
+	cached, ok := v.cache[key]
+	v.cacheMu.Unlock()
+	if ok && time.Since(cached.loadedAt) < mostReadCacheTTL {
+		return cached.results, nil
+	}
+
+	results, err := v.viewRepository.GetMostRead(ctx, entity.MostReadQueryEntity{
+		Since:      time.Now().Add(-w.duration),
+		Daily:      w.daily,
+		CategoryID: categoryID,
+		Limit:      limit,
+	})
+	if err != nil {
+		code = "[SERVICE] GetMostRead - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].Content.ImageVariants = v.imageService.ImageVariants(results[i].Content.Media)
+	}
+
+	v.cacheMu.Lock()
+	v.cache[key] = mostReadCacheEntry{results: results, loadedAt: time.Now()}
+	v.cacheMu.Unlock()
+
+	return results, nil
+}
+
+// RunWriter implements ViewService. It writes the buffered counters every
+// viewFlushInterval, or sooner when the buffer fills up, until ctx is done,
+// then writes what is left.
+func (v *viewService) RunWriter(ctx context.Context) {
+	ticker := time.NewTicker(viewFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			v.flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			v.flush(ctx)
+		case <-v.full:
+			v.flush(ctx)
+		}
+	}
+}
+
+// DeleteOldViews implements ViewService.
+func (v *viewService) DeleteOldViews(ctx context.Context) (int64, error) {
+	deleted, err := v.viewRepository.DeleteHourlyViewsBefore(ctx, time.Now().Add(-hourlyViewRetention))
+	if err != nil {
+		code = "[SERVICE] DeleteOldViews - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// flush swaps the buffer for an empty one and writes it. Counters that fail
+// to be written are put back for the next attempt. When the batch is
+// rejected for its values, the counters are written one at a time instead
+// and the ones rejected on their own are dropped, so a bad counter cannot
+// hold back every other one.
+func (v *viewService) flush(ctx context.Context) {
+	v.mu.Lock()
+	counts := v.counts
+	v.counts = map[viewCountKey]int64{}
+	v.pruneSeen(time.Now().Add(-viewDedupWindow))
+	v.mu.Unlock()
+
+	if len(counts) == 0 {
+		return
+	}
+
+	batch := make([]entity.ContentViewCountEntity, 0, len(counts))
+	for key, views := range counts {
+		batch = append(batch, entity.ContentViewCountEntity{ContentID: key.contentID, Hour: key.hour, Views: views})
+	}
+
+	err := v.viewRepository.AddViews(ctx, batch)
+	if err == nil {
+		return
+	}
+	code = "[SERVICE] flush - 1"
+	log.Errorw(code, err)
+
+	failed := batch
+	if errors.Is(err, repository.ErrViewCountRejected) {
+		failed = nil
+		for _, count := range batch {
+			err = v.viewRepository.AddViews(ctx, []entity.ContentViewCountEntity{count})
+			if errors.Is(err, repository.ErrViewCountRejected) {
+				code = "[SERVICE] flush - 2"
+				log.Errorw(code, fmt.Errorf("dropping %d views of content %d: %w", count.Views, count.ContentID, err))
+				continue
+			}
+			if err != nil {
+				failed = append(failed, count)
+			}
+		}
+	}
+
+	v.mu.Lock()
+	for _, count := range failed {
+		v.counts[viewCountKey{contentID: count.ContentID, hour: count.Hour}] += count.Views
+	}
+	v.mu.Unlock()
+}
+
+// pruneSeen forgets visitors seen before cutoff. The caller holds v.mu.
+func (v *viewService) pruneSeen(cutoff time.Time) {
+	for key, seenAt := range v.seen {
+		if seenAt.Before(cutoff) {
+			delete(v.seen, key)
+		}
+	}
+}
+
+func NewViewService(viewRepo repository.ViewRepository, imageService ImageService) ViewService {
+	return &viewService{
+		viewRepository: viewRepo,
+		imageService:   imageService,
+		seen:           map[viewerKey]time.Time{},
+		counts:         map[viewCountKey]int64{},
+		full:           make(chan struct{}, 1),
+		cache:          map[string]mostReadCacheEntry{},
+	}
+}
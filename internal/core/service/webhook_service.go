@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"news-app/config"
+	"news-app/internal/adapter/repository"
+	"news-app/internal/adapter/webhook"
+	"news-app/internal/core/domain/entity"
+	webhookLib "news-app/lib/webhook"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+const (
+	webhookPollInterval = 5 * time.Second
+	webhookBatchSize    = 20
+	// webhookLease is how long a claimed delivery is left to its worker. It
+	// has to outlast an attempt.
+	webhookLease = 2 * time.Minute
+
+	// webhookMaxAttempts and the backoff, doubling from webhookRetryBase up
+	// to webhookRetryMax, give a receiver over half a day to come back.
+	webhookMaxAttempts = 12
+	webhookRetryBase   = 30 * time.Second
+	webhookRetryMax    = 6 * time.Hour
+)
+
+var (
+	ErrUnknownWebhookEvent = errors.New("unknown webhook event type")
+	ErrInvalidWebhookURL   = errors.New("webhook url must be an absolute http or https url")
+)
+
+type WebhookService interface {
+	GetWebhooks(ctx context.Context, query entity.QueryString) ([]entity.WebhookEntity, int64, error)
+	GetWebhookByID(ctx context.Context, id int64) (*entity.WebhookEntity, error)
+	CreateWebhook(ctx context.Context, req entity.WebhookEntity) (*entity.WebhookEntity, error)
+	UpdateWebhook(ctx context.Context, req entity.WebhookEntity) (*entity.WebhookEntity, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+
+	GetDeliveries(ctx context.Context, webhookID int64, query entity.QueryString) ([]entity.WebhookDeliveryEntity, int64, error)
+	GetDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDeliveryEntity, error)
+	Redeliver(ctx context.Context, id int64) (*entity.WebhookDeliveryEntity, error)
+
+	HandleEvent(ctx context.Context, event entity.DomainEventEntity) error
+	RunWorker(ctx context.Context)
+}
+
+type webhookPayload struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt string      `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+type webhookContentData struct {
+	contentEvent
+	URL string `json:"url"`
+}
+
+type webhookService struct {
+	webhookRepository repository.WebhookRepository
+	webhookAdapter    webhook.WebhookAdapter
+	cfg               *config.Config
+}
+
+// GetWebhooks implements WebhookService.
+func (w *webhookService) GetWebhooks(ctx context.Context, query entity.QueryString) ([]entity.WebhookEntity, int64, error) {
+	results, totalData, err := w.webhookRepository.GetWebhooks(ctx, query)
+	if err != nil {
+		code = "[SERVICE] GetWebhooks - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	return results, totalData, nil
+}
+
+// GetWebhookByID implements WebhookService.
+func (w *webhookService) GetWebhookByID(ctx context.Context, id int64) (*entity.WebhookEntity, error) {
+	result, err := w.webhookRepository.GetWebhookByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] GetWebhookByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateWebhook implements WebhookService. Without a secret one is
+// generated; the caller gets it once, in the result.
+func (w *webhookService) CreateWebhook(ctx context.Context, req entity.WebhookEntity) (*entity.WebhookEntity, error) {
+	err = w.checkWebhook(&req)
+	if err != nil {
+		code = "[SERVICE] CreateWebhook - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if req.Secret == "" {
+		req.Secret, err = webhookLib.NewSecret()
+		if err != nil {
+			code = "[SERVICE] CreateWebhook - 2"
+			log.Errorw(code, err)
+			return nil, err
+		}
+	}
+
+	result, err := w.webhookRepository.CreateWebhook(ctx, req)
+	if err != nil {
+		code = "[SERVICE] CreateWebhook - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateWebhook implements WebhookService.
+func (w *webhookService) UpdateWebhook(ctx context.Context, req entity.WebhookEntity) (*entity.WebhookEntity, error) {
+	err = w.checkWebhook(&req)
+	if err != nil {
+		code = "[SERVICE] UpdateWebhook - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	result, err := w.webhookRepository.UpdateWebhook(ctx, req)
+	if err != nil {
+		code = "[SERVICE] UpdateWebhook - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteWebhook implements WebhookService.
+func (w *webhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	err = w.webhookRepository.DeleteWebhook(ctx, id)
+	if err != nil {
+		code = "[SERVICE] DeleteWebhook - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetDeliveries implements WebhookService.
+func (w *webhookService) GetDeliveries(ctx context.Context, webhookID int64, query entity.QueryString) ([]entity.WebhookDeliveryEntity, int64, error) {
+	_, err = w.webhookRepository.GetWebhookByID(ctx, webhookID)
+	if err != nil {
+		code = "[SERVICE] GetDeliveries - 1"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	results, totalData, err := w.webhookRepository.GetDeliveries(ctx, webhookID, query)
+	if err != nil {
+		code = "[SERVICE] GetDeliveries - 2"
+		log.Errorw(code, err)
+		return nil, 0, err
+	}
+
+	return results, totalData, nil
+}
+
+// GetDeliveryByID implements WebhookService.
+func (w *webhookService) GetDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDeliveryEntity, error) {
+	result, err := w.webhookRepository.GetDeliveryByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] GetDeliveryByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Redeliver implements WebhookService. The delivery is sent again with the
+// same payload and event id, so receivers can tell it is a repeat.
+func (w *webhookService) Redeliver(ctx context.Context, id int64) (*entity.WebhookDeliveryEntity, error) {
+	err = w.webhookRepository.RedeliverDelivery(ctx, id, time.Now())
+	if err != nil {
+		code = "[SERVICE] Redeliver - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return w.GetDeliveryByID(ctx, id)
+}
+
+// HandleEvent implements WebhookService. The content event is queued for
+// every webhook subscribed to it, with the event id as its own. An event
+// handed over again queues nothing new.
+func (w *webhookService) HandleEvent(ctx context.Context, event entity.DomainEventEntity) error {
+	var data webhookContentData
+	err := json.Unmarshal([]byte(event.Payload), &data.contentEvent)
+	if err != nil {
+		code = "[SERVICE] HandleEvent - 1"
+		log.Errorw(code, err)
+		return err
+	}
+	data.URL = w.cfg.ContentURL(data.Slug)
+
+	payload, err := json.Marshal(webhookPayload{
+		ID:        event.EventID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt.Format(time.RFC3339),
+		Data:      data,
+	})
+	if err != nil {
+		code = "[SERVICE] HandleEvent - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	_, err = w.webhookRepository.EnqueueDeliveries(ctx, event.EventID, event.Type, string(payload))
+	if err != nil {
+		code = "[SERVICE] HandleEvent - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// RunWorker implements WebhookService. It sends the due deliveries every
+// webhookPollInterval until ctx is done, and keeps going while there is a
+// backlog.
+func (w *webhookService) RunWorker(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for w.deliverDue(ctx) == webhookBatchSize && ctx.Err() == nil {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverDue sends one batch of due deliveries at once and returns its
+// size.
+func (w *webhookService) deliverDue(ctx context.Context) int {
+	now := time.Now()
+	deliveries, err := w.webhookRepository.ClaimDueDeliveries(ctx, now, now.Add(webhookLease), webhookBatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			code = "[SERVICE] deliverDue - 1"
+			log.Errorw(code, err)
+		}
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery entity.WebhookDeliveryEntity) {
+			defer wg.Done()
+			w.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries)
+}
+
+// deliver makes one attempt at a delivery. Any 2xx response is a success;
+// anything else is retried until webhookMaxAttempts.
+func (w *webhookService) deliver(ctx context.Context, delivery entity.WebhookDeliveryEntity) {
+	start := time.Now()
+	resp, err := w.webhookAdapter.Send(ctx, entity.WebhookRequestEntity{
+		URL:        delivery.Webhook.URL,
+		Secret:     delivery.Webhook.Secret,
+		DeliveryID: delivery.ID,
+		EventType:  delivery.EventType,
+		Payload:    []byte(delivery.Payload),
+	})
+	// Shutting down is not the receiver's fault; the lease runs out and
+	// the attempt is made again.
+	if ctx.Err() != nil {
+		return
+	}
+
+	attempt := entity.WebhookAttemptEntity{
+		DeliveryID: delivery.ID,
+		Duration:   time.Since(start),
+		CreatedAt:  start,
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	} else {
+		attempt.ResponseCode = resp.StatusCode
+		attempt.ResponseBody = resp.Body
+	}
+
+	status := entity.WebhookDeliveryPending
+	var nextAttemptAt *time.Time
+	switch {
+	case err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300:
+		status = entity.WebhookDeliverySuccess
+	case delivery.Attempts+1 >= webhookMaxAttempts:
+		status = entity.WebhookDeliveryFailed
+	default:
+		next := time.Now().Add(webhookBackoff(delivery.Attempts + 1))
+		nextAttemptAt = &next
+	}
+
+	err = w.webhookRepository.RecordDeliveryAttempt(ctx, attempt, status, nextAttemptAt)
+	if err != nil {
+		code = "[SERVICE] deliver - 1"
+		log.Errorw(code, err)
+	}
+}
+
+// checkWebhook validates a webhook and drops repeated event types.
+func (w *webhookService) checkWebhook(req *entity.WebhookEntity) error {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	eventTypes := []string{}
+	for _, eventType := range req.EventTypes {
+		if !slices.Contains(entity.WebhookEventTypes, eventType) {
+			return fmt.Errorf("%w: %s", ErrUnknownWebhookEvent, eventType)
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	req.EventTypes = eventTypes
+
+	return nil
+}
+
+// webhookBackoff is the wait after the given failed attempt, with up to a
+// fifth added at random so receivers coming back are not hit all at once.
+func webhookBackoff(attempt int) time.Duration {
+	wait := webhookRetryMax
+	if attempt < 20 {
+		wait = min(webhookRetryBase<<(attempt-1), webhookRetryMax)
+	}
+
+	return wait + rand.N(wait/5+1)
+}
+
+func NewWebhookService(webhookRepo repository.WebhookRepository, webhookAdapter webhook.WebhookAdapter, cfg *config.Config) WebhookService {
+	return &webhookService{
+		webhookRepository: webhookRepo,
+		webhookAdapter:    webhookAdapter,
+		cfg:               cfg,
+	}
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		base    time.Duration
+	}{
+		{attempt: 1, base: webhookRetryBase},
+		{attempt: 2, base: 2 * webhookRetryBase},
+		{attempt: 3, base: 4 * webhookRetryBase},
+		{attempt: 11, base: webhookRetryMax},
+		{attempt: webhookMaxAttempts, base: webhookRetryMax},
+		{attempt: 64, base: webhookRetryMax},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			wait := webhookBackoff(tt.attempt)
+			if wait < tt.base || wait > tt.base+tt.base/5 {
+				t.Fatalf("webhookBackoff(%d) = %v, want between %v and %v", tt.attempt, wait, tt.base, tt.base+tt.base/5)
+			}
+		}
+	}
+}
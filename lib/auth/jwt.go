@@ -53,8 +53,14 @@ func (o *Options) VeryfyToken(token string) (*entity.JwtData, error) {
 			return nil, err
 		}
 
-		jwtData := &entity.JwtData{
-			UserID: claim["user_id"].(float64),
+		jwtData := &entity.JwtData{}
+		jwtData.UserID, _ = claim["user_id"].(float64)
+		jwtData.ReaderID, _ = claim["reader_id"].(float64)
+		jwtData.Kind, _ = claim["kind"].(string)
+		// Tokens issued before readers existed carry no kind and are all
+		// staff tokens.
+		if jwtData.Kind == "" {
+			jwtData.Kind = entity.JwtKindStaff
 		}
 
 		return jwtData, nil
@@ -2,7 +2,6 @@ package conv
 
 import (
 	"strconv"
-	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -18,13 +17,6 @@ func CheckPasswordHash(password, hash string) bool {
 	return err == nil
 }
 
-func GeneratesSlug(title string) string {
-	slug := strings.ToLower(title)
-	slug = strings.ReplaceAll(slug, " ", "-")
-
-	return slug
-}
-
 func StringToInt64(s string) (int64, error) {
 	newData, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
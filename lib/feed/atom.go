@@ -0,0 +1,113 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"time"
+)
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Links      []atomLink     `xml:"link"`
+	Author     *atomAuthor    `xml:"author,omitempty"`
+	Categories []atomCategory `xml:"category"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Content    *atomText      `xml:"content,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Atom renders the feed as Atom 1.0, with the article HTML as an escaped
+// type="html" content element.
+func (f *Feed) Atom() ([]byte, error) {
+	doc := atomFeed{
+		Title:   f.Title,
+		ID:      f.Link,
+		Updated: f.Updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.Link, Rel: "alternate"},
+			{Href: f.FeedLink, Rel: "self", Type: "application/atom+xml"},
+		},
+	}
+
+	for _, item := range f.Items {
+		updated := item.Updated
+		if updated.IsZero() {
+			updated = item.Published
+		}
+
+		entry := atomEntry{
+			Title:     item.Title,
+			ID:        item.ID,
+			Updated:   updated.Format(time.RFC3339),
+			Published: item.Published.Format(time.RFC3339),
+			Links:     []atomLink{{Href: item.Link, Rel: "alternate"}},
+		}
+		if item.Author != "" {
+			entry.Author = &atomAuthor{Name: item.Author}
+		}
+		for _, category := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+		if item.Summary != "" {
+			entry.Summary = &atomText{Type: "text", Value: item.Summary}
+		}
+		if item.ContentHTML != "" {
+			entry.Content = &atomText{Type: "html", Value: item.ContentHTML}
+		}
+		if item.Image != "" {
+			entry.Links = append(entry.Links, atomLink{Href: item.Image, Rel: "enclosure", Type: imageType(item), Length: item.ImageSize})
+		}
+
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return marshalXML(doc, "")
+}
+
+// marshalXML encodes v with the XML header. extraNS is added to the root
+// element for namespaces encoding/xml cannot declare on its own.
+func marshalXML(v interface{}, extraNS string) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if extraNS != "" {
+		if i := bytes.IndexByte(body, '>'); i > 0 {
+			body = append(body[:i:i], append([]byte(" "+extraNS), body[i:]...)...)
+		}
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
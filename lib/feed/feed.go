@@ -0,0 +1,52 @@
+package feed
+
+import (
+	"mime"
+	"path"
+	"strings"
+	"time"
+)
+
+type Feed struct {
+	Title       string
+	Description string
+	Link        string
+	FeedLink    string
+	Language    string
+	Updated     time.Time
+	Items       []Item
+}
+
+type Item struct {
+	ID          string
+	Title       string
+	Link        string
+	Summary     string
+	ContentHTML string
+	Author      string
+	Categories  []string
+	Image       string
+	ImageSize   int64 // bytes, 0 when unknown
+	ImageType   string
+	Published   time.Time
+	Updated     time.Time
+}
+
+// imageType returns the MIME type of the image of item, guessed from its
+// file extension when it is not known.
+func imageType(item Item) string {
+	if item.ImageType != "" {
+		return item.ImageType
+	}
+
+	url := item.Image
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		url = url[:i]
+	}
+
+	if t := mime.TypeByExtension(strings.ToLower(path.Ext(url))); t != "" {
+		return t
+	}
+
+	return "image/jpeg"
+}
@@ -0,0 +1,79 @@
+package feed
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type jsonFeed struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	HomePageURL string     `json:"home_page_url,omitempty"`
+	FeedURL     string     `json:"feed_url,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Language    string     `json:"language,omitempty"`
+	Items       []jsonItem `json:"items"`
+}
+
+type jsonItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html,omitempty"`
+	Summary       string           `json:"summary,omitempty"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified,omitempty"`
+	Authors       []jsonAuthor     `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Attachments   []jsonAttachment `json:"attachments,omitempty"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonAttachment struct {
+	URL         string `json:"url"`
+	MimeType    string `json:"mime_type"`
+	SizeInBytes int64  `json:"size_in_bytes,omitempty"`
+}
+
+// JSON renders the feed as JSON Feed 1.1.
+func (f *Feed) JSON() ([]byte, error) {
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.FeedLink,
+		Description: f.Description,
+		Language:    f.Language,
+		Items:       []jsonItem{},
+	}
+
+	for _, item := range f.Items {
+		jsonItem := jsonItem{
+			ID:            item.ID,
+			URL:           item.Link,
+			Title:         item.Title,
+			ContentHTML:   item.ContentHTML,
+			Summary:       item.Summary,
+			Image:         item.Image,
+			DatePublished: item.Published.Format(time.RFC3339),
+			Tags:          item.Categories,
+		}
+		if !item.Updated.IsZero() {
+			jsonItem.DateModified = item.Updated.Format(time.RFC3339)
+		}
+		if item.Author != "" {
+			jsonItem.Authors = []jsonAuthor{{Name: item.Author}}
+		}
+		if item.Image != "" {
+			jsonItem.Attachments = []jsonAttachment{{URL: item.Image, MimeType: imageType(item), SizeInBytes: item.ImageSize}}
+		}
+
+		doc.Items = append(doc.Items, jsonItem)
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
@@ -0,0 +1,93 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	AtomLink      rssLink   `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	Author      string        `xml:"dc:creator,omitempty"`
+	Categories  []string      `xml:"category"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// RSS renders the feed as RSS 2.0. Article HTML goes into the description
+// as escaped text, which is how RSS readers expect it.
+func (f *Feed) RSS() ([]byte, error) {
+	channel := rssChannel{
+		Title:       f.Title,
+		Link:        f.Link,
+		Description: f.Description,
+		Language:    f.Language,
+		AtomLink:    rssLink{Href: f.FeedLink, Rel: "self", Type: "application/rss+xml"},
+	}
+	if !f.Updated.IsZero() {
+		channel.LastBuildDate = f.Updated.Format(time.RFC1123Z)
+	}
+
+	for _, item := range f.Items {
+		description := item.ContentHTML
+		if description == "" {
+			description = item.Summary
+		}
+
+		rssItem := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: description,
+			Author:      item.Author,
+			Categories:  item.Categories,
+			GUID:        rssGUID{Value: item.ID, IsPermaLink: item.ID == item.Link},
+			PubDate:     item.Published.Format(time.RFC1123Z),
+		}
+		// An enclosure has to state its length, so images of unknown size
+		// are left out.
+		if item.Image != "" && item.ImageSize > 0 {
+			rssItem.Enclosure = &rssEnclosure{URL: item.Image, Length: item.ImageSize, Type: imageType(item)}
+		}
+
+		channel.Items = append(channel.Items, rssItem)
+	}
+
+	doc := rss{Version: "2.0", Atom: "http://www.w3.org/2005/Atom", Channel: channel}
+	return marshalXML(doc, `xmlns:dc="http://purl.org/dc/elements/1.1/"`)
+}
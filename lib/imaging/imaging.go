@@ -0,0 +1,202 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	DefaultVariants = "thumbnail:320x180,card:640x360,hero:1600x900"
+	// DefaultMaxPixels allows a 40 megapixel image, about 160 MB decoded.
+	DefaultMaxPixels = 40_000_000
+	jpegQuality      = 85
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("image must be a JPEG, PNG or WebP file")
+	ErrTooManyPixels     = errors.New("image has too many pixels")
+)
+
+type Variant struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+type Encoded struct {
+	Body        []byte
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+}
+
+// ParseVariants reads a "name:WIDTHxHEIGHT,..." list such as
+// DefaultVariants.
+func ParseVariants(spec string) ([]Variant, error) {
+	if strings.TrimSpace(spec) == "" {
+		spec = DefaultVariants
+	}
+
+	var res []Variant
+	for _, part := range strings.Split(spec, ",") {
+		name, size, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid image variant %q", part)
+		}
+
+		w, h, ok := strings.Cut(size, "x")
+		if !ok {
+			return nil, fmt.Errorf("invalid image variant size %q", size)
+		}
+
+		width, err := strconv.Atoi(w)
+		if err != nil || width <= 0 {
+			return nil, fmt.Errorf("invalid image variant width %q", w)
+		}
+
+		height, err := strconv.Atoi(h)
+		if err != nil || height <= 0 {
+			return nil, fmt.Errorf("invalid image variant height %q", h)
+		}
+
+		res = append(res, Variant{Name: name, Width: width, Height: height})
+	}
+
+	return res, nil
+}
+
+// FormatVariants writes variants in the form ParseVariants reads.
+func FormatVariants(variants []Variant) string {
+	parts := make([]string, 0, len(variants))
+	for _, v := range variants {
+		parts = append(parts, fmt.Sprintf("%s:%dx%d", v.Name, v.Width, v.Height))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Decode reads a JPEG, PNG or WebP image and applies the EXIF orientation
+// of JPEGs to the pixels, since the metadata is dropped on re-encoding. The
+// size is read from the header first and images of more than maxPixels are
+// refused before any pixel is allocated, as a small file can declare a huge
+// image.
+func Decode(data []byte, maxPixels int) (image.Image, error) {
+	if maxPixels <= 0 {
+		maxPixels = DefaultMaxPixels
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, ErrUnsupportedFormat
+		}
+		return nil, err
+	}
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > int64(maxPixels) {
+		return nil, ErrTooManyPixels
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, ErrUnsupportedFormat
+		}
+		return nil, err
+	}
+
+	switch format {
+	case "jpeg":
+		return orient(img, jpegOrientation(data)), nil
+	case "png", "webp":
+		return img, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// Fill scales and center crops img so it covers exactly the variant size.
+// Images smaller than the variant are not upscaled; they are cropped to the
+// variant's aspect ratio instead.
+func Fill(img image.Image, v Variant) image.Image {
+	src := img.Bounds()
+	srcW, srcH := src.Dx(), src.Dy()
+
+	cropW, cropH := cropSize(srcW, srcH, v)
+	crop := image.Rect(0, 0, cropW, cropH).Add(src.Min).Add(image.Pt((srcW-cropW)/2, (srcH-cropH)/2))
+
+	dstW, dstH := FillSize(srcW, srcH, v)
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+	return dst
+}
+
+// FillSize is the size of Fill(img, v) for an img of width x height.
+func FillSize(width, height int, v Variant) (int, int) {
+	cropW, cropH := cropSize(width, height, v)
+	if cropW < v.Width {
+		return cropW, cropH
+	}
+
+	return v.Width, v.Height
+}
+
+// cropSize is the largest rectangle with the variant's aspect ratio that
+// fits the source.
+func cropSize(width, height int, v Variant) (int, int) {
+	cropW, cropH := width, width*v.Height/v.Width
+	if cropH > height {
+		cropW, cropH = height*v.Width/v.Height, height
+	}
+
+	return cropW, cropH
+}
+
+// Encode writes img as a JPEG, or as a PNG when it has transparency. Neither
+// output carries any of the source's metadata.
+func Encode(img image.Image) (*Encoded, error) {
+	return encode(img, hasAlpha(img))
+}
+
+// EncodeLike writes img in the same format as ref, so variants of an image
+// always share its file type.
+func EncodeLike(img image.Image, ref *Encoded) (*Encoded, error) {
+	return encode(img, ref.ContentType == "image/png")
+}
+
+func encode(img image.Image, asPNG bool) (*Encoded, error) {
+	var buf bytes.Buffer
+	res := &Encoded{Width: img.Bounds().Dx(), Height: img.Bounds().Dy()}
+
+	if asPNG {
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+		res.ContentType, res.Extension = "image/png", ".png"
+	} else {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		res.ContentType, res.Extension = "image/jpeg", ".jpg"
+	}
+
+	res.Body = buf.Bytes()
+	return res, nil
+}
+
+func hasAlpha(img image.Image) bool {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok {
+		return !opaque.Opaque()
+	}
+
+	return false
+}
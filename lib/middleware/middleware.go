@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"news-app/internal/adapter/handler/response"
+	"news-app/internal/core/domain/entity"
 	"news-app/lib/auth"
 
 	"news-app/config"
@@ -13,24 +14,58 @@ import (
 
 type Middleware interface {
 	CheckToken() fiber.Handler
+	CheckReaderToken() fiber.Handler
+	OptionalReaderToken() fiber.Handler
 }
 
 type Options struct {
 	authJwt auth.Jwt
 }
 
-// CheckToken implements Middleware.
+// CheckToken implements Middleware. Only staff tokens are accepted.
 func (o *Options) CheckToken() fiber.Handler {
+	return o.checkToken(entity.JwtKindStaff)
+}
+
+// CheckReaderToken implements Middleware. Only reader tokens are accepted.
+func (o *Options) CheckReaderToken() fiber.Handler {
+	return o.checkToken(entity.JwtKindReader)
+}
+
+// OptionalReaderToken implements Middleware. A valid reader token signs the
+// reader in; without one the request goes on anonymously.
+func (o *Options) OptionalReaderToken() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+		if token == "" {
+			return c.Next()
+		}
+
+		claims, err := o.authJwt.VeryfyToken(token)
+		if err == nil && claims.Kind == entity.JwtKindReader {
+			c.Locals("user", claims)
+		}
+
+		return c.Next()
+	}
+}
+
+func (o *Options) checkToken(kind string) fiber.Handler {
 	var errorResponse response.ErrorResponseDefault
 	return func(c *fiber.Ctx) error {
 		authHandler := c.Get("Authorization")
+		// Browsers cannot set headers on a WebSocket handshake, so the
+		// token comes in the query string there.
+		if authHandler == "" && strings.EqualFold(c.Get(fiber.HeaderUpgrade), "websocket") {
+			authHandler = c.Query("access_token")
+		}
 		if authHandler == "" {
 			errorResponse.Meta.Status = false
 			errorResponse.Meta.Message = "Authorization header is missing"
 			return c.Status(fiber.StatusUnauthorized).JSON(errorResponse)
 		}
 
-		token := strings.Split(authHandler, "Bearer ")[1]
+		token := strings.TrimPrefix(authHandler, "Bearer ")
 		claims, err := o.authJwt.VeryfyToken(token)
 		if err != nil {
 			errorResponse.Meta.Status = false
@@ -38,6 +73,12 @@ func (o *Options) CheckToken() fiber.Handler {
 			return c.Status(fiber.StatusUnauthorized).JSON(errorResponse)
 		}
 
+		if claims.Kind != kind {
+			errorResponse.Meta.Status = false
+			errorResponse.Meta.Message = "Forbidden"
+			return c.Status(fiber.StatusForbidden).JSON(errorResponse)
+		}
+
 		c.Locals("user", claims)
 
 		return c.Next()
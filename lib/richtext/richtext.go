@@ -0,0 +1,112 @@
+package richtext
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+)
+
+var ErrUnknownFormat = errors.New("description format must be html or markdown")
+
+// embedHosts are the only sources allowed in iframes.
+var embedHosts = regexp.MustCompile(`^https://(www\.)?(youtube\.com/embed/|youtube-nocookie\.com/embed/|player\.vimeo\.com/video/|open\.spotify\.com/embed/|platform\.twitter\.com/embed/|www\.instagram\.com/p/|www\.google\.com/maps/embed|datawrapper\.dwcdn\.net/|flo\.uri\.sh/)`)
+
+var (
+	markdown = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		// Raw HTML is passed through so embeds written inline survive; the
+		// sanitizer runs on the output either way.
+		goldmark.WithRendererOptions(html.WithUnsafe()),
+	)
+	policy = newPolicy()
+)
+
+// Render turns source in the given format into HTML that is safe to serve
+// as is.
+func Render(format, source string) (string, error) {
+	switch format {
+	case FormatHTML, "":
+		return Sanitize(source), nil
+	case FormatMarkdown:
+		var buf bytes.Buffer
+		if err := markdown.Convert([]byte(source), &buf); err != nil {
+			return "", err
+		}
+		return Sanitize(buf.String()), nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// Sanitize strips everything from s that is not allowed in an article body.
+func Sanitize(s string) string {
+	return strings.TrimSpace(policy.Sanitize(s))
+}
+
+// newPolicy allows the markup a news article needs: text formatting, links,
+// images and figures, tables, quotes and embeds from known providers.
+// Scripts, styles, event handlers and forms are always removed.
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+
+	p.AllowStandardURLs()
+	p.AllowURLSchemes("http", "https", "mailto", "tel")
+
+	p.AllowElements(
+		"p", "br", "hr", "span", "div",
+		"h1", "h2", "h3", "h4", "h5", "h6",
+		"strong", "b", "em", "i", "u", "s", "del", "ins", "mark", "small", "sub", "sup",
+		"abbr", "cite", "q", "code", "pre", "kbd",
+		"ul", "ol", "li", "dl", "dt", "dd",
+		"figure", "figcaption", "picture",
+	)
+	p.AllowAttrs("title").OnElements("abbr")
+	p.AllowAttrs("cite").OnElements("blockquote", "q", "del", "ins")
+	p.AllowAttrs("start", "reversed").OnElements("ol")
+	p.AllowAttrs("id").Matching(regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowAttrs("title").OnElements("a")
+	p.RequireNoReferrerOnFullyQualifiedLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+
+	p.AllowImages()
+	p.AllowAttrs("srcset", "sizes", "loading").OnElements("img")
+	p.AllowAttrs("srcset", "sizes", "media", "type").OnElements("source")
+	p.AllowElements("source")
+
+	p.AllowTables()
+	p.AllowAttrs("align").Matching(regexp.MustCompile(`^(left|center|right)$`)).OnElements("th", "td")
+
+	p.AllowElements("blockquote")
+	// Twitter and Instagram render their embeds from a marked blockquote.
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^(twitter-tweet|instagram-media|tiktok-embed)$`)).OnElements("blockquote")
+	p.AllowDataAttributes()
+
+	p.AllowElements("iframe")
+	p.AllowAttrs("src").Matching(embedHosts).OnElements("iframe")
+	p.AllowAttrs("width", "height").Matching(bluemonday.Number).OnElements("iframe")
+	p.AllowAttrs("title", "allowfullscreen", "loading").OnElements("iframe")
+	p.AllowAttrs("allow").Matching(regexp.MustCompile(`^[a-z-]+(;\s*[a-z-]+)*;?$`)).OnElements("iframe")
+
+	p.AllowElements("video", "audio")
+	p.AllowAttrs("src", "poster", "controls", "width", "height", "preload").OnElements("video")
+	p.AllowAttrs("src", "controls", "preload").OnElements("audio")
+
+	// Articles are written by staff, so their links keep passing rank. Set
+	// last because AllowImages turns nofollow back on.
+	p.RequireNoFollowOnLinks(false)
+
+	return p
+}
@@ -0,0 +1,137 @@
+package richtext
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// WordsPerMinute is the reading speed used for ReadingTime.
+const WordsPerMinute = 200
+
+// PlainText returns the visible text of an HTML fragment with whitespace
+// collapsed.
+func PlainText(fragment string) string {
+	var b strings.Builder
+	walkText(fragment, func(text, _ string) {
+		b.WriteString(text)
+		b.WriteByte(' ')
+	})
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// WordCount counts the words in the visible text of an HTML fragment.
+func WordCount(fragment string) int {
+	return len(strings.Fields(PlainText(fragment)))
+}
+
+// ReadingTime returns the estimated reading time in whole minutes, rounded
+// up, and never less than one minute for a non-empty text.
+func ReadingTime(words int) int {
+	if words <= 0 {
+		return 0
+	}
+
+	return (words + WordsPerMinute - 1) / WordsPerMinute
+}
+
+// Excerpt builds a summary of at most max characters from the first
+// paragraphs of an HTML fragment, cut at a word boundary with an ellipsis
+// when the text does not fit. Headings and lists are left out; a fragment
+// without paragraphs falls back to all of its text.
+func Excerpt(fragment string, max int) string {
+	var paragraphs []string
+	var current strings.Builder
+	walkText(fragment, func(text, blockEnd string) {
+		current.WriteString(text)
+		if blockEnd == "" {
+			return
+		}
+
+		if p := strings.Join(strings.Fields(current.String()), " "); p != "" && blockEnd == "p" {
+			paragraphs = append(paragraphs, p)
+		}
+		current.Reset()
+	})
+
+	if len(paragraphs) == 0 {
+		return TruncateWords(PlainText(fragment), max)
+	}
+
+	return TruncateWords(strings.Join(paragraphs, " "), max)
+}
+
+// TruncateWords shortens text to at most max characters, cutting at a word
+// boundary and ending with an ellipsis.
+func TruncateWords(text string, max int) string {
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+
+	const ellipsis = "…"
+	runes := []rune(text)[:max-utf8.RuneCountInString(ellipsis)]
+
+	cut := len(runes)
+	for i := len(runes) - 1; i > 0; i-- {
+		if runes[i] == ' ' {
+			cut = i
+			break
+		}
+	}
+
+	return strings.TrimRight(string(runes[:cut]), " ,.;:-–—") + ellipsis
+}
+
+// blockElements end a run of text for Excerpt and separate words for
+// PlainText.
+var blockElements = map[string]bool{
+	"p": true, "div": true, "li": true, "blockquote": true, "pre": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// skippedElements hold no reader visible text.
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "figure": true, "table": true, "video": true, "audio": true,
+}
+
+// walkText calls fn with each run of text in fragment, and with the name of
+// every block element as it closes. Figures, tables and embeds are skipped
+// so captions and cell values do not end up in excerpts or word counts.
+func walkText(fragment string, fn func(text, blockEnd string)) {
+	tokenizer := html.NewTokenizer(strings.NewReader(fragment))
+	skip := 0
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return
+		case html.TextToken:
+			if skip == 0 {
+				fn(string(tokenizer.Text()), "")
+			}
+		case html.StartTagToken:
+			name, _ := tokenizer.TagName()
+			if skippedElements[string(name)] {
+				skip++
+			} else if string(name) == "br" && skip == 0 {
+				fn(" ", "")
+			}
+		case html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			if string(name) == "br" && skip == 0 {
+				fn(" ", "")
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if skippedElements[string(name)] {
+				if skip > 0 {
+					skip--
+				}
+			} else if blockElements[string(name)] && skip == 0 {
+				fn(" ", string(name))
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package seo
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Tag is a <meta> element. Open Graph tags use the property attribute and
+// everything else uses name.
+type Tag struct {
+	Attribute string
+	Key       string
+	Content   string
+}
+
+func Name(key, content string) Tag {
+	return Tag{Attribute: "name", Key: key, Content: content}
+}
+
+func Property(key, content string) Tag {
+	return Tag{Attribute: "property", Key: key, Content: content}
+}
+
+type Thing struct {
+	Type string `json:"@type"`
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+	ID   string `json:"@id,omitempty"`
+}
+
+type ImageObject struct {
+	Type   string `json:"@type"`
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+type Organization struct {
+	Type string       `json:"@type"`
+	Name string       `json:"name"`
+	URL  string       `json:"url,omitempty"`
+	Logo *ImageObject `json:"logo,omitempty"`
+}
+
+// NewsArticle is the schema.org NewsArticle subset search engines read.
+type NewsArticle struct {
+	Context             string        `json:"@context"`
+	Type                string        `json:"@type"`
+	Headline            string        `json:"headline"`
+	Description         string        `json:"description,omitempty"`
+	Image               []ImageObject `json:"image,omitempty"`
+	DatePublished       string        `json:"datePublished,omitempty"`
+	DateModified        string        `json:"dateModified,omitempty"`
+	Author              []Thing       `json:"author,omitempty"`
+	Publisher           Organization  `json:"publisher"`
+	MainEntityOfPage    Thing         `json:"mainEntityOfPage"`
+	ArticleSection      string        `json:"articleSection,omitempty"`
+	Keywords            string        `json:"keywords,omitempty"`
+	WordCount           int           `json:"wordCount,omitempty"`
+	InLanguage          string        `json:"inLanguage,omitempty"`
+	IsAccessibleForFree bool          `json:"isAccessibleForFree"`
+}
+
+// MaxHeadlineLength is the longest headline Google shows for articles.
+const MaxHeadlineLength = 110
+
+func NewNewsArticle(headline, canonicalURL string) NewsArticle {
+	return NewsArticle{
+		Context:             "https://schema.org",
+		Type:                "NewsArticle",
+		Headline:            headline,
+		MainEntityOfPage:    Thing{Type: "WebPage", ID: canonicalURL},
+		IsAccessibleForFree: true,
+	}
+}
+
+func (a NewsArticle) Marshal() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+// Time formats t as ISO 8601, or returns "" for the zero time.
+func Time(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,115 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+)
+
+// MaxURLs is the limit of URLs a single sitemap file may hold.
+const MaxURLs = 50000
+
+type URL struct {
+	Loc     string
+	LastMod time.Time
+	News    *News
+}
+
+type News struct {
+	PublicationName string
+	Language        string
+	Title           string
+	Keywords        []string
+	PublishedAt     time.Time
+}
+
+type Sitemap struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type urlSet struct {
+	XMLName xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	NewsNS  string   `xml:"xmlns:news,attr,omitempty"`
+	URLs    []xmlURL `xml:"url"`
+}
+
+type xmlURL struct {
+	Loc     string   `xml:"loc"`
+	LastMod string   `xml:"lastmod,omitempty"`
+	News    *xmlNews `xml:"news:news,omitempty"`
+}
+
+type xmlNews struct {
+	Publication     xmlPublication `xml:"news:publication"`
+	PublicationDate string         `xml:"news:publication_date"`
+	Title           string         `xml:"news:title"`
+	Keywords        string         `xml:"news:keywords,omitempty"`
+}
+
+type xmlPublication struct {
+	Name     string `xml:"news:name"`
+	Language string `xml:"news:language"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []xmlSitemap `xml:"sitemap"`
+}
+
+type xmlSitemap struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// URLSet renders a <urlset> document. The Google News namespace is declared
+// when any of the URLs carries news metadata.
+func URLSet(urls []URL) ([]byte, error) {
+	doc := urlSet{URLs: []xmlURL{}}
+	for _, url := range urls {
+		item := xmlURL{Loc: url.Loc, LastMod: formatTime(url.LastMod)}
+		if url.News != nil {
+			doc.NewsNS = "http://www.google.com/schemas/sitemap-news/0.9"
+			item.News = &xmlNews{
+				Publication: xmlPublication{
+					Name:     url.News.PublicationName,
+					Language: url.News.Language,
+				},
+				PublicationDate: formatTime(url.News.PublishedAt),
+				Title:           url.News.Title,
+				Keywords:        strings.Join(url.News.Keywords, ", "),
+			}
+		}
+
+		doc.URLs = append(doc.URLs, item)
+	}
+
+	return marshal(doc)
+}
+
+// Index renders a <sitemapindex> document.
+func Index(sitemaps []Sitemap) ([]byte, error) {
+	doc := sitemapIndex{Sitemaps: []xmlSitemap{}}
+	for _, sitemap := range sitemaps {
+		doc.Sitemaps = append(doc.Sitemaps, xmlSitemap{Loc: sitemap.Loc, LastMod: formatTime(sitemap.LastMod)})
+	}
+
+	return marshal(doc)
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,120 @@
+package slug
+
+import (
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// historyEntry is a row of the slug_histories table.
+type historyEntry struct {
+	ID         int64  `gorm:"id"`
+	EntityType string `gorm:"entity_type"`
+	EntityID   int64  `gorm:"entity_id"`
+	Slug       string `gorm:"slug"`
+}
+
+func (historyEntry) TableName() string {
+	return "slug_histories"
+}
+
+// Unique returns base, or base with the lowest free "-N" suffix, such that
+// the slug is not used by any other row of table nor by any other row's
+// slug history. It takes a transaction scoped advisory lock on the table so
+// concurrent writers see each other's slugs; tx must be a transaction and the
+// row must be written before it commits.
+func Unique(tx *gorm.DB, table, base string, excludeID int64) (string, error) {
+	if base == "" {
+		base = fallback
+	}
+	base = Truncate(base, MaxLength-8)
+
+	err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "slug:"+table).Error
+	if err != nil {
+		return "", err
+	}
+
+	pattern := escapeLike(base) + "-%"
+
+	var taken []string
+	err = tx.Table(table).
+		Where("(slug = ? OR slug LIKE ?) AND id <> ?", base, pattern, excludeID).
+		Pluck("slug", &taken).Error
+	if err != nil {
+		return "", err
+	}
+
+	var historic []string
+	err = tx.Model(&historyEntry{}).
+		Where("entity_type = ? AND (slug = ? OR slug LIKE ?) AND entity_id <> ?", table, base, pattern, excludeID).
+		Pluck("slug", &historic).Error
+	if err != nil {
+		return "", err
+	}
+
+	used := map[string]bool{}
+	for _, s := range append(taken, historic...) {
+		used[s] = true
+	}
+
+	if !used[base] {
+		return base, nil
+	}
+
+	for n := 2; ; n++ {
+		candidate := base + "-" + strconv.Itoa(n)
+		if !used[candidate] {
+			return candidate, nil
+		}
+	}
+}
+
+// Record stores oldSlug as a former slug of the given row so it keeps
+// resolving after a rename. If the row had previously given up newSlug and
+// is now taking it back, that history entry is removed.
+func Record(tx *gorm.DB, table string, entityID int64, oldSlug, newSlug string) error {
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+
+	err := tx.Where("entity_type = ? AND slug = ? AND entity_id = ?", table, newSlug, entityID).
+		Delete(&historyEntry{}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity_type"}, {Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"entity_id"}),
+	}).Create(&historyEntry{
+		EntityType: table,
+		EntityID:   entityID,
+		Slug:       oldSlug,
+	}).Error
+}
+
+// Repoint moves the history of the given rows onto targetID, used when rows
+// are merged into another one.
+func Repoint(tx *gorm.DB, table string, entityIDs []int64, targetID int64) error {
+	return tx.Model(&historyEntry{}).
+		Where("entity_type = ? AND entity_id IN ?", table, entityIDs).
+		Update("entity_id", targetID).Error
+}
+
+// Resolve looks a former slug up in the history and returns the id of the row
+// that owns it now.
+func Resolve(db *gorm.DB, table, slug string) (int64, error) {
+	var history historyEntry
+	err := db.Where("entity_type = ? AND slug = ?", table, slug).First(&history).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return history.EntityID, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
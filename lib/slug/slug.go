@@ -0,0 +1,108 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength matches the VARCHAR(200) slug columns.
+const MaxLength = 200
+
+const fallback = "untitled"
+
+// transliterations covers letters that do not decompose into a base letter
+// plus combining marks, and the Cyrillic and Greek alphabets.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d",
+	'ł': "l", 'þ': "th", 'ı': "i", 'ħ': "h", 'ŋ': "ng", 'ĸ': "k",
+	'&': " dan ", '@': " at ",
+
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i",
+	'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x",
+	'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y",
+	'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// Generate turns a title into a lowercase, hyphen separated slug. Latin
+// diacritics are folded to their base letter, Cyrillic and Greek are
+// transliterated, and letters of other scripts are kept as they are.
+// Everything else becomes a separator.
+func Generate(title string) string {
+	var b strings.Builder
+	pendingHyphen := false
+
+	write := func(s string) {
+		for _, r := range s {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				if pendingHyphen && b.Len() > 0 {
+					b.WriteByte('-')
+				}
+				pendingHyphen = false
+				b.WriteRune(r)
+				continue
+			}
+			if unicode.IsMark(r) && !pendingHyphen && b.Len() > 0 {
+				b.WriteRune(r)
+				continue
+			}
+			pendingHyphen = true
+		}
+	}
+
+	for _, r := range norm.NFKC.String(strings.ToLower(title)) {
+		if t, ok := transliterations[r]; ok {
+			write(t)
+			continue
+		}
+
+		if !unicode.In(r, unicode.Latin, unicode.Greek, unicode.Cyrillic) {
+			write(string(r))
+			continue
+		}
+
+		for _, d := range norm.NFD.String(string(r)) {
+			if unicode.Is(unicode.Mn, d) {
+				continue
+			}
+
+			if t, ok := transliterations[d]; ok {
+				write(t)
+				continue
+			}
+
+			write(string(d))
+		}
+	}
+
+	return Truncate(b.String(), MaxLength)
+}
+
+// Truncate shortens a slug to at most max bytes, cutting at the last hyphen
+// when possible so words are not split.
+func Truncate(slug string, max int) string {
+	if len(slug) <= max {
+		return slug
+	}
+
+	end := max
+	for end > 0 && !utf8.RuneStart(slug[end]) {
+		end--
+	}
+
+	cut := slug[:end]
+	if i := strings.LastIndexByte(cut, '-'); i > 0 {
+		cut = cut[:i]
+	}
+
+	return strings.Trim(cut, "-")
+}
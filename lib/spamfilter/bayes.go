@@ -0,0 +1,165 @@
+package spamfilter
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	LabelSpam = "SPAM"
+	LabelHam  = "HAM"
+
+	// MaxTokenLength is the longest token in bytes; longer ones are
+	// dropped.
+	MaxTokenLength = 64
+)
+
+// Classifier is a naive Bayes classifier over the set of tokens in a text.
+// It is safe for concurrent use.
+type Classifier struct {
+	mu       sync.RWMutex
+	spam     map[string]int
+	ham      map[string]int
+	spamDocs int
+	hamDocs  int
+}
+
+// NewClassifier starts a classifier from stored token and document counts.
+func NewClassifier(spam, ham map[string]int, spamDocs, hamDocs int) *Classifier {
+	if spam == nil {
+		spam = map[string]int{}
+	}
+	if ham == nil {
+		ham = map[string]int{}
+	}
+
+	return &Classifier{spam: spam, ham: ham, spamDocs: spamDocs, hamDocs: hamDocs}
+}
+
+// Tokens returns the distinct tokens of text: its words and the hosts it
+// links to.
+func Tokens(text string) []string {
+	seen := map[string]bool{}
+	tokens := []string{}
+
+	add := func(token string) {
+		if len(token) <= MaxTokenLength && !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+
+	for _, host := range Links(text) {
+		add("host:" + host)
+	}
+	for _, word := range Words(text) {
+		if n := utf8.RuneCountInString(word); n >= 2 && n <= 30 {
+			add(word)
+		}
+	}
+
+	return tokens
+}
+
+// Train adds a document with tokens to label, or removes one when delta is
+// negative, as when a moderator reverses a decision.
+func (c *Classifier) Train(label string, tokens []string, delta int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := c.ham
+	if label == LabelSpam {
+		counts = c.spam
+		c.spamDocs = max(c.spamDocs+delta, 0)
+	} else {
+		c.hamDocs = max(c.hamDocs+delta, 0)
+	}
+
+	for _, token := range tokens {
+		counts[token] = max(counts[token]+delta, 0)
+	}
+}
+
+// Documents returns how many spam and ham documents the classifier learned
+// from.
+func (c *Classifier) Documents() (spam, ham int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.spamDocs, c.hamDocs
+}
+
+// SpamProbability returns the probability that a text with tokens is spam,
+// with add-one smoothing so unseen tokens are neutral. Tokens seen in
+// neither class are ignored.
+func (c *Classifier) SpamProbability(tokens []string) float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.spamDocs == 0 || c.hamDocs == 0 {
+		return 0.5
+	}
+
+	total := float64(c.spamDocs + c.hamDocs)
+	logSpam := math.Log(float64(c.spamDocs) / total)
+	logHam := math.Log(float64(c.hamDocs) / total)
+
+	for _, token := range tokens {
+		spam, ham := c.spam[token], c.ham[token]
+		if spam == 0 && ham == 0 {
+			continue
+		}
+
+		logSpam += math.Log(float64(spam+1) / float64(c.spamDocs+2))
+		logHam += math.Log(float64(ham+1) / float64(c.hamDocs+2))
+	}
+
+	return 1 / (1 + math.Exp(logHam-logSpam))
+}
+
+// TopTokens lists up to n of the tokens that pushed a text the most towards
+// spam, for explaining a score.
+func (c *Classifier) TopTokens(tokens []string, n int) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	type weighted struct {
+		token  string
+		weight float64
+	}
+	top := []weighted{}
+	for _, token := range tokens {
+		spam, ham := c.spam[token], c.ham[token]
+		if spam == 0 {
+			continue
+		}
+
+		weight := math.Log(float64(spam+1)/float64(c.spamDocs+2)) - math.Log(float64(ham+1)/float64(c.hamDocs+2))
+		if weight <= 0 {
+			continue
+		}
+
+		top = append(top, weighted{token: token, weight: weight})
+		for i := len(top) - 1; i > 0 && top[i].weight > top[i-1].weight; i-- {
+			top[i], top[i-1] = top[i-1], top[i]
+		}
+		if len(top) > n {
+			top = top[:n]
+		}
+	}
+
+	res := []string{}
+	for _, val := range top {
+		res = append(res, val.token)
+	}
+
+	return res
+}
+
+// Normalize lower cases text and collapses its whitespace, so copies that
+// only differ in spacing or case compare equal.
+func Normalize(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
@@ -0,0 +1,118 @@
+package spamfilter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// LinkFilter flags comments with more than Max links. Each link past the
+// limit adds to the score.
+type LinkFilter struct {
+	Max int
+}
+
+func (f LinkFilter) Name() string {
+	return "links"
+}
+
+func (f LinkFilter) Check(_ context.Context, in Input) (Verdict, error) {
+	links := len(Links(in.Body))
+	if links <= f.Max {
+		return Verdict{}, nil
+	}
+
+	return Verdict{
+		Score:  0.5 + 0.15*float64(links-f.Max),
+		Reason: fmt.Sprintf("%d links, at most %d allowed", links, f.Max),
+	}, nil
+}
+
+// BlocklistFilter rejects comments linking to a blocked domain, or whose
+// body, author name or email contains a blocked phrase.
+type BlocklistFilter struct {
+	Domains []string
+	Phrases []string
+}
+
+func (f BlocklistFilter) Name() string {
+	return "blocklist"
+}
+
+func (f BlocklistFilter) Check(_ context.Context, in Input) (Verdict, error) {
+	for _, host := range Links(in.Body) {
+		for _, domain := range f.Domains {
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return Verdict{Score: 1, Reason: "links to blocked domain " + domain}, nil
+			}
+		}
+	}
+
+	text := strings.ToLower(in.Body + "\n" + in.Author + "\n" + in.Email)
+	for _, phrase := range f.Phrases {
+		if strings.Contains(text, phrase) {
+			return Verdict{Score: 1, Reason: "contains blocked phrase " + phrase}, nil
+		}
+	}
+
+	return Verdict{}, nil
+}
+
+// NewBlocklistFilter sorts entries into domains and phrases: anything that
+// looks like a host name is a domain.
+func NewBlocklistFilter(entries []string) BlocklistFilter {
+	var f BlocklistFilter
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+		case !strings.ContainsAny(entry, " /") && strings.Contains(entry, "."):
+			f.Domains = append(f.Domains, strings.TrimPrefix(entry, "www."))
+		default:
+			f.Phrases = append(f.Phrases, entry)
+		}
+	}
+
+	return f
+}
+
+// WordListFilter holds comments using a listed word for review. Words are
+// compared after normalization by Words.
+type WordListFilter struct {
+	words map[string]bool
+}
+
+func NewWordListFilter(words []string) WordListFilter {
+	f := WordListFilter{words: map[string]bool{}}
+	for _, word := range words {
+		for _, normalized := range Words(word) {
+			f.words[normalized] = true
+		}
+	}
+
+	return f
+}
+
+func (f WordListFilter) Name() string {
+	return "wordlist"
+}
+
+func (f WordListFilter) Check(_ context.Context, in Input) (Verdict, error) {
+	found := []string{}
+	seen := map[string]bool{}
+	for _, word := range Words(in.Body + " " + in.Author) {
+		if f.words[word] && !seen[word] {
+			seen[word] = true
+			found = append(found, word)
+		}
+	}
+
+	if len(found) == 0 {
+		return Verdict{}, nil
+	}
+
+	return Verdict{
+		Score:  min(0.6+0.1*float64(len(found)-1), 0.9),
+		Reason: "contains listed words: " + strings.Join(found, ", "),
+	}, nil
+}
@@ -0,0 +1,109 @@
+package spamfilter
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Input is what filters look at in a comment.
+type Input struct {
+	Body   string
+	Author string
+	Email  string
+	IP     string
+}
+
+// Verdict is the outcome of one filter. Score is the probability, between 0
+// and 1, that the comment is spam or abuse according to that filter.
+type Verdict struct {
+	Filter string
+	Score  float64
+	Reason string
+}
+
+// Filter checks a comment. Filters are independent of each other, so new
+// ones can be added to a Chain without touching the others.
+type Filter interface {
+	Name() string
+	Check(ctx context.Context, in Input) (Verdict, error)
+}
+
+type Chain []Filter
+
+// Result holds the combined score of a chain and the verdict of every
+// filter that ran.
+type Result struct {
+	Score    float64
+	Verdicts []Verdict
+}
+
+// Run runs every filter and combines their scores as independent
+// probabilities: the comment passes only if it passes all of them. A filter
+// that fails is recorded with a zero score rather than blocking the comment.
+func (c Chain) Run(ctx context.Context, in Input) Result {
+	res := Result{Verdicts: []Verdict{}}
+	pass := 1.0
+
+	for _, filter := range c {
+		verdict, err := filter.Check(ctx, in)
+		if err != nil {
+			verdict = Verdict{Reason: "error: " + err.Error()}
+		}
+		verdict.Filter = filter.Name()
+		verdict.Score = min(max(verdict.Score, 0), 1)
+
+		pass *= 1 - verdict.Score
+		res.Verdicts = append(res.Verdicts, verdict)
+	}
+
+	res.Score = 1 - pass
+	return res
+}
+
+var urlPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)([a-z0-9.-]+\.[a-z]{2,})[^\s]*`)
+
+// Links returns the host of every link in text.
+func Links(text string) []string {
+	hosts := []string{}
+	for _, match := range urlPattern.FindAllStringSubmatch(text, -1) {
+		hosts = append(hosts, strings.TrimPrefix(strings.ToLower(match[1]), "www."))
+	}
+
+	return hosts
+}
+
+// leet maps the digits and symbols used to dodge word lists to the letters
+// they stand for.
+var leet = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s")
+
+// Words splits text into lower case words, undoing common letter
+// substitutions and squeezing repeated letters, so "anjiiing" and "4njing"
+// both become "anjing".
+func Words(text string) []string {
+	words := strings.FieldsFunc(leet.Replace(strings.ToLower(text)), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	for i, word := range words {
+		words[i] = squeeze(word)
+	}
+
+	return words
+}
+
+// squeeze collapses runs of the same letter into one.
+func squeeze(word string) string {
+	var b strings.Builder
+	var last rune
+	for i, r := range word {
+		if i > 0 && r == last {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+
+	return b.String()
+}
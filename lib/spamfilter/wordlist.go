@@ -0,0 +1,13 @@
+package spamfilter
+
+// DefaultWords is a starting list of Indonesian and English profanity and
+// slurs. Sites extend it through configuration rather than editing it.
+var DefaultWords = []string{
+	// Indonesian
+	"anjing", "anjir", "asu", "babi", "bajingan", "bangsat", "bego", "bencong", "brengsek",
+	"goblok", "idiot", "jancok", "jancuk", "kampret", "keparat", "kontol", "lonte", "memek",
+	"ngentot", "pelacur", "perek", "tai", "tolol", "sialan",
+	// English
+	"asshole", "bastard", "bitch", "bullshit", "cunt", "dick", "fag", "faggot", "fuck",
+	"fucker", "fucking", "motherfucker", "nigger", "pussy", "retard", "shit", "slut", "whore",
+}
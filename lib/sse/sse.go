@@ -0,0 +1,148 @@
+package sse
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// subscriberBuffer is how many events a subscriber may lag behind before
+// it is dropped. A dropped client reconnects with Last-Event-ID and catches
+// up from the stored events.
+const subscriberBuffer = 64
+
+type Event struct {
+	ID   int64
+	Type string
+	Data string
+}
+
+// Write writes event in the text/event-stream format. Data spanning several
+// lines is sent as several data fields, as the format requires.
+func Write(w *bufio.Writer, event Event) error {
+	if event.ID > 0 {
+		fmt.Fprintf(w, "id: %d\n", event.ID)
+	}
+	if event.Type != "" {
+		fmt.Fprintf(w, "event: %s\n", event.Type)
+	}
+	for _, line := range strings.Split(event.Data, "\n") {
+		fmt.Fprintf(w, "data: %s\n", line)
+	}
+	w.WriteString("\n")
+
+	return w.Flush()
+}
+
+// WriteComment writes a comment line, which clients ignore. It keeps idle
+// connections from being closed by proxies and detects gone clients.
+func WriteComment(w *bufio.Writer, comment string) error {
+	fmt.Fprintf(w, ": %s\n\n", comment)
+	return w.Flush()
+}
+
+// WriteRetry tells the client how many milliseconds to wait before it
+// reconnects.
+func WriteRetry(w *bufio.Writer, ms int) error {
+	fmt.Fprintf(w, "retry: %d\n\n", ms)
+	return w.Flush()
+}
+
+type Subscription struct {
+	C <-chan Event
+
+	hub   *Hub
+	topic string
+	ch    chan Event
+}
+
+// Close stops the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.hub.remove(s.topic, s.ch)
+}
+
+// Hub fans out the events published to a topic to its subscribers.
+type Hub struct {
+	mu     sync.Mutex
+	topics map[string]map[chan Event]bool
+	closed bool
+}
+
+func NewHub() *Hub {
+	return &Hub{topics: map[string]map[chan Event]bool{}}
+}
+
+// Subscribe starts receiving the events of topic. The channel is closed
+// when the subscriber falls too far behind or the hub is closed.
+func (h *Hub) Subscribe(topic string) *Subscription {
+	ch := make(chan Event, subscriberBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(ch)
+	} else {
+		if h.topics[topic] == nil {
+			h.topics[topic] = map[chan Event]bool{}
+		}
+		h.topics[topic][ch] = true
+	}
+
+	return &Subscription{C: ch, hub: h, topic: topic, ch: ch}
+}
+
+// Publish sends event to every subscriber of topic without blocking.
+func (h *Hub) Publish(topic string, event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.topics[topic] {
+		select {
+		case ch <- event:
+		default:
+			delete(h.topics[topic], ch)
+			close(ch)
+		}
+	}
+	if len(h.topics[topic]) == 0 {
+		delete(h.topics, topic)
+	}
+}
+
+// Subscribers returns the number of subscribers of topic.
+func (h *Hub) Subscribers(topic string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.topics[topic])
+}
+
+// Close ends every subscription, so open streams finish and the server
+// can shut down.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for topic, subscribers := range h.topics {
+		for ch := range subscribers {
+			close(ch)
+		}
+		delete(h.topics, topic)
+	}
+}
+
+func (h *Hub) remove(topic string, ch chan Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.topics[topic][ch] {
+		delete(h.topics[topic], ch)
+		close(ch)
+	}
+	if len(h.topics[topic]) == 0 {
+		delete(h.topics, topic)
+	}
+}
@@ -0,0 +1,21 @@
+package useragent
+
+import (
+	"regexp"
+	"strings"
+)
+
+// botPattern matches crawlers, link preview fetchers, monitoring services
+// and HTTP libraries. Browsers never send any of these words.
+var botPattern = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|archiver|fetcher|scraper|facebookexternalhit|embedly|quora link preview|whatsapp|telegram|skypeuripreview|bitlybot|headless|phantomjs|puppeteer|playwright|selenium|lighthouse|pingdom|uptime|monitor|statuscake|curl|wget|httpie|python-|go-http-client|java/|okhttp|axios|node-fetch|libwww|scrapy|feedfetcher|feedly|inoreader`)
+
+// IsBot reports whether ua looks like an automated client. An empty user
+// agent counts as one, since every browser sends it.
+func IsBot(ua string) bool {
+	ua = strings.TrimSpace(ua)
+	if ua == "" {
+		return true
+	}
+
+	return botPattern.MatchString(ua)
+}
@@ -25,7 +25,13 @@ func ValidateStruct(s interface{}) error {
 			case "min":
 				if err.Field() == "password" {
 					errMessage = append(errMessage, "Password must be at least 8 characters")
+				} else {
+					errMessage = append(errMessage, "Field "+err.Field()+" must be at least "+err.Param())
 				}
+			case "max":
+				errMessage = append(errMessage, "Field "+err.Field()+" must be at most "+err.Param())
+			case "oneof":
+				errMessage = append(errMessage, "Field "+err.Field()+" must be one of "+err.Param())
 			case "eqfield":
 				errMessage = append(errMessage, err.Field()+" must be equal to "+err.Param())
 			case "default":
@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	secret := "secret"
+	timestamp := int64(1700000000)
+	body := []byte(`{"event":"content.published","id":1}`)
+
+	signature := Sign(secret, timestamp, body)
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		t.Fatalf("Sign() = %q, want the %q prefix", signature, signaturePrefix)
+	}
+	if !Verify(secret, signature, timestamp, body) {
+		t.Fatal("Verify() rejected the signature Sign() gave")
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp int64
+		body      []byte
+	}{
+		{name: "other secret", secret: "other", signature: signature, timestamp: timestamp, body: body},
+		{name: "other timestamp", secret: secret, signature: signature, timestamp: timestamp + 1, body: body},
+		{name: "other body", secret: secret, signature: signature, timestamp: timestamp, body: []byte(`{"event":"content.published","id":2}`)},
+		{name: "no prefix", secret: secret, signature: strings.TrimPrefix(signature, signaturePrefix), timestamp: timestamp, body: body},
+		{name: "empty", secret: secret, signature: "", timestamp: timestamp, body: body},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Verify(tt.secret, tt.signature, tt.timestamp, tt.body) {
+				t.Error("Verify() accepted a signature that does not match")
+			}
+		})
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	first, err := NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(first) != 64 {
+		t.Errorf("len(NewSecret()) = %d, want 64", len(first))
+	}
+	if first == second {
+		t.Error("NewSecret() returned the same secret twice")
+	}
+}